@@ -0,0 +1,71 @@
+package dbcmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alexisvisco/goframe/db/migrate"
+	"github.com/spf13/cobra"
+)
+
+func lintCmd() *cobra.Command {
+	var dir string
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check SQL migrations for common mistakes",
+		Long: `Check SQL migration files for common mistakes before they are applied:
+missing -- migrate:down sections, DROP TABLE without IF EXISTS, CREATE TABLE without
+IF NOT EXISTS and timestamps shared by several files.
+
+The command fails when an error is found, or when a warning is found with --strict.
+
+Examples:
+  # Lint migrations in db/migrations
+  goframe db lint
+
+  # Fail on warnings too, useful in CI
+  goframe db lint --strict`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			issues, err := migrate.LintDir(os.DirFS("."), dir)
+			if err != nil {
+				return err
+			}
+
+			if len(issues) == 0 {
+				fmt.Println("No issues found")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
+			fmt.Fprintf(w, "SEVERITY\tFILE\tRULE\tMESSAGE\n")
+
+			var errorsCount, warningsCount int
+			for _, issue := range issues {
+				if issue.Severity == migrate.LintSeverityError {
+					errorsCount++
+				} else {
+					warningsCount++
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", issue.Severity, issue.File, issue.Rule, issue.Message)
+			}
+
+			w.Flush()
+
+			fmt.Println()
+			fmt.Printf("Total: %d issues (%d errors, %d warnings)\n", len(issues), errorsCount, warningsCount)
+
+			if errorsCount > 0 || (strict && warningsCount > 0) {
+				return fmt.Errorf("migration lint failed")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&dir, "dir", "d", "db/migrations", "Directory containing the SQL migrations")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail when warnings are found")
+
+	return cmd
+}
@@ -14,6 +14,7 @@ func NewCmdRootMigrate() *cobra.Command {
 	cmd.AddCommand(rollbackCmd())
 	cmd.AddCommand(statusCmd())
 	cmd.AddCommand(cleanCmd())
+	cmd.AddCommand(lintCmd())
 	cmd.AddCommand(seedsRootCmd())
 
 	return cmd
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	"github.com/alexisvisco/goframe/db/migrate"
 	"github.com/spf13/cobra"
@@ -38,45 +39,40 @@ Examples:
 
 			migrator := migrate.New(db)
 
-			// Get all applied migrations
-			applied, err := migrator.Applied(cmd.Context())
+			statuses, err := migrator.Status(cmd.Context(), migrations)
 			if err != nil {
-				return fmt.Errorf("failed to get applied migrations: %w", err)
+				return fmt.Errorf("failed to get migration status: %w", err)
 			}
 
-			// Create a map for quick lookup
-			appliedMap := make(map[string]bool)
-			for _, version := range applied {
-				appliedMap[version] = true
-			}
-
-			// Show status header
 			// Create tabwriter for aligned output
 			w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, ' ', 0)
 
 			// Print header
-			fmt.Fprintf(w, "STATUS\tMIGRATION NAME\n")
-
-			// Check each migration
-			for _, migration := range migrations {
-				name, at := migration.Version()
-				version := fmt.Sprintf("%s_%s", at.UTC().Format("20060102150405"), name)
+			fmt.Fprintf(w, "STATUS\tMIGRATION NAME\tAPPLIED AT\n")
+
+			applied := 0
+			for _, status := range statuses {
+				label := "PENDING"
+				if status.Applied {
+					label = "APPLIED"
+					applied++
+				}
 
-				status := "PENDING"
-				if appliedMap[version] {
-					status = "APPLIED"
+				appliedAt := "-"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format(time.DateTime)
 				}
 
-				fmt.Fprintf(w, "%s\t%s\n", status, name)
+				fmt.Fprintf(w, "%s\t%s\t%s\n", label, status.Name, appliedAt)
 			}
 
 			w.Flush()
 
 			fmt.Println()
 			fmt.Printf("Total: %d migrations (%d applied, %d pending)\n",
-				len(migrations),
-				len(applied),
-				len(migrations)-len(applied))
+				len(statuses),
+				applied,
+				len(statuses)-applied)
 
 			return nil
 		},
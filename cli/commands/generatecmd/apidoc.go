@@ -0,0 +1,88 @@
+package generatecmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexisvisco/goframe/cli/generators/genapidoc"
+	"github.com/alexisvisco/goframe/cli/generators/genhelper"
+	"github.com/spf13/cobra"
+)
+
+func apiDocCmd() *cobra.Command {
+	var flagFile string
+	var flagDir string
+	var flagPkg string
+	cmd := &cobra.Command{
+		Use:   "api-doc",
+		Short: "Generate Markdown API documentation for routes",
+		Long: `Generate Markdown documentation describing the method, path, headers, request fields and responses of each route.
+
+Examples:
+  # Single document
+  goframe generate api-doc --file docs/api.md
+
+  # One document per route with an index.md and a types.md
+  goframe generate api-doc --dir docs/api`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workdir, _ := cmd.Context().Value("workdir").(string)
+			packages, err := genhelper.CollectRootHandlerPackages(workdir)
+			if err != nil {
+				return fmt.Errorf("failed to collect root handler packages: %w", err)
+			}
+
+			var paths []string
+			for _, pkg := range packages {
+				if pkg.Path == flagPkg {
+					paths = append(paths, pkg.Path)
+					paths = append(paths, pkg.Subfolders...)
+					break
+				}
+			}
+
+			if len(paths) == 0 {
+				return fmt.Errorf("no package found with name %s", flagPkg)
+			}
+
+			routes, err := genhelper.CollectRoutesDocumentation(workdir, paths)
+			if err != nil {
+				return err
+			}
+
+			generator := genapidoc.NewMarkdownGenerator()
+			for _, r := range routes {
+				generator.AddRoute(*r)
+			}
+
+			if flagDir != "" {
+				if err := os.MkdirAll(flagDir, 0755); err != nil {
+					return fmt.Errorf("failed to create directory %s: %w", flagDir, err)
+				}
+				for name, content := range generator.Files() {
+					filename := filepath.Join(flagDir, name)
+					if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+						return fmt.Errorf("failed to write API doc file %s: %w", filename, err)
+					}
+				}
+				return nil
+			}
+
+			if flagFile != "" {
+				if err := os.WriteFile(flagFile, []byte(generator.File()), 0644); err != nil {
+					return fmt.Errorf("failed to write API doc file %s: %w", flagFile, err)
+				}
+				return nil
+			}
+
+			fmt.Println(generator.File())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flagFile, "file", "f", "", "Output file for a single Markdown document")
+	cmd.Flags().StringVarP(&flagDir, "dir", "d", "", "Output directory for one Markdown document per route")
+	cmd.Flags().StringVarP(&flagPkg, "pkg", "p", "internal/v1handler", "Package name where routes are defined")
+
+	return cmd
+}
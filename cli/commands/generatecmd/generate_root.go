@@ -22,7 +22,10 @@ func NewCmdRootGenerate(subCommands ...*cobra.Command) *cobra.Command {
 	cmd.AddCommand(moduleCmd())
 	cmd.AddCommand(scaffoldCmd())
 	cmd.AddCommand(urlHelperCmd())
+	cmd.AddCommand(routeTestCmd())
 	cmd.AddCommand(tsclientCmd())
+	cmd.AddCommand(apiDocCmd())
+	cmd.AddCommand(openAPICmd())
 	for _, subCmd := range subCommands {
 		cmd.AddCommand(subCmd)
 	}
@@ -0,0 +1,73 @@
+package generatecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexisvisco/goframe/cli/generators/genapidoc"
+	"github.com/alexisvisco/goframe/cli/generators/genhelper"
+	"github.com/spf13/cobra"
+)
+
+func openAPICmd() *cobra.Command {
+	var flagFile string
+	var flagPkg string
+	var flagTitle string
+	var flagVersion string
+	cmd := &cobra.Command{
+		Use:   "openapi",
+		Short: "Generate an OpenAPI 3.1 document for routes",
+		Long: `Generate an OpenAPI 3.1 document describing the parameters, request body and responses of each route.
+
+Examples:
+  goframe generate openapi --file openapi.json --title "Acme API" --version 1.0.0`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workdir, _ := cmd.Context().Value("workdir").(string)
+			packages, err := genhelper.CollectRootHandlerPackages(workdir)
+			if err != nil {
+				return fmt.Errorf("failed to collect root handler packages: %w", err)
+			}
+
+			var paths []string
+			for _, pkg := range packages {
+				if pkg.Path == flagPkg {
+					paths = append(paths, pkg.Path)
+					paths = append(paths, pkg.Subfolders...)
+					break
+				}
+			}
+
+			if len(paths) == 0 {
+				return fmt.Errorf("no package found with name %s", flagPkg)
+			}
+
+			routes, err := genhelper.CollectRoutesDocumentation(workdir, paths)
+			if err != nil {
+				return err
+			}
+
+			generator := genapidoc.NewOpenAPIGenerator()
+			generator.SetInfo(flagTitle, flagVersion)
+			for _, r := range routes {
+				generator.AddRoute(*r)
+			}
+
+			if flagFile != "" {
+				if err := os.WriteFile(flagFile, []byte(generator.File()), 0644); err != nil {
+					return fmt.Errorf("failed to write OpenAPI document %s: %w", flagFile, err)
+				}
+				return nil
+			}
+
+			fmt.Print(generator.File())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&flagFile, "file", "f", "", "Output file for the OpenAPI document, e.g. openapi.json")
+	cmd.Flags().StringVarP(&flagPkg, "pkg", "p", "internal/v1handler", "Package name where routes are defined")
+	cmd.Flags().StringVar(&flagTitle, "title", "API", "Title of the API in the document info")
+	cmd.Flags().StringVar(&flagVersion, "version", "0.1.0", "Version of the API in the document info")
+
+	return cmd
+}
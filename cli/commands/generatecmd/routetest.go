@@ -0,0 +1,27 @@
+package generatecmd
+
+import (
+	"fmt"
+
+	"github.com/alexisvisco/goframe/cli/generators"
+	"github.com/alexisvisco/goframe/cli/generators/genhelper"
+	"github.com/alexisvisco/goframe/cli/generators/genroutetest"
+	"github.com/spf13/cobra"
+)
+
+func routeTestCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route-test",
+		Short: "Generate httptest based test stubs for routes",
+		Long:  "Generate a <handler>_test.go file next to each handler with a test per route. Existing test files are not overwritten.",
+		RunE: genhelper.WithFileDiff(func(cmd *cobra.Command, args []string) error {
+			g := cmd.Context().Value("generator").(*generators.Generator)
+			gen := genroutetest.RouteTestGenerator{Gen: g}
+			if err := gen.Generate(); err != nil {
+				return fmt.Errorf("failed to generate route tests: %w", err)
+			}
+			return nil
+		}),
+	}
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/alexisvisco/goframe/cli/generators/genhelper"
@@ -17,6 +18,20 @@ import (
 func tsclientCmd() *cobra.Command {
 	var flagFile string
 	var flagPkg string
+	var flagMSWFile string
+	var flagColumnMetadata bool
+	var flagBasePath string
+	var flagTrimTrailingSlash bool
+	var flagUnknownForAny bool
+	var flagPackageName string
+	var flagPackageVersion string
+	var flagOpenResponseEnums bool
+	var flagEnumSnapshot string
+	var flagReactQuery bool
+	var flagOutDir string
+	var flagEnumStyle string
+	var flagZodImportPath string
+	var flagFetcherTemplate string
 	cmd := &cobra.Command{
 		Use: "client [packages...]",
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -57,7 +72,30 @@ func tsclientCmd() *cobra.Command {
 
 			prefixMap := collectTypePrefixes(routes, rootImportPath)
 
+			enumStyle := gentsclient.EnumStyle(flagEnumStyle)
+			if enumStyle != gentsclient.EnumStyleConst && enumStyle != gentsclient.EnumStyleEnum {
+				return fmt.Errorf("invalid enum style %q, expected const or enum", flagEnumStyle)
+			}
+
 			generator := gentsclient.NewTypescriptClientGenerator(rootImportPath, prefixMap)
+			generator.EnableColumnMetadata(flagColumnMetadata)
+			generator.SetBasePath(flagBasePath)
+			generator.SetTrimTrailingSlash(flagTrimTrailingSlash)
+			generator.SetUnknownForAny(flagUnknownForAny)
+			generator.SetOpenResponseEnums(flagOpenResponseEnums)
+			generator.SetReactQuery(flagReactQuery)
+			generator.SetEnumStyle(enumStyle)
+			generator.SetZodImportPath(flagZodImportPath)
+			if flagFetcherTemplate != "" {
+				fetcher, err := os.ReadFile(flagFetcherTemplate)
+				if err != nil {
+					return fmt.Errorf("failed to read fetcher template %s: %w", flagFetcherTemplate, err)
+				}
+				generator.SetFetcherTemplate(fetcher)
+			}
+			mswGenerator := gentsclient.NewMSWGenerator()
+			mswGenerator.SetBasePath(flagBasePath)
+			mswGenerator.SetTrimTrailingSlash(flagTrimTrailingSlash)
 
 			for _, r := range routes {
 				if r.Request != nil {
@@ -69,6 +107,61 @@ func tsclientCmd() *cobra.Command {
 					}
 				}
 				generator.AddRoute(*r)
+				mswGenerator.AddRoute(*r)
+			}
+
+			if flagEnumSnapshot != "" {
+				previous, err := gentsclient.ReadEnumSnapshot(flagEnumSnapshot)
+				if err != nil {
+					return err
+				}
+				current := generator.EnumSnapshot()
+				for _, change := range gentsclient.DiffEnumSnapshots(previous, current) {
+					fmt.Fprintf(os.Stderr, "warning: %s\n", change)
+				}
+				if err := gentsclient.WriteEnumSnapshot(flagEnumSnapshot, current); err != nil {
+					return err
+				}
+			}
+
+			if flagMSWFile != "" {
+				if err := os.WriteFile(flagMSWFile, []byte(mswGenerator.File()), 0644); err != nil {
+					return fmt.Errorf("failed to write MSW handlers file %s: %w", flagMSWFile, err)
+				}
+			}
+
+			if flagPackageName != "" {
+				if flagFile == "" {
+					return fmt.Errorf("--package-name requires --file to know where to write the package files")
+				}
+				files, err := gentsclient.PackageFiles(gentsclient.PackageOptions{
+					Name:       flagPackageName,
+					Version:    flagPackageVersion,
+					ClientFile: flagFile,
+					ReactQuery: flagReactQuery,
+				})
+				if err != nil {
+					return err
+				}
+				for name, content := range files {
+					filename := filepath.Join(filepath.Dir(flagFile), name)
+					if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+						return fmt.Errorf("failed to write package file %s: %w", filename, err)
+					}
+				}
+			}
+
+			if flagOutDir != "" {
+				if err := os.MkdirAll(flagOutDir, 0755); err != nil {
+					return fmt.Errorf("failed to create output directory %s: %w", flagOutDir, err)
+				}
+				for name, content := range generator.Files() {
+					filename := filepath.Join(flagOutDir, name)
+					if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+						return fmt.Errorf("failed to write client file %s: %w", filename, err)
+					}
+				}
+				return nil
 			}
 
 			if flagFile != "" {
@@ -91,6 +184,20 @@ func tsclientCmd() *cobra.Command {
 
 	cmd.Flags().StringVarP(&flagFile, "file", "f", "", "Output file for the generated TypeScript client code")
 	cmd.Flags().StringVarP(&flagPkg, "pkg", "p", "internal/v1handler", "Package name where routes are defined")
+	cmd.Flags().StringVar(&flagMSWFile, "msw-file", "", "Output file for generated MSW (Mock Service Worker) request handlers")
+	cmd.Flags().StringVar(&flagBasePath, "base-path", "", "Path prefix prepended to every route, e.g. /api/v1 when mounted behind a gateway")
+	cmd.Flags().BoolVar(&flagTrimTrailingSlash, "trim-trailing-slash", false, "Remove trailing slashes from route paths, for servers with server.ignore_trailing_slash")
+	cmd.Flags().BoolVar(&flagUnknownForAny, "unknown-for-any", false, "Emit z.unknown() instead of z.any() for empty interface fields")
+	cmd.Flags().StringVar(&flagPackageName, "package-name", "", "Emit package.json and tsconfig.json next to the client file to publish it as this npm package")
+	cmd.Flags().StringVar(&flagPackageVersion, "package-version", "0.1.0", "Version of the npm package emitted with --package-name")
+	cmd.Flags().BoolVar(&flagOpenResponseEnums, "open-response-enums", false, "Accept unknown enum values in response schemas, request enums stay closed")
+	cmd.Flags().StringVar(&flagEnumSnapshot, "enum-snapshot", "", "JSON file recording enum values, removed values since the previous run are reported")
+	cmd.Flags().BoolVar(&flagReactQuery, "react-query", false, "Emit a TanStack Query hook per route, useQuery for GET routes and useMutation for others")
+	cmd.Flags().StringVar(&flagOutDir, "out-dir", "", "Output directory for the client split into schemas.ts, routes.ts and index.ts, instead of a single file")
+	cmd.Flags().StringVar(&flagEnumStyle, "enum-style", "const", "Declaration of enums: const for const objects with a union type, enum for TypeScript enums")
+	cmd.Flags().StringVar(&flagZodImportPath, "zod-import-path", "zod", "Module z and ZodSchema are imported from, e.g. a re-export with project defaults")
+	cmd.Flags().StringVar(&flagFetcherTemplate, "fetcher-template", "", "TypeScript file emitted instead of the embedded fetcher, it must export the same types and helpers")
+	cmd.Flags().BoolVar(&flagColumnMetadata, "column-metadata", false, "Emit sortable/filterable column metadata for response types")
 
 	return cmd
 }
@@ -0,0 +1,424 @@
+package genapidoc
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/core/helpers/str"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+// MarkdownGenerator generates Markdown API documentation from routes, the same routes the TypeScript client is
+// generated from. Each endpoint documents its method, path, headers, request fields and the response of each status.
+// Object types are documented once in a Types section and linked from the endpoints.
+type MarkdownGenerator struct {
+	endpoints map[string]string                 // "path METHOD" -> endpoint section
+	filenames map[string]string                 // "path METHOD" -> file name used by Files
+	types     map[string]*introspect.ObjectType // type name -> object, documented in the Types section
+}
+
+func NewMarkdownGenerator() *MarkdownGenerator {
+	return &MarkdownGenerator{
+		endpoints: make(map[string]string),
+		filenames: make(map[string]string),
+		types:     make(map[string]*introspect.ObjectType),
+	}
+}
+
+func (gen *MarkdownGenerator) AddRoute(route apidoc.Route) {
+	for path, methods := range route.Paths {
+		for _, method := range methods {
+			key := path + " " + method
+			gen.endpoints[key] = gen.buildEndpoint(route, path, method)
+			gen.filenames[key] = endpointFilename(route, path, method)
+		}
+	}
+}
+
+// File returns a single document with every endpoint sorted by path and method, followed by the types.
+func (gen *MarkdownGenerator) File() string {
+	var sb strings.Builder
+	sb.WriteString("# API\n\n")
+
+	keys := gen.sortedEndpoints()
+	for _, key := range keys {
+		sb.WriteString(fmt.Sprintf("- [`%s`](#%s)\n", endpointTitle(key), anchor(endpointTitle(key))))
+	}
+	sb.WriteString("\n")
+
+	for _, key := range keys {
+		sb.WriteString(gen.endpoints[key])
+	}
+
+	sb.WriteString(gen.typesSection())
+	return sb.String()
+}
+
+// Files returns a document per endpoint keyed by file name, plus an index.md listing them and a types.md
+// documenting the object types.
+func (gen *MarkdownGenerator) Files() map[string]string {
+	files := make(map[string]string)
+
+	var index strings.Builder
+	index.WriteString("# API\n\n")
+	for _, key := range gen.sortedEndpoints() {
+		filename := gen.filenames[key]
+		index.WriteString(fmt.Sprintf("- [`%s`](%s)\n", endpointTitle(key), filename))
+		files[filename] = gen.endpoints[key]
+	}
+	files["index.md"] = index.String()
+
+	if len(gen.types) > 0 {
+		files["types.md"] = "# Types\n\n" + strings.TrimPrefix(gen.typesSection(), "## Types\n\n")
+	}
+
+	// links to types point to types.md when the endpoints are in separate files
+	for filename, content := range files {
+		if filename != "index.md" && filename != "types.md" {
+			files[filename] = strings.ReplaceAll(content, "](#type-", "](types.md#type-")
+		}
+	}
+
+	return files
+}
+
+func (gen *MarkdownGenerator) sortedEndpoints() []string {
+	keys := make([]string, 0, len(gen.endpoints))
+	for key := range gen.endpoints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (gen *MarkdownGenerator) buildEndpoint(route apidoc.Route, path, method string) string {
+	var sb strings.Builder
+	title := endpointTitle(path + " " + method)
+	sb.WriteString(fmt.Sprintf("<a id=\"%s\"></a>\n\n## %s\n\n", anchor(title), title))
+
+	handler := route.Name
+	if route.ParentStructName != nil {
+		handler = *route.ParentStructName + "." + route.Name
+	}
+	sb.WriteString(fmt.Sprintf("Handler: `%s`\n\n", handler))
+	if name, ok := route.NamedRoutes[path][method]; ok {
+		sb.WriteString(fmt.Sprintf("Route name: `%s`\n\n", name))
+	}
+	if len(route.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags: %s\n\n", strings.Join(route.Tags, ", ")))
+	}
+
+	if len(route.RequiredHeaders) > 0 || len(route.OptionalHeaders) > 0 {
+		sb.WriteString("### Headers\n\n")
+		sb.WriteString("| Name | Required |\n")
+		sb.WriteString("| --- | --- |\n")
+		for _, header := range route.RequiredHeaders {
+			sb.WriteString(fmt.Sprintf("| `%s` | yes |\n", header))
+		}
+		for _, header := range route.OptionalHeaders {
+			sb.WriteString(fmt.Sprintf("| `%s` | no |\n", header))
+		}
+		sb.WriteString("\n")
+	}
+
+	if route.Request != nil {
+		if fields := gen.requestFields(*route.Request); fields != "" {
+			sb.WriteString("### Request\n\n")
+			if contentType := requestContentType(route); contentType != "" {
+				sb.WriteString(fmt.Sprintf("Content type: `%s`\n\n", contentType))
+			}
+			sb.WriteString(fields)
+		}
+	}
+
+	if len(route.StatusToResponse) > 0 {
+		sb.WriteString("### Responses\n\n")
+		sb.WriteString("| Status | Body |\n")
+		sb.WriteString("| --- | --- |\n")
+		for _, response := range route.StatusToResponse {
+			body := "-"
+			switch {
+			case response.IsError:
+				body = "error"
+			case response.IsRedirect:
+				body = "redirect"
+			case response.Response != nil:
+				body = gen.objectLink(*response.Response)
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", statusLabel(response.StatusPattern), body))
+		}
+		sb.WriteString("\n")
+		if route.ResponseContentType != "" {
+			sb.WriteString(fmt.Sprintf("Content type: `%s`\n\n", route.ResponseContentType))
+		}
+	}
+
+	if len(route.ResponseHeaders) > 0 {
+		sb.WriteString("Response headers: ")
+		for i, header := range route.ResponseHeaders {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(fmt.Sprintf("`%s`", header))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	return sb.String()
+}
+
+// requestFields returns a table of the request fields with where each one is read from.
+func (gen *MarkdownGenerator) requestFields(request introspect.ObjectType) string {
+	locations := map[introspect.FieldKind]string{
+		introspect.FieldKindPath:   "path",
+		introspect.FieldKindQuery:  "query",
+		introspect.FieldKindHeader: "header",
+		introspect.FieldKindCookie: "cookie",
+		introspect.FieldKindJSON:   "body",
+		introspect.FieldKindForm:   "form",
+		introspect.FieldKindFile:   "form",
+		introspect.FieldKindFiles:  "form",
+	}
+
+	var rows []string
+	for _, field := range request.Fields {
+		if field.IsCtx() {
+			continue
+		}
+		for _, tag := range field.Tags {
+			location, ok := locations[tag.Key]
+			if !ok || tag.Value == "-" {
+				continue
+			}
+			name := tag.Value
+			if name == "" {
+				name = field.ExposedName()
+			}
+			rows = append(rows, fmt.Sprintf("| `%s` | %s | %s | %s |\n",
+				name, location, gen.typeLabel(field.Type, request.TypeName, field.Name), requiredLabel(field)))
+		}
+	}
+
+	if len(rows) == 0 {
+		return ""
+	}
+	return "| Name | In | Type | Required |\n| --- | --- | --- | --- |\n" + strings.Join(rows, "") + "\n"
+}
+
+func (gen *MarkdownGenerator) typesSection() string {
+	if len(gen.types) == 0 {
+		return ""
+	}
+
+	// documenting a type may register the types of its fields, loop until every type is documented
+	documented := make(map[string]string)
+	for len(documented) < len(gen.types) {
+		for name, obj := range gen.types {
+			if _, ok := documented[name]; !ok {
+				documented[name] = gen.typeDoc(name, *obj)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(documented))
+	for name := range documented {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("## Types\n\n")
+	for _, name := range names {
+		sb.WriteString(documented[name])
+	}
+
+	return sb.String()
+}
+
+func (gen *MarkdownGenerator) typeDoc(name string, obj introspect.ObjectType) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<a id=\"%s\"></a>\n\n### %s\n\n", typeAnchor(name), name))
+
+	var rows []string
+	for _, field := range obj.Fields {
+		if field.IsNotSerializable() || field.IsCtx() {
+			continue
+		}
+		rows = append(rows, fmt.Sprintf("| `%s` | %s | %s |\n",
+			field.ExposedName(), gen.typeLabel(field.Type, name, field.Name), requiredLabel(field)))
+	}
+
+	if len(rows) == 0 {
+		sb.WriteString("No fields.\n\n")
+		return sb.String()
+	}
+	sb.WriteString("| Name | Type | Required |\n| --- | --- | --- |\n")
+	sb.WriteString(strings.Join(rows, ""))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// typeLabel returns a readable type, object types are registered to be documented and linked.
+func (gen *MarkdownGenerator) typeLabel(ft introspect.FieldType, parentTypeName, fieldName string) string {
+	switch {
+	case ft.Array != nil:
+		return "array of " + gen.typeLabel(ft.Array.ItemType, parentTypeName, fieldName)
+	case ft.Map != nil:
+		return fmt.Sprintf("map of %s to %s",
+			gen.typeLabel(ft.Map.Key, parentTypeName, fieldName), gen.typeLabel(ft.Map.Value, parentTypeName, fieldName))
+	case ft.Enum != nil:
+		return "one of " + strings.Join(enumValues(*ft.Enum), ", ")
+	case ft.Object != nil && ft.Primitive != introspect.FieldTypePrimitiveFile && ft.Primitive != introspect.FieldTypePrimitiveTime &&
+		ft.Primitive != introspect.FieldTypePrimitiveUnixTime && ft.Primitive != introspect.FieldTypePrimitiveUnixMilliTime:
+		obj := *ft.Object
+		if obj.IsAnonymous {
+			obj.TypeName = shortTypeName(parentTypeName) + str.ToPascalCase(fieldName)
+		}
+		return gen.objectLink(obj)
+	}
+
+	switch ft.Primitive {
+	case introspect.FieldTypePrimitiveTime:
+		return "datetime (RFC 3339)"
+	case introspect.FieldTypePrimitiveUnixTime:
+		return "unix timestamp (seconds)"
+	case introspect.FieldTypePrimitiveUnixMilliTime:
+		return "unix timestamp (milliseconds)"
+	case introspect.FieldTypePrimitiveDuration:
+		return "duration"
+	case "":
+		return "any"
+	default:
+		return string(ft.Primitive)
+	}
+}
+
+func (gen *MarkdownGenerator) objectLink(obj introspect.ObjectType) string {
+	name := shortTypeName(obj.TypeName)
+	if _, ok := gen.types[name]; !ok {
+		gen.types[name] = &obj
+	}
+	return fmt.Sprintf("[%s](#%s)", name, typeAnchor(name))
+}
+
+func requestContentType(route apidoc.Route) string {
+	if route.RequestContentType != "" {
+		return route.RequestContentType
+	}
+	if len(route.Consumes) > 0 {
+		return strings.Join(route.Consumes, ", ")
+	}
+	if route.Request.HasFormBody() {
+		return "multipart/form-data"
+	}
+	if route.Request.HasJSONBody() {
+		return "application/json"
+	}
+	return ""
+}
+
+func requiredLabel(field introspect.Field) string {
+	if field.Optional {
+		return "no"
+	}
+	return "yes"
+}
+
+func enumValues(enum introspect.FieldTypeEnum) []string {
+	var values []string
+	for _, v := range enum.KeyValuesString {
+		values = append(values, fmt.Sprintf("`%s`", v))
+	}
+	ints := make([]int, 0, len(enum.KeyValuesInt))
+	for _, v := range enum.KeyValuesInt {
+		ints = append(ints, v)
+	}
+	slices.Sort(values)
+	slices.Sort(ints)
+	for _, v := range ints {
+		values = append(values, fmt.Sprintf("`%d`", v))
+	}
+	return values
+}
+
+// statusLabel returns the status codes matched by pattern, 2xx for a whole class and 200-204 for other ranges.
+func statusLabel(pattern *regexp.Regexp) string {
+	if pattern == nil {
+		return "default"
+	}
+
+	var labels []string
+	for _, r := range statusRanges(pattern) {
+		start, end := r[0], r[1]
+		switch {
+		case start == end:
+			labels = append(labels, strconv.Itoa(start))
+		case start%100 == 0 && end%100 == 99:
+			for class := start / 100; class <= end/100; class++ {
+				labels = append(labels, fmt.Sprintf("%dxx", class))
+			}
+		default:
+			labels = append(labels, fmt.Sprintf("%d-%d", start, end))
+		}
+	}
+	if len(labels) == 0 {
+		return pattern.String()
+	}
+	return strings.Join(labels, ", ")
+}
+
+// statusRanges returns the ranges of consecutive status codes matched by pattern, bounds included.
+func statusRanges(pattern *regexp.Regexp) [][2]int {
+	var ranges [][2]int
+	for code := 100; code < 600; code++ {
+		if !pattern.MatchString(strconv.Itoa(code)) {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1][1] == code-1 {
+			ranges[n-1][1] = code
+		} else {
+			ranges = append(ranges, [2]int{code, code})
+		}
+	}
+	return ranges
+}
+
+func endpointTitle(key string) string {
+	path, method, _ := strings.Cut(key, " ")
+	return method + " " + path
+}
+
+// endpointFilename returns the file of an endpoint in Files, named after the route (e.g. user_handler_get_user.md).
+func endpointFilename(route apidoc.Route, path, method string) string {
+	name := route.Name
+	if routeName, ok := route.NamedRoutes[path][method]; ok {
+		name = routeName
+	} else if len(route.Paths) > 1 || len(route.Paths[path]) > 1 {
+		// a route serving several endpoints gets a file per endpoint
+		name += " " + method + " " + path
+	}
+	if route.ParentStructName != nil {
+		name = *route.ParentStructName + " " + name
+	}
+	return str.ToSnakeCase(nonAlphanumeric.ReplaceAllString(name, " ")) + ".md"
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// anchor returns the id of the anchor placed before a heading.
+func anchor(heading string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(heading), "-"), "-")
+}
+
+func typeAnchor(name string) string {
+	return "type-" + anchor(name)
+}
+
+func shortTypeName(typeName string) string {
+	return typeName[strings.LastIndex(typeName, ".")+1:]
+}
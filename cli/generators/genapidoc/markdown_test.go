@@ -0,0 +1,123 @@
+package genapidoc
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+func testRoute() apidoc.Route {
+	structName := "UserHandler"
+	return apidoc.Route{
+		Name:             "GetUser",
+		ParentStructName: &structName,
+		Paths:            map[string][]string{"/users/{id}": {"GET"}},
+		RequiredHeaders:  []string{"Authorization"},
+		OptionalHeaders:  []string{"Accept-Language"},
+		ResponseHeaders:  []string{"ETag"},
+		Tags:             []string{"Users"},
+		Request: &introspect.ObjectType{
+			TypeName: "test.GetUserRequest",
+			Fields: []introspect.Field{
+				{
+					Name: "ID",
+					Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt},
+					Tags: []introspect.FieldTag{{Key: introspect.FieldKindPath, Value: "id"}},
+				},
+				{
+					Name:     "Expand",
+					Type:     introspect.FieldType{Array: &introspect.FieldTypeArray{ItemType: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}}},
+					Tags:     []introspect.FieldTag{{Key: introspect.FieldKindQuery, Value: "expand"}},
+					Optional: true,
+				},
+			},
+		},
+		StatusToResponse: []apidoc.StatusToResponse{
+			{
+				StatusPattern: regexp.MustCompile("^200$"),
+				Response: &introspect.ObjectType{
+					TypeName: "test.UserResponse",
+					Fields: []introspect.Field{
+						{
+							Name: "Name",
+							Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+							Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}},
+						},
+						{
+							Name: "Role",
+							Type: introspect.FieldType{Enum: &introspect.FieldTypeEnum{
+								TypeName:        "test.Role",
+								KeyValuesString: map[string]string{"RoleAdmin": "admin", "RoleMember": "member"},
+							}},
+							Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "role"}},
+						},
+					},
+				},
+			},
+			{StatusPattern: regexp.MustCompile("^[45][0-9]{2}$"), IsError: true},
+		},
+	}
+}
+
+func TestMarkdownFile(t *testing.T) {
+	generator := NewMarkdownGenerator()
+	generator.AddRoute(testRoute())
+	result := generator.File()
+
+	expected := []string{
+		"## GET /users/{id}",
+		"Handler: `UserHandler.GetUser`",
+		"Tags: Users",
+		"| `Authorization` | yes |",
+		"| `Accept-Language` | no |",
+		"| `id` | path | int | yes |",
+		"| `expand` | query | array of string | no |",
+		"| 200 | [UserResponse](#type-userresponse) |",
+		"| 4xx, 5xx | error |",
+		"Response headers: `ETag`",
+		"### UserResponse",
+		"| `role` | one of `admin`, `member` | yes |",
+	}
+	for _, e := range expected {
+		if !strings.Contains(result, e) {
+			t.Errorf("Expected %q in:\n%s", e, result)
+		}
+	}
+}
+
+func TestMarkdownFiles(t *testing.T) {
+	generator := NewMarkdownGenerator()
+	generator.AddRoute(testRoute())
+	files := generator.Files()
+
+	endpoint, ok := files["user_handler_get_user.md"]
+	if !ok {
+		t.Fatalf("Expected a file per endpoint, got %v", files)
+	}
+	if !strings.Contains(endpoint, "[UserResponse](types.md#type-userresponse)") {
+		t.Errorf("Expected types to link to types.md, got:\n%s", endpoint)
+	}
+	if !strings.Contains(files["index.md"], "[`GET /users/{id}`](user_handler_get_user.md)") {
+		t.Errorf("Expected the index to link the endpoint, got:\n%s", files["index.md"])
+	}
+	if !strings.Contains(files["types.md"], "### UserResponse") {
+		t.Errorf("Expected types.md to document UserResponse, got:\n%s", files["types.md"])
+	}
+}
+
+func TestStatusLabel(t *testing.T) {
+	tests := map[string]string{
+		"^200$":          "200",
+		"^2[0-9]{2}$":    "2xx",
+		"^[45][0-9]{2}$": "4xx, 5xx",
+		"^20[0-4]$":      "200-204",
+	}
+	for pattern, expected := range tests {
+		if got := statusLabel(regexp.MustCompile(pattern)); got != expected {
+			t.Errorf("Expected %s to be %q, got %q", pattern, expected, got)
+		}
+	}
+}
@@ -0,0 +1,579 @@
+package genapidoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/core/helpers/str"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+// OpenAPIGenerator generates an OpenAPI 3.1 document from routes, the same routes the TypeScript client and the
+// Markdown documentation are generated from. Request fields are split into parameters and a request body by
+// their tags, and named object types are described once in components.schemas.
+type OpenAPIGenerator struct {
+	title   string
+	version string
+
+	paths        map[string]map[string]*openAPIOperation // path -> lower case method -> operation
+	schemas      map[string]jsonSchema                   // component name -> schema
+	operationIDs map[string]bool
+
+	componentTypes map[string]string // component name -> type name
+}
+
+func NewOpenAPIGenerator() *OpenAPIGenerator {
+	return &OpenAPIGenerator{
+		title:        "API",
+		version:      "0.1.0",
+		paths:        make(map[string]map[string]*openAPIOperation),
+		schemas:      make(map[string]jsonSchema),
+		operationIDs: make(map[string]bool),
+
+		componentTypes: map[string]string{errorSchemaName: errorSchemaName},
+	}
+}
+
+// SetInfo sets the title and the version of the API, "API" and "0.1.0" by default.
+func (gen *OpenAPIGenerator) SetInfo(title, version string) {
+	gen.title = title
+	gen.version = version
+}
+
+// jsonSchema is a JSON Schema, as embedded in OpenAPI 3.1 documents.
+type jsonSchema = map[string]any
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Info       openAPIInfo                             `json:"info"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]jsonSchema `json:"schemas"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Description string                     `json:"description,omitempty"`
+	Tags        []string                   `json:"tags,omitempty"`
+	Deprecated  bool                       `json:"deprecated,omitempty"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *openAPIRequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name        string     `json:"name"`
+	In          string     `json:"in"`
+	Description string     `json:"description,omitempty"`
+	Required    bool       `json:"required,omitempty"`
+	Schema      jsonSchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema jsonSchema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Headers     map[string]openAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIHeader struct {
+	Schema jsonSchema `json:"schema"`
+}
+
+// openAPIParameterLocations maps the field kinds read from the request line and headers to parameter locations,
+// other serializable kinds are read from the body.
+var openAPIParameterLocations = map[introspect.FieldKind]string{
+	introspect.FieldKindPath:   "path",
+	introspect.FieldKindQuery:  "query",
+	introspect.FieldKindHeader: "header",
+	introspect.FieldKindCookie: "cookie",
+}
+
+// errorSchemaName is the component describing the body of error responses, written by httpx.
+const errorSchemaName = "Error"
+
+func (gen *OpenAPIGenerator) AddRoute(route apidoc.Route) {
+	for _, path := range sortedKeys(route.Paths) {
+		for _, method := range route.Paths[path] {
+			openAPIPath := openAPIPathPattern(path)
+			if _, ok := gen.paths[openAPIPath]; !ok {
+				gen.paths[openAPIPath] = make(map[string]*openAPIOperation)
+			}
+			gen.paths[openAPIPath][strings.ToLower(method)] = gen.buildOperation(route, path, method)
+		}
+	}
+}
+
+// File returns the OpenAPI document as indented JSON.
+func (gen *OpenAPIGenerator) File() string {
+	doc := openAPIDocument{
+		OpenAPI:    "3.1.0",
+		Info:       openAPIInfo{Title: gen.title, Version: gen.version},
+		Paths:      gen.paths,
+		Components: openAPIComponents{Schemas: gen.schemas},
+	}
+	b, _ := json.MarshalIndent(doc, "", "  ")
+	return string(b) + "\n"
+}
+
+func (gen *OpenAPIGenerator) buildOperation(route apidoc.Route, path, method string) *openAPIOperation {
+	operation := &openAPIOperation{
+		OperationID: gen.operationID(route, path, method),
+		Description: route.Description,
+		Tags:        route.Tags,
+		Deprecated:  route.Deprecated,
+		Responses:   make(map[string]openAPIResponse),
+	}
+
+	if route.Request != nil {
+		operation.Parameters = gen.parameters(*route.Request)
+		operation.RequestBody = gen.requestBody(route)
+	}
+
+	// every parameter of the path template must be described, and documented headers may not be request fields
+	for _, name := range pathParameterNames(openAPIPathPattern(path)) {
+		if !hasParameter(operation.Parameters, name, "path") {
+			operation.Parameters = append(operation.Parameters, openAPIParameter{Name: name, In: "path", Required: true, Schema: jsonSchema{"type": "string"}})
+		}
+	}
+	for _, headers := range []struct {
+		names    []string
+		required bool
+	}{{route.RequiredHeaders, true}, {route.OptionalHeaders, false}} {
+		for _, name := range headers.names {
+			if !hasParameter(operation.Parameters, name, "header") {
+				operation.Parameters = append(operation.Parameters, openAPIParameter{Name: name, In: "header", Required: headers.required, Schema: jsonSchema{"type": "string"}})
+			}
+		}
+	}
+
+	for _, response := range route.StatusToResponse {
+		for _, status := range openAPIStatuses(response.StatusPattern) {
+			operation.Responses[status] = gen.response(route, response, status)
+		}
+	}
+	if len(operation.Responses) == 0 {
+		operation.Responses["default"] = openAPIResponse{Description: "Default response"}
+	}
+
+	return operation
+}
+
+// operationID returns the route name, or the name prefixed with the method when several endpoints share it.
+func (gen *OpenAPIGenerator) operationID(route apidoc.Route, path, method string) string {
+	name := route.Name
+	if routeName, ok := route.NamedRoutes[path][method]; ok {
+		name = routeName
+	}
+	candidates := []string{
+		str.ToCamelCase(name),
+		str.ToCamelCase(strings.ToLower(method) + "_" + name),
+		str.ToCamelCase(strings.ToLower(method) + "_" + strings.Trim(strings.NewReplacer("/", "_", "{", "", "}", "").Replace(path), "_") + "_" + name),
+	}
+	for _, id := range candidates {
+		if !gen.operationIDs[id] {
+			gen.operationIDs[id] = true
+			return id
+		}
+	}
+	return candidates[len(candidates)-1]
+}
+
+// parameters returns the path, query, header and cookie parameters of a request.
+func (gen *OpenAPIGenerator) parameters(request introspect.ObjectType) []openAPIParameter {
+	var parameters []openAPIParameter
+	for _, field := range request.Fields {
+		if field.IsNotSerializable() || field.IsCtx() {
+			continue
+		}
+		for _, tag := range field.Tags {
+			in, ok := openAPIParameterLocations[tag.Key]
+			if !ok || tag.Value == "-" {
+				continue
+			}
+			name := tag.Value
+			if name == "" {
+				name = field.ExposedName()
+			}
+			parameters = append(parameters, openAPIParameter{
+				Name:        name,
+				In:          in,
+				Description: field.Doc,
+				Required:    in == "path" || !field.Optional,
+				Schema:      gen.fieldSchema(field),
+			})
+		}
+	}
+	return parameters
+}
+
+// requestBody returns the body of a request: JSON fields under the request content type and the other
+// consumed ones, form and file fields under the consumed form content types, multipart/form-data when
+// there is none. It is nil when the request has no body field.
+func (gen *OpenAPIGenerator) requestBody(route apidoc.Route) *openAPIRequestBody {
+	jsonBody := gen.bodySchema(*route.Request, introspect.FieldKindJSON)
+	formBody := gen.bodySchema(*route.Request, introspect.FieldKindForm, introspect.FieldKindFile, introspect.FieldKindFiles)
+
+	contentTypes := []string{route.RequestContentType}
+	for _, contentType := range route.Consumes {
+		if !slices.Contains(contentTypes, contentType) {
+			contentTypes = append(contentTypes, contentType)
+		}
+	}
+
+	body := &openAPIRequestBody{Required: true, Content: make(map[string]openAPIMediaType)}
+	var hasJSON, hasForm bool
+	for _, contentType := range contentTypes {
+		if contentType == "" {
+			contentType = apidoc.DefaultContentType
+		}
+		schema := jsonBody
+		if isFormContentType(apidoc.MediaType(contentType)) {
+			schema = formBody
+			hasForm = true
+		} else {
+			hasJSON = true
+		}
+		if schema != nil {
+			body.Content[contentType] = openAPIMediaType{Schema: schema}
+		}
+	}
+	if jsonBody != nil && !hasJSON {
+		body.Content[apidoc.DefaultContentType] = openAPIMediaType{Schema: jsonBody}
+	}
+	if formBody != nil && !hasForm {
+		body.Content["multipart/form-data"] = openAPIMediaType{Schema: formBody}
+	}
+	if len(body.Content) == 0 {
+		return nil
+	}
+	return body
+}
+
+// bodySchema returns the object schema of the request fields of the given kinds, nil when there is none.
+func (gen *OpenAPIGenerator) bodySchema(request introspect.ObjectType, kinds ...introspect.FieldKind) jsonSchema {
+	properties := jsonSchema{}
+	var required []string
+	for _, field := range request.Fields {
+		if field.IsNotSerializable() || field.IsCtx() {
+			continue
+		}
+		hasKind := slices.ContainsFunc(field.Tags, func(tag introspect.FieldTag) bool {
+			return slices.Contains(kinds, tag.Key) && !tag.IsJSONIgnore()
+		})
+		if !hasKind {
+			continue
+		}
+		properties[field.ExposedName()] = gen.fieldSchema(field)
+		if !field.Optional {
+			required = append(required, field.ExposedName())
+		}
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return objectSchema(properties, required)
+}
+
+func (gen *OpenAPIGenerator) response(route apidoc.Route, response apidoc.StatusToResponse, status string) openAPIResponse {
+	result := openAPIResponse{Description: statusDescription(status)}
+
+	switch {
+	case response.IsError:
+		gen.addErrorSchema()
+		result.Content = map[string]openAPIMediaType{
+			apidoc.DefaultContentType: {Schema: schemaRef(errorSchemaName)},
+		}
+		return result
+	case response.IsRedirect:
+		result.Headers = map[string]openAPIHeader{"Location": {Schema: jsonSchema{"type": "string"}}}
+		return result
+	case response.Response != nil:
+		contentType := route.ResponseContentType
+		if contentType == "" {
+			contentType = apidoc.DefaultContentType
+		}
+		// bodies of other content types are not described by the JSON schema of the response type
+		schema := jsonSchema{"type": "string", "contentMediaType": contentType}
+		if apidoc.IsJSONContentType(contentType) {
+			schema = gen.objectSchemaRef(*response.Response)
+		}
+		result.Content = map[string]openAPIMediaType{contentType: {Schema: schema}}
+	}
+
+	if len(route.ResponseHeaders) > 0 {
+		result.Headers = make(map[string]openAPIHeader)
+		for _, header := range route.ResponseHeaders {
+			result.Headers[header] = openAPIHeader{Schema: jsonSchema{"type": "string"}}
+		}
+	}
+	return result
+}
+
+// fieldSchema returns the schema of a field, with its doc and the literal value of union discriminators.
+// Nullable fields accept null both ways, encoding/json decodes it to a nil pointer.
+func (gen *OpenAPIGenerator) fieldSchema(field introspect.Field) jsonSchema {
+	var schema jsonSchema
+	switch {
+	case field.Discriminator != "":
+		schema = jsonSchema{"type": "string", "const": field.Discriminator}
+	case field.HasJSONStringOption() && (field.Type.Primitive == introspect.FieldTypePrimitiveInt || field.Type.Primitive == introspect.FieldTypePrimitiveFloat):
+		schema = jsonSchema{"type": "string", "pattern": `^-?\d+(\.\d+)?$`}
+	default:
+		schema = gen.typeSchema(field.Type)
+	}
+
+	if field.Nullable {
+		schema = jsonSchema{"anyOf": []jsonSchema{schema, {"type": "null"}}}
+	}
+	if field.Doc != "" {
+		if _, isRef := schema["$ref"]; isRef {
+			schema = jsonSchema{"allOf": []jsonSchema{schema}}
+		}
+		schema["description"] = field.Doc
+	}
+	return schema
+}
+
+// typeSchema maps a field type to JSON Schema, named objects and enums are references to components.schemas.
+func (gen *OpenAPIGenerator) typeSchema(ft introspect.FieldType) jsonSchema {
+	switch {
+	case ft.Array != nil:
+		return jsonSchema{"type": "array", "items": gen.typeSchema(ft.Array.ItemType)}
+	case ft.Map != nil:
+		return jsonSchema{"type": "object", "additionalProperties": gen.typeSchema(ft.Map.Value)}
+	case ft.Enum != nil:
+		return gen.enumSchemaRef(*ft.Enum)
+	case ft.Object != nil && ft.Primitive != introspect.FieldTypePrimitiveFile && ft.Primitive != introspect.FieldTypePrimitiveTime &&
+		ft.Primitive != introspect.FieldTypePrimitiveUnixTime && ft.Primitive != introspect.FieldTypePrimitiveUnixMilliTime:
+		return gen.objectSchemaRef(*ft.Object)
+	}
+
+	switch ft.Primitive {
+	case introspect.FieldTypePrimitiveString:
+		return jsonSchema{"type": "string"}
+	case introspect.FieldTypePrimitiveInt:
+		return jsonSchema{"type": "integer"}
+	case introspect.FieldTypePrimitiveFloat:
+		return jsonSchema{"type": "number"}
+	case introspect.FieldTypePrimitiveBool:
+		return jsonSchema{"type": "boolean"}
+	case introspect.FieldTypePrimitiveTime:
+		return jsonSchema{"type": "string", "format": "date-time"}
+	case introspect.FieldTypePrimitiveUnixTime:
+		return jsonSchema{"type": "integer", "description": "Unix timestamp in seconds"}
+	case introspect.FieldTypePrimitiveUnixMilliTime:
+		return jsonSchema{"type": "integer", "description": "Unix timestamp in milliseconds"}
+	case introspect.FieldTypePrimitiveDuration:
+		return jsonSchema{"type": "integer", "description": "Duration in nanoseconds"}
+	case introspect.FieldTypePrimitiveFile:
+		return jsonSchema{"type": "string", "format": "binary"}
+	default:
+		return jsonSchema{}
+	}
+}
+
+// objectSchemaRef returns a reference to the component of a named object, registering it on first use.
+// Anonymous objects are described inline.
+func (gen *OpenAPIGenerator) objectSchemaRef(obj introspect.ObjectType) jsonSchema {
+	if obj.IsAnonymous {
+		return gen.objectTypeSchema(obj)
+	}
+
+	name := gen.componentName(obj.TypeName)
+	if _, ok := gen.schemas[name]; !ok {
+		gen.schemas[name] = jsonSchema{} // registered first, fields may refer to the object itself
+		gen.schemas[name] = gen.objectTypeSchema(obj)
+	}
+	return schemaRef(name)
+}
+
+func (gen *OpenAPIGenerator) objectTypeSchema(obj introspect.ObjectType) jsonSchema {
+	if obj.Union != nil {
+		variants := make([]jsonSchema, 0, len(obj.Union.Variants))
+		mapping := make(map[string]string)
+		for _, variant := range obj.Union.Variants {
+			ref := gen.objectSchemaRef(*variant)
+			variants = append(variants, ref)
+			if field, ok := variant.DiscriminatorField(); ok {
+				mapping[field.Discriminator] = ref["$ref"].(string)
+			}
+		}
+		return jsonSchema{
+			"oneOf":         variants,
+			"discriminator": jsonSchema{"propertyName": obj.Union.Discriminator, "mapping": mapping},
+		}
+	}
+
+	properties := jsonSchema{}
+	var required []string
+	for _, field := range obj.Fields {
+		if field.IsNotSerializable() || field.IsCtx() {
+			continue
+		}
+		properties[field.ExposedName()] = gen.fieldSchema(field)
+		if !field.Optional {
+			required = append(required, field.ExposedName())
+		}
+	}
+	schema := objectSchema(properties, required)
+	if obj.Doc != "" {
+		schema["description"] = obj.Doc
+	}
+	return schema
+}
+
+// enumSchemaRef returns a reference to the component of an enum, registering it on first use.
+func (gen *OpenAPIGenerator) enumSchemaRef(enum introspect.FieldTypeEnum) jsonSchema {
+	name := gen.componentName(enum.TypeName)
+	if _, ok := gen.schemas[name]; !ok {
+		if len(enum.KeyValuesInt) > 0 {
+			values := make([]int, 0, len(enum.KeyValuesInt))
+			for _, v := range enum.KeyValuesInt {
+				values = append(values, v)
+			}
+			slices.Sort(values)
+			gen.schemas[name] = jsonSchema{"type": "integer", "enum": values}
+		} else {
+			values := make([]string, 0, len(enum.KeyValuesString))
+			for _, v := range enum.KeyValuesString {
+				values = append(values, v)
+			}
+			slices.Sort(values)
+			gen.schemas[name] = jsonSchema{"type": "string", "enum": values}
+		}
+	}
+	return schemaRef(name)
+}
+
+func (gen *OpenAPIGenerator) addErrorSchema() {
+	if _, ok := gen.schemas[errorSchemaName]; ok {
+		return
+	}
+	gen.schemas[errorSchemaName] = objectSchema(jsonSchema{
+		"message":  jsonSchema{"type": "string"},
+		"code":     jsonSchema{"type": "string"},
+		"metadata": jsonSchema{"type": "object", "additionalProperties": jsonSchema{}},
+	}, []string{"code"})
+}
+
+func objectSchema(properties jsonSchema, required []string) jsonSchema {
+	schema := jsonSchema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func schemaRef(name string) jsonSchema {
+	return jsonSchema{"$ref": "#/components/schemas/" + name}
+}
+
+// componentNamePattern matches the characters not allowed in the names of components.
+var componentNamePattern = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// componentName returns the name of the component of a type: its short name, qualified with its package name
+// or path when another type already has it.
+func (gen *OpenAPIGenerator) componentName(typeName string) string {
+	pkgPath := typeName[:max(strings.LastIndex(typeName, "."), 0)]
+	candidates := []string{
+		shortTypeName(typeName),
+		pkgPath[strings.LastIndex(pkgPath, "/")+1:] + "." + shortTypeName(typeName),
+		typeName,
+	}
+	for _, candidate := range candidates {
+		name := componentNamePattern.ReplaceAllString(candidate, "_")
+		if owner, ok := gen.componentTypes[name]; !ok || owner == typeName {
+			gen.componentTypes[name] = typeName
+			return name
+		}
+	}
+	return componentNamePattern.ReplaceAllString(typeName, "_")
+}
+
+// pathParameterPattern matches a parameter of a path template, including Go wildcards like {path...}.
+var pathParameterPattern = regexp.MustCompile(`\{([^}]+?)(\.\.\.)?\}`)
+
+// openAPIPathPattern converts a route path to an OpenAPI path template: wildcards lose their dots and the
+// {$} end anchor of Go patterns is removed.
+func openAPIPathPattern(path string) string {
+	path = strings.ReplaceAll(path, "{$}", "")
+	return pathParameterPattern.ReplaceAllString(path, "{$1}")
+}
+
+func pathParameterNames(path string) []string {
+	var names []string
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(path, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+func hasParameter(parameters []openAPIParameter, name, in string) bool {
+	return slices.ContainsFunc(parameters, func(p openAPIParameter) bool {
+		return p.In == in && strings.EqualFold(p.Name, name)
+	})
+}
+
+// openAPIStatuses returns the response keys matched by pattern: 2XX for a whole class, each status of other
+// ranges, and default when the route does not restrict the status.
+func openAPIStatuses(pattern *regexp.Regexp) []string {
+	if pattern == nil {
+		return []string{"default"}
+	}
+
+	var statuses []string
+	for _, r := range statusRanges(pattern) {
+		for code := r[0]; code <= r[1]; code++ {
+			if code%100 == 0 && code+99 <= r[1] {
+				statuses = append(statuses, fmt.Sprintf("%dXX", code/100))
+				code += 99
+				continue
+			}
+			statuses = append(statuses, strconv.Itoa(code))
+		}
+	}
+	return statuses
+}
+
+func statusDescription(status string) string {
+	if code, err := strconv.Atoi(status); err == nil && http.StatusText(code) != "" {
+		return http.StatusText(code)
+	}
+	return status + " response"
+}
+
+func isFormContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "multipart/") || contentType == "application/x-www-form-urlencoded"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,288 @@
+package genapidoc
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+// jsonPath returns the value found by following keys (object keys or array indexes) in a decoded JSON document.
+func jsonPath(t *testing.T, value any, keys ...any) any {
+	t.Helper()
+	for _, key := range keys {
+		switch k := key.(type) {
+		case string:
+			object, ok := value.(map[string]any)
+			if !ok {
+				t.Fatalf("Expected an object to look up %q, got %v", k, value)
+			}
+			if value, ok = object[k]; !ok {
+				t.Fatalf("Expected key %q in %v", k, object)
+			}
+		case int:
+			array, ok := value.([]any)
+			if !ok || k >= len(array) {
+				t.Fatalf("Expected an array with an index %d, got %v", k, value)
+			}
+			value = array[k]
+		}
+	}
+	return value
+}
+
+func TestOpenAPIFile(t *testing.T) {
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	createUser := apidoc.Route{
+		Name:               "CreateUser",
+		Paths:              map[string][]string{"/users": {"POST"}},
+		Description:        "Creates a user.",
+		Deprecated:         true,
+		RequestContentType: apidoc.DefaultContentType,
+		Request: &introspect.ObjectType{
+			TypeName: "test.CreateUserRequest",
+			Fields: []introspect.Field{
+				{Name: "Name", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}}},
+				{Name: "Bio", Type: stringType, Optional: true, Nullable: true, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "bio"}}},
+				{Name: "Session", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindCookie, Value: "session"}}},
+				{Name: "UserID", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindCtx, Value: "user_id"}}},
+			},
+		},
+		StatusToResponse: []apidoc.StatusToResponse{
+			{StatusPattern: regexp.MustCompile("^2[0-9]{2}$"), Response: testRoute().StatusToResponse[0].Response},
+			{StatusPattern: regexp.MustCompile("^30[12]$"), IsRedirect: true},
+		},
+	}
+
+	generator := NewOpenAPIGenerator()
+	generator.SetInfo("Users", "1.2.0")
+	generator.AddRoute(testRoute())
+	generator.AddRoute(createUser)
+	result := generator.File()
+
+	var doc any
+	if err := json.Unmarshal([]byte(result), &doc); err != nil {
+		t.Fatalf("Expected valid JSON: %v\n%s", err, result)
+	}
+
+	if got := jsonPath(t, doc, "openapi"); got != "3.1.0" {
+		t.Errorf("Expected openapi 3.1.0, got %v", got)
+	}
+	if got := jsonPath(t, doc, "info", "title"); got != "Users" {
+		t.Errorf("Expected the title set with SetInfo, got %v", got)
+	}
+
+	getUser := jsonPath(t, doc, "paths", "/users/{id}", "get")
+	if got := jsonPath(t, getUser, "operationId"); got != "getUser" {
+		t.Errorf("Expected operationId getUser, got %v", got)
+	}
+	parameters := map[string]string{}
+	for _, parameter := range jsonPath(t, getUser, "parameters").([]any) {
+		parameter := parameter.(map[string]any)
+		parameters[parameter["name"].(string)] = parameter["in"].(string)
+		if parameter["name"] == "id" && (parameter["required"] != true || !reflect.DeepEqual(parameter["schema"], map[string]any{"type": "integer"})) {
+			t.Errorf("Expected a required integer path parameter, got %v", parameter)
+		}
+		if parameter["name"] == "expand" && parameter["required"] != nil {
+			t.Errorf("Expected an optional query parameter, got %v", parameter)
+		}
+	}
+	expectedParameters := map[string]string{"id": "path", "expand": "query", "Authorization": "header", "Accept-Language": "header"}
+	if !reflect.DeepEqual(parameters, expectedParameters) {
+		t.Errorf("Expected parameters %v, got %v", expectedParameters, parameters)
+	}
+
+	if got := jsonPath(t, getUser, "responses", "200", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/UserResponse" {
+		t.Errorf("Expected the 200 response to refer to UserResponse, got %v", got)
+	}
+	if got := jsonPath(t, getUser, "responses", "200", "headers", "ETag", "schema", "type"); got != "string" {
+		t.Errorf("Expected the ETag response header, got %v", got)
+	}
+	for _, status := range []string{"4XX", "5XX"} {
+		if got := jsonPath(t, getUser, "responses", status, "content", "application/json", "schema", "$ref"); got != "#/components/schemas/Error" {
+			t.Errorf("Expected the %s response to refer to Error, got %v", status, got)
+		}
+	}
+
+	create := jsonPath(t, doc, "paths", "/users", "post")
+	if jsonPath(t, create, "deprecated") != true || jsonPath(t, create, "description") != "Creates a user." {
+		t.Errorf("Expected the description and deprecation of the route, got %v", create)
+	}
+	if got := jsonPath(t, create, "parameters", 0); !reflect.DeepEqual(got, map[string]any{"name": "session", "in": "cookie", "required": true, "schema": map[string]any{"type": "string"}}) {
+		t.Errorf("Expected a cookie parameter, ctx fields being left out, got %v", got)
+	}
+	body := jsonPath(t, create, "requestBody", "content", "application/json", "schema")
+	if got := jsonPath(t, body, "required"); !reflect.DeepEqual(got, []any{"name"}) {
+		t.Errorf("Expected name to be the only required body field, got %v", got)
+	}
+	if got := jsonPath(t, body, "properties", "bio", "anyOf", 1, "type"); got != "null" {
+		t.Errorf("Expected the nullable bio to accept null, got %v", got)
+	}
+	if got := jsonPath(t, create, "responses", "2XX", "description"); got != "2XX response" {
+		t.Errorf("Expected a 2XX response, got %v", got)
+	}
+	for _, status := range []string{"301", "302"} {
+		if got := jsonPath(t, create, "responses", status, "description"); got == "" {
+			t.Errorf("Expected a %s redirect response", status)
+		}
+	}
+
+	userResponse := jsonPath(t, doc, "components", "schemas", "UserResponse")
+	if got := jsonPath(t, userResponse, "properties", "role", "$ref"); got != "#/components/schemas/Role" {
+		t.Errorf("Expected the role to refer to the Role enum, got %v", got)
+	}
+	if got := jsonPath(t, doc, "components", "schemas", "Role", "enum"); !reflect.DeepEqual(got, []any{"admin", "member"}) {
+		t.Errorf("Expected the Role enum values, got %v", got)
+	}
+}
+
+func TestOpenAPIFormAndUnion(t *testing.T) {
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	variant := func(name, value string) *introspect.ObjectType {
+		return &introspect.ObjectType{
+			TypeName: "test." + name,
+			Fields: []introspect.Field{
+				{Name: "Type", Type: stringType, Discriminator: value, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "type"}}},
+			},
+		}
+	}
+	route := apidoc.Route{
+		Name:               "Upload",
+		Paths:              map[string][]string{"/files/{path...}": {"PUT"}},
+		RequestContentType: "multipart/form-data",
+		Request: &introspect.ObjectType{
+			TypeName: "test.UploadRequest",
+			Fields: []introspect.Field{
+				{Name: "File", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveFile}, Tags: []introspect.FieldTag{{Key: introspect.FieldKindFile, Value: "file"}}},
+				{Name: "Title", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindForm, Value: "title"}}},
+			},
+		},
+		StatusToResponse: []apidoc.StatusToResponse{{
+			StatusPattern: regexp.MustCompile("^201$"),
+			Response: &introspect.ObjectType{
+				TypeName: "test.Upload",
+				Union: &introspect.ObjectUnion{
+					Discriminator: "type",
+					Variants:      []*introspect.ObjectType{variant("ImageUpload", "image"), variant("VideoUpload", "video")},
+				},
+			},
+		}},
+	}
+
+	generator := NewOpenAPIGenerator()
+	generator.AddRoute(route)
+
+	var doc any
+	if err := json.Unmarshal([]byte(generator.File()), &doc); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+
+	upload := jsonPath(t, doc, "paths", "/files/{path}", "put")
+	if got := jsonPath(t, upload, "parameters", 0); !reflect.DeepEqual(got, map[string]any{"name": "path", "in": "path", "required": true, "schema": map[string]any{"type": "string"}}) {
+		t.Errorf("Expected the wildcard to be a path parameter, got %v", got)
+	}
+	form := jsonPath(t, upload, "requestBody", "content", "multipart/form-data", "schema", "properties")
+	if got := jsonPath(t, form, "file"); !reflect.DeepEqual(got, map[string]any{"type": "string", "format": "binary"}) {
+		t.Errorf("Expected a binary file field, got %v", got)
+	}
+	if got := jsonPath(t, upload, "responses", "201", "description"); got != "Created" {
+		t.Errorf("Expected the status text as description, got %v", got)
+	}
+
+	union := jsonPath(t, doc, "components", "schemas", "Upload")
+	if got := jsonPath(t, union, "discriminator", "mapping", "video"); got != "#/components/schemas/VideoUpload" {
+		t.Errorf("Expected the discriminator mapping, got %v", got)
+	}
+	if got := jsonPath(t, union, "oneOf", 0, "$ref"); got != "#/components/schemas/ImageUpload" {
+		t.Errorf("Expected the variants in oneOf, got %v", got)
+	}
+	if got := jsonPath(t, doc, "components", "schemas", "ImageUpload", "properties", "type", "const"); got != "image" {
+		t.Errorf("Expected the discriminator to be a constant, got %v", got)
+	}
+}
+
+func TestOpenAPIPathAnchorAndComponentNames(t *testing.T) {
+	object := func(typeName string) *introspect.ObjectType {
+		return &introspect.ObjectType{
+			TypeName: typeName,
+			Fields: []introspect.Field{
+				{Name: "Name", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}}},
+			},
+		}
+	}
+	route := func(name, path, typeName string) apidoc.Route {
+		return apidoc.Route{
+			Name:             name,
+			Paths:            map[string][]string{path: {"GET"}},
+			StatusToResponse: []apidoc.StatusToResponse{{StatusPattern: regexp.MustCompile("^200$"), Response: object(typeName)}},
+		}
+	}
+
+	generator := NewOpenAPIGenerator()
+	generator.AddRoute(route("ListUsers", "/users/{$}", "example.com/app/users.Item"))
+	generator.AddRoute(route("ListOrders", "/orders/{id}/{$}", "example.com/app/orders.Item"))
+	generator.AddRoute(route("GetError", "/error", "example.com/app/errors.Error"))
+
+	var doc any
+	if err := json.Unmarshal([]byte(generator.File()), &doc); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+
+	if got := jsonPath(t, doc, "paths", "/users/", "get"); got.(map[string]any)["parameters"] != nil {
+		t.Errorf("Expected no parameter for the {$} anchor, got %v", got)
+	}
+	orders := jsonPath(t, doc, "paths", "/orders/{id}/", "get", "parameters").([]any)
+	if len(orders) != 1 || jsonPath(t, orders, 0, "name") != "id" {
+		t.Errorf("Expected only the id path parameter, got %v", orders)
+	}
+
+	expectedRefs := map[string]string{
+		"/users/":       "#/components/schemas/Item",
+		"/orders/{id}/": "#/components/schemas/orders.Item",
+		"/error":        "#/components/schemas/errors.Error",
+	}
+	for path, expected := range expectedRefs {
+		if got := jsonPath(t, doc, "paths", path, "get", "responses", "200", "content", "application/json", "schema", "$ref"); got != expected {
+			t.Errorf("Expected %s to refer to %s, got %v", path, expected, got)
+		}
+	}
+}
+
+func TestOpenAPIConsumes(t *testing.T) {
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	route := apidoc.Route{
+		Name:               "ImportUsers",
+		Paths:              map[string][]string{"/users/import": {"POST"}},
+		RequestContentType: apidoc.DefaultContentType,
+		Consumes:           []string{apidoc.DefaultContentType, "application/xml", "application/x-www-form-urlencoded"},
+		Request: &introspect.ObjectType{
+			TypeName: "test.ImportUsersRequest",
+			Fields: []introspect.Field{
+				{Name: "Name", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}}},
+				{Name: "Source", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindForm, Value: "source"}}},
+			},
+		},
+	}
+
+	generator := NewOpenAPIGenerator()
+	generator.AddRoute(route)
+
+	var doc any
+	if err := json.Unmarshal([]byte(generator.File()), &doc); err != nil {
+		t.Fatalf("Expected valid JSON: %v", err)
+	}
+
+	content, _ := jsonPath(t, doc, "paths", "/users/import", "post", "requestBody", "content").(map[string]any)
+	if got := sortedKeys(content); !reflect.DeepEqual(got, []string{"application/json", "application/x-www-form-urlencoded", "application/xml"}) {
+		t.Fatalf("Expected an entry per consumed content type, got %v", got)
+	}
+	if got := jsonPath(t, content, "application/xml", "schema", "properties", "name", "type"); got != "string" {
+		t.Errorf("Expected the JSON fields under application/xml, got %v", got)
+	}
+	if got := jsonPath(t, content, "application/x-www-form-urlencoded", "schema", "properties", "source", "type"); got != "string" {
+		t.Errorf("Expected the form fields under the consumed form content type, got %v", got)
+	}
+}
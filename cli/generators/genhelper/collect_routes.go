@@ -8,11 +8,13 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
 	"github.com/alexisvisco/goframe/http/apidoc"
 )
 
 func CollectRoutesDocumentation(workdir string, packagePaths []string) ([]*apidoc.Route, error) {
 	var routes []*apidoc.Route
+	parser := introspect.NewParser(workdir) // shared by all routes, packages are loaded once
 	for _, pkg := range packagePaths {
 		gopkg, err := LoadGoPkg(pkg, false)
 		if err != nil {
@@ -50,7 +52,7 @@ func CollectRoutesDocumentation(workdir string, packagePaths []string) ([]*apido
 					return true
 				}
 
-				r, err := apidoc.ParseRoute(workdir, file.ImportPath, structName, fd.Name.Name)
+				r, err := apidoc.ParseRouteWithParser(parser, file.ImportPath, structName, fd.Name.Name)
 				if err == nil {
 					routes = append(routes, r)
 				} else {
@@ -65,6 +67,10 @@ func CollectRoutesDocumentation(workdir string, packagePaths []string) ([]*apido
 		}
 	}
 
+	if err := apidoc.ValidateRoutes(routes); err != nil {
+		return nil, err
+	}
+
 	slices.SortFunc(routes, func(a, b *apidoc.Route) int {
 		parentStructNameA := ""
 		if a.ParentStructName != nil {
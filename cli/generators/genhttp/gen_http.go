@@ -7,6 +7,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/alexisvisco/goframe/cli/generators"
@@ -427,6 +428,16 @@ func (p *HTTPGenerator) GenerateRoutes() error {
 					}
 
 					line := fmt.Sprintf("\tp.Mux.HandleFunc(\"%s %s\", p.%s.%s())", method, pathRoute, parentStructName, doc.Name)
+					if len(doc.Consumes) > 0 {
+						// reject bodies that do not match the documented content types with a 415
+						contentTypes := make([]string, len(doc.Consumes))
+						for i, ct := range doc.Consumes {
+							contentTypes[i] = strconv.Quote(ct)
+						}
+						line = fmt.Sprintf("\tp.Mux.Handle(\"%s %s\", middlewares.Consumes(%s)(p.%s.%s()))",
+							method, pathRoute, strings.Join(contentTypes, ", "), parentStructName, doc.Name)
+						gf.AddNamedImport("", "github.com/alexisvisco/goframe/http/middlewares")
+					}
 					gf.AddLineAfterRegex(`func\s+Router\(p\s+RouterParams\)\s+{`, line)
 				}
 			}
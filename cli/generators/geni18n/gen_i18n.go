@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/alexisvisco/goframe/core/configuration"
 	"github.com/alexisvisco/goframe/core/helpers/str"
 	"github.com/alexisvisco/goframe/core/helpers/typeutil"
+	"github.com/alexisvisco/goframe/core/i18n"
 	"gopkg.in/yaml.v3"
 )
 
@@ -47,7 +49,13 @@ func (d *I18nGenerator) NewFile(name, path string, cfg configuration.I18n) ([]ge
 			"invalid_input": "Invalid input ({input}) provided by {user}, available options: {options:[]string}",
 		},
 		"messages": map[string]interface{}{
-			"success": "Operation completed successfully",
+			"success":  "Operation completed successfully",
+			"assigned": "{gender, select, male{He} female{She} other{They}} assigned you {count, plural, one{# task} other{# tasks}}",
+			"unread": map[string]interface{}{
+				"zero":  "You have no unread messages",
+				"one":   "You have one unread message",
+				"other": "You have {count:int} unread messages",
+			},
 			"status": map[string]interface{}{
 				"pending":  "Your request is pending",
 				"approved": "Your request has been approved",
@@ -76,7 +84,10 @@ func (g *I18nGenerator) CreateOrUpdateGoFile(name, path string, cfg configuratio
 	if err := yaml.Unmarshal(content, &data); err != nil {
 		return generators.FileConfig{}, fmt.Errorf("error parsing YAML: %w", err)
 	}
-	tree := buildTranslationTree(data, "")
+	tree, err := buildTranslationTree(data, "")
+	if err != nil {
+		return generators.FileConfig{}, fmt.Errorf("error parsing translations: %w", err)
+	}
 	needsStrings := g.needsStringsPackage(tree)
 	structsCode := g.generateStructCode(tree, str.ToPascalCase(name), str.ToPascalCase(name), "")
 	embedsFilesVariablesCode, files := g.generateEmbedsFilesVariablesCode(name, cfg.SupportedLocales)
@@ -160,7 +171,7 @@ func (g *I18nGenerator) generateStructCode(node *I18nTranslationNode, baseStruct
 	sb.WriteString("\ttranslations *i18n.Translations\n")
 	hasChildren := false
 	for key, child := range node.Children {
-		if len(child.Children) > 0 {
+		if child.isGroup() {
 			hasChildren = true
 			fieldName := formatStructName(key)
 			nestedStructName := fmt.Sprintf("%s%s", baseStruct, fieldName)
@@ -171,7 +182,7 @@ func (g *I18nGenerator) generateStructCode(node *I18nTranslationNode, baseStruct
 	if hasChildren {
 		sb.WriteString(fmt.Sprintf("func (t *%s) initializeStructs() {\n", currentStruct))
 		for key, child := range node.Children {
-			if len(child.Children) > 0 {
+			if child.isGroup() {
 				fieldName := formatStructName(key)
 				nestedStructName := fmt.Sprintf("%s%s", baseStruct, fieldName)
 				sb.WriteString(fmt.Sprintf("\tt.%s = &%s{translations: t.translations}\n", fieldName, nestedStructName))
@@ -183,13 +194,21 @@ func (g *I18nGenerator) generateStructCode(node *I18nTranslationNode, baseStruct
 		sb.WriteString("}\n\n")
 	}
 	for key, child := range node.Children {
-		if len(child.Children) > 0 {
+		if child.isGroup() {
 			fieldName := formatStructName(key)
 			nestedStructName := fmt.Sprintf("%s%s", baseStruct, fieldName)
 			sb.WriteString(g.generateStructCode(child, baseStruct, nestedStructName, joinPrefix(prefix, key)))
 		}
 	}
 	for key, child := range node.Children {
+		if child.Plural {
+			sb.WriteString(g.generatePluralMethodCode(child, currentStruct, key, joinPrefix(prefix, key)))
+			continue
+		}
+		if child.MessageFormat {
+			sb.WriteString(g.generateMessageFormatMethodCode(child, currentStruct, key, joinPrefix(prefix, key)))
+			continue
+		}
 		if child.Value != "" {
 			methodName := formatStructName(key)
 			fullKey := joinPrefix(prefix, key)
@@ -216,9 +235,46 @@ func (g *I18nGenerator) generateStructCode(node *I18nTranslationNode, baseStruct
 	return sb.String()
 }
 
+// generatePluralMethodCode generates the method of a pluralized key, the form being picked at runtime from
+// the count argument and the plural rules of the context language.
+func (g *I18nGenerator) generatePluralMethodCode(node *I18nTranslationNode, currentStruct, key, fullKey string) string {
+	var sb strings.Builder
+	params := append([]i18nParameter{{Name: "count", Type: "int"}}, node.Parameters...)
+	sb.WriteString(fmt.Sprintf("func (t *%s) %s(ctx context.Context, %s) string {\n", currentStruct, formatStructName(key), g.generateParamsList(params)))
+	sb.WriteString(fmt.Sprintf("\treturn t.translations.Plural(ctx, \"%s\", count, %s)\n", fullKey, g.generateArgsMap(params)))
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+// generateMessageFormatMethodCode generates the method of an ICU MessageFormat key, taking the select and
+// plural arguments along with the placeholders of every case.
+func (g *I18nGenerator) generateMessageFormatMethodCode(node *I18nTranslationNode, currentStruct, key, fullKey string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("func (t *%s) %s(ctx context.Context, %s) string {\n", currentStruct, formatStructName(key), g.generateParamsList(node.Parameters)))
+	sb.WriteString(fmt.Sprintf("\treturn t.translations.Format(ctx, \"%s\", %s)\n", fullKey, g.generateArgsMap(node.Parameters)))
+	sb.WriteString("}\n\n")
+	return sb.String()
+}
+
+func (g *I18nGenerator) generateParamsList(params []i18nParameter) string {
+	list := make([]string, len(params))
+	for i, param := range params {
+		list[i] = fmt.Sprintf("%s %s", param.Name, param.Type)
+	}
+	return strings.Join(list, ", ")
+}
+
+func (g *I18nGenerator) generateArgsMap(params []i18nParameter) string {
+	args := make([]string, len(params))
+	for i, param := range params {
+		args[i] = fmt.Sprintf("%q: %s", param.Name, g.generateArgFormatting(param))
+	}
+	return fmt.Sprintf("map[string]any{%s}", strings.Join(args, ", "))
+}
+
 func (g *I18nGenerator) containsNestedChildren(node *I18nTranslationNode) bool {
 	for _, child := range node.Children {
-		if len(child.Children) > 0 {
+		if child.isGroup() {
 			return true
 		}
 	}
@@ -279,32 +335,108 @@ type I18nTranslationNode struct {
 	Value      string
 	Parameters []i18nParameter
 	Children   map[string]*I18nTranslationNode
+
+	// Plural is set on keys whose children are plural forms (zero, one, two, few, many and other), the
+	// parameters are then those of every form except count.
+	Plural bool
+
+	// MessageFormat is set on values with ICU select or plural arguments, formatted at runtime.
+	MessageFormat bool
 }
 
 func newTranslationNode() *I18nTranslationNode {
 	return &I18nTranslationNode{Children: make(map[string]*I18nTranslationNode)}
 }
 
-func buildTranslationTree(data map[string]interface{}, prefix string) *I18nTranslationNode {
+// isGroup reports whether the node is a group of keys, generated as a nested struct.
+func (n *I18nTranslationNode) isGroup() bool {
+	return len(n.Children) > 0 && !n.Plural
+}
+
+// isPluralForms reports whether every key of data is a plural category, other being required.
+func isPluralForms(data map[string]interface{}) bool {
+	if _, ok := data[i18n.PluralOther]; !ok {
+		return false
+	}
+	for key, value := range data {
+		if _, ok := value.(map[string]interface{}); ok || !slices.Contains(i18n.PluralCategories, key) {
+			return false
+		}
+	}
+	return true
+}
+
+// pluralParameters returns the parameters of the plural forms of node in their order of appearance, count
+// being left out as it is always the first argument.
+func pluralParameters(node *I18nTranslationNode) []i18nParameter {
+	var params []i18nParameter
+	seen := map[string]bool{"count": true}
+	for _, category := range i18n.PluralCategories {
+		form, ok := node.Children[category]
+		if !ok {
+			continue
+		}
+		for _, param := range form.Parameters {
+			if !seen[param.Name] {
+				seen[param.Name] = true
+				params = append(params, param)
+			}
+		}
+	}
+	return params
+}
+
+func buildTranslationTree(data map[string]interface{}, prefix string) (*I18nTranslationNode, error) {
 	root := newTranslationNode()
-	var process func(map[string]interface{}, *I18nTranslationNode, string)
-	process = func(data map[string]interface{}, node *I18nTranslationNode, currentPrefix string) {
+	var process func(map[string]interface{}, *I18nTranslationNode, string) error
+	process = func(data map[string]interface{}, node *I18nTranslationNode, currentPrefix string) error {
 		for key, value := range data {
 			switch v := value.(type) {
 			case map[string]interface{}:
 				childNode := newTranslationNode()
 				node.Children[key] = childNode
-				process(v, childNode, joinPrefix(currentPrefix, key))
+				if err := process(v, childNode, joinPrefix(currentPrefix, key)); err != nil {
+					return err
+				}
+				if isPluralForms(v) {
+					childNode.Plural = true
+					childNode.Parameters = pluralParameters(childNode)
+				}
 			default:
 				childNode := newTranslationNode()
 				str := fmt.Sprint(v)
-				childNode.Value, childNode.Parameters = parseParameters(str)
+				if i18n.IsMessageFormat(str) {
+					params, err := parseMessageFormatParameters(str)
+					if err != nil {
+						return fmt.Errorf("key %s: %w", joinPrefix(currentPrefix, key), err)
+					}
+					childNode.Value, childNode.Parameters, childNode.MessageFormat = str, params, true
+				} else {
+					childNode.Value, childNode.Parameters = parseParameters(str)
+				}
 				node.Children[key] = childNode
 			}
 		}
+		return nil
 	}
-	process(data, root, prefix)
-	return root
+	if err := process(data, root, prefix); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// parseMessageFormatParameters returns the parameters of an ICU MessageFormat value, select arguments being
+// strings and plural arguments ints.
+func parseMessageFormatParameters(value string) ([]i18nParameter, error) {
+	message, err := i18n.ParseMessage(value)
+	if err != nil {
+		return nil, err
+	}
+	var params []i18nParameter
+	for _, param := range message.Parameters() {
+		params = append(params, i18nParameter{Name: param.Name, Type: param.Type})
+	}
+	return params, nil
 }
 
 func parseParameters(value string) (string, []i18nParameter) {
@@ -353,7 +485,7 @@ func (g *I18nGenerator) syncTranslations(base, target map[string]interface{}) (m
 		}
 	}
 	for key := range flattenTarget {
-		if _, ok := flattenBase[key]; !ok {
+		if _, ok := flattenBase[key]; !ok && !isLocalePluralForm(key, flattenBase) {
 			ops = append(ops, i18nSyncOperationInfo{Operation: operationDelete, Key: key})
 			delete(flattenTarget, key)
 		}
@@ -361,6 +493,17 @@ func (g *I18nGenerator) syncTranslations(base, target map[string]interface{}) (m
 	return unflatten(flattenTarget), ops
 }
 
+// isLocalePluralForm reports whether key is a plural form of a pluralized base key, locales having their own
+// plural categories, e.g. few and many in polish.
+func isLocalePluralForm(key string, base map[string]string) bool {
+	i := strings.LastIndex(key, ".")
+	if i < 0 || !slices.Contains(i18n.PluralCategories, key[i+1:]) {
+		return false
+	}
+	_, ok := base[key[:i]+"."+i18n.PluralOther]
+	return ok
+}
+
 func flattenForSync(m map[string]interface{}, prefix string, translations map[string]string) {
 	for k, v := range m {
 		key := k
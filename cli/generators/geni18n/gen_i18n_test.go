@@ -0,0 +1,107 @@
+package geni18n
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestPluralTranslationMethods(t *testing.T) {
+	var data map[string]interface{}
+	err := yaml.Unmarshal([]byte(`
+messages:
+  unread:
+    zero: "You have no unread messages"
+    one: "You have one unread message"
+    other: "You have {count:int} unread messages from {senders:[]string}"
+  status:
+    one: "One"
+    two: "Two"
+`), &data)
+	if err != nil {
+		t.Fatalf("Expected valid YAML: %v", err)
+	}
+
+	tree, err := buildTranslationTree(data, "")
+	if err != nil {
+		t.Fatalf("Expected a translation tree: %v", err)
+	}
+	if !tree.Children["messages"].Children["unread"].Plural {
+		t.Errorf("Expected unread to be pluralized")
+	}
+	if tree.Children["messages"].Children["status"].Plural {
+		t.Errorf("Expected status not to be pluralized without an other form")
+	}
+
+	g := &I18nGenerator{}
+	code := g.generateStructCode(tree, "Translations", "Translations", "")
+	expected := "func (t *TranslationsMessages) Unread(ctx context.Context, count int, senders []string) string {\n" +
+		"\treturn t.translations.Plural(ctx, \"messages.unread\", count, map[string]any{\"count\": count, \"senders\": strings.Join(senders, \", \")})\n" +
+		"}\n"
+	if !strings.Contains(code, expected) {
+		t.Errorf("Expected the plural method:\n%s\ngot:\n%s", expected, code)
+	}
+	if strings.Contains(code, "TranslationsUnread") {
+		t.Errorf("Expected no nested struct for plural forms, got:\n%s", code)
+	}
+	if !strings.Contains(code, "Status *TranslationsStatus") {
+		t.Errorf("Expected status to stay a nested struct, got:\n%s", code)
+	}
+	if !g.needsStringsPackage(tree) {
+		t.Errorf("Expected the strings package to be needed by the slice parameter")
+	}
+}
+
+func TestSyncKeepsLocalePluralForms(t *testing.T) {
+	base := map[string]interface{}{
+		"files": map[string]interface{}{"one": "One file", "other": "{count:int} files"},
+		"title": "Title",
+	}
+	target := map[string]interface{}{
+		"files": map[string]interface{}{"one": "plik", "few": "pliki", "many": "plików", "other": "pliku"},
+		"stale": "Stale",
+	}
+
+	g := &I18nGenerator{}
+	result, _ := g.syncTranslations(base, target)
+	files := result["files"].(map[string]interface{})
+	for _, form := range []string{"one", "few", "many", "other"} {
+		if _, ok := files[form]; !ok {
+			t.Errorf("Expected the %s form to be kept, got %v", form, files)
+		}
+	}
+	if _, ok := result["stale"]; ok {
+		t.Errorf("Expected keys missing from the base to be deleted, got %v", result)
+	}
+	if result["title"] != "Title" {
+		t.Errorf("Expected keys missing from the locale to be added, got %v", result)
+	}
+}
+
+func TestMessageFormatTranslationMethods(t *testing.T) {
+	var data map[string]interface{}
+	err := yaml.Unmarshal([]byte(`
+invite: "{gender, select, male{He} female{She} other{They}} invited {count, plural, =0{nobody} other{# guests}} to {event}"
+`), &data)
+	if err != nil {
+		t.Fatalf("Expected valid YAML: %v", err)
+	}
+
+	tree, err := buildTranslationTree(data, "")
+	if err != nil {
+		t.Fatalf("Expected a translation tree: %v", err)
+	}
+	g := &I18nGenerator{}
+	code := g.generateStructCode(tree, "Translations", "Translations", "")
+	expected := "func (t *Translations) Invite(ctx context.Context, gender string, count int, event string) string {\n" +
+		"\treturn t.translations.Format(ctx, \"invite\", map[string]any{\"gender\": gender, \"count\": count, \"event\": event})\n" +
+		"}\n"
+	if !strings.Contains(code, expected) {
+		t.Errorf("Expected the message format method:\n%s\ngot:\n%s", expected, code)
+	}
+
+	if _, err := buildTranslationTree(map[string]interface{}{"invalid": "{gender, select, male{He}}"}, ""); err == nil {
+		t.Errorf("Expected an error for a select argument without other case")
+	}
+}
@@ -0,0 +1,335 @@
+package genroutetest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/alexisvisco/goframe/cli/generators"
+	"github.com/alexisvisco/goframe/cli/generators/genhelper"
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/core/helpers/str"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+// RouteTestGenerator scaffolds an httptest based test stub for each handler having goframe:http_route methods.
+type RouteTestGenerator struct {
+	Gen *generators.Generator
+}
+
+//go:embed templates
+var templatesFS embed.FS
+
+// FileTemplateData represents data for the handler_test.go.tmpl template
+type FileTemplateData struct {
+	Pkg   string         // Package name of the handler (e.g., "v1handler")
+	Tests []TestTemplate // One test function per route
+}
+
+// TestTemplate is the test function of a route
+type TestTemplate struct {
+	Name       string     // Test name without the Test prefix (e.g., "UserHandlerGetUser")
+	StructName string     // Handler struct (e.g., "UserHandler")
+	MethodName string     // Route method returning the http.HandlerFunc (e.g., "GetUser")
+	Cases      []TestCase // One subtest per path and method
+}
+
+// TestCase is a request sent to one of the endpoints of a route
+type TestCase struct {
+	Pattern     string   // Mux pattern (e.g., "GET /users/{id}")
+	Method      string   // HTTP method (e.g., "GET")
+	Target      string   // Request target with placeholder path and query values (e.g., "/users/1?limit=1")
+	Body        string   // Go string literal of the placeholder body, empty without body
+	ContentType string   // Content type of the body
+	Headers     []Header // Placeholder headers
+	Statuses    []int    // Status codes declared by the route documentation
+}
+
+// Header is a request header set by a test case
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Generate writes a <handler>_test.go file next to every handler of the root handler packages.
+// Files that already exist are left untouched so the stubs can be edited freely once generated.
+func (g *RouteTestGenerator) Generate() error {
+	packages, err := genhelper.CollectRootHandlerPackages(g.Gen.WorkDir)
+	if err != nil {
+		return err
+	}
+
+	for _, pkg := range packages {
+		paths := []string{pkg.Path}
+		paths = append(paths, pkg.Subfolders...)
+		routes, err := genhelper.CollectRoutesDocumentation(g.Gen.WorkDir, paths)
+		if err != nil {
+			return fmt.Errorf("failed to collect routes documentation for package %s: %w", pkg.Path, err)
+		}
+
+		// routes are sorted by package and struct, keep the files in that order
+		var files []string
+		routesByFile := make(map[string][]*apidoc.Route)
+		for _, route := range routes {
+			if route.ParentStructName == nil {
+				continue
+			}
+			dir := strings.TrimPrefix(strings.TrimPrefix(route.PackagePath, g.Gen.GoModuleName), "/")
+			file := filepath.Join(g.Gen.WorkDir, dir, str.ToSnakeCase(*route.ParentStructName)+"_test.go")
+			if _, exists := routesByFile[file]; !exists {
+				files = append(files, file)
+			}
+			routesByFile[file] = append(routesByFile[file], route)
+		}
+
+		for _, file := range files {
+			if _, err := os.Stat(file); err == nil {
+				continue
+			}
+
+			content, err := File(filepath.Base(filepath.Dir(file)), routesByFile[file])
+			if err != nil {
+				return fmt.Errorf("failed to generate route tests for %s: %w", file, err)
+			}
+
+			if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+				return fmt.Errorf("failed to write route tests %s: %w", file, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// File renders the test file of routes, which must belong to handlers of the package pkg.
+func File(pkg string, routes []*apidoc.Route) (string, error) {
+	data := FileTemplateData{Pkg: pkg}
+	for _, route := range routes {
+		if route.ParentStructName == nil {
+			continue
+		}
+		data.Tests = append(data.Tests, buildTest(route))
+	}
+
+	tmpl, err := template.New("handler_test.go.tmpl").ParseFS(templatesFS, "templates/handler_test.go.tmpl")
+	if err != nil {
+		return "", fmt.Errorf("failed to parse route test template: %w", err)
+	}
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute route test template: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func buildTest(route *apidoc.Route) TestTemplate {
+	test := TestTemplate{
+		Name:       *route.ParentStructName + str.ToPascalCase(route.Name),
+		StructName: *route.ParentStructName,
+		MethodName: route.Name,
+	}
+
+	paths := make([]string, 0, len(route.Paths))
+	for path := range route.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	statuses := declaredStatuses(route.StatusToResponse)
+	for _, path := range paths {
+		for _, method := range route.Paths[path] {
+			tc := TestCase{
+				Pattern:  method + " " + path,
+				Method:   method,
+				Target:   placeholderTarget(path, route.Request),
+				Statuses: statuses,
+			}
+			if method != "GET" && method != "HEAD" && route.Request != nil {
+				tc.Body, tc.ContentType = placeholderBody(route.Request)
+			}
+			tc.Headers = placeholderHeaders(route)
+			test.Cases = append(test.Cases, tc)
+		}
+	}
+
+	return test
+}
+
+// placeholderTarget replaces the path parameters of path and appends the query parameters of the request.
+func placeholderTarget(path string, request *introspect.ObjectType) string {
+	if request == nil {
+		return path
+	}
+
+	query := url.Values{}
+	for _, field := range request.Fields {
+		if tag, ok := field.PathParam(); ok {
+			path = strings.ReplaceAll(path, "{"+tag.Value+"}", url.PathEscape(placeholderValue(field.Type)))
+			path = strings.ReplaceAll(path, "{"+tag.Value+"...}", url.PathEscape(placeholderValue(field.Type)))
+		}
+		if tag, ok := field.QueryParam(); ok && !field.Optional {
+			query.Set(tag.Value, placeholderValue(field.Type))
+		}
+	}
+
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
+// placeholderBody returns the Go string literal of a body filled with placeholder values along with its content type.
+// JSON is preferred over form values, file fields are left for the test author.
+func placeholderBody(request *introspect.ObjectType) (string, string) {
+	switch {
+	case request.HasJSONBody():
+		body := make(map[string]any)
+		for _, field := range request.Fields {
+			for _, tag := range field.Tags {
+				if tag.Key == introspect.FieldKindJSON && !tag.IsJSONIgnore() {
+					body[field.ExposedName()] = placeholderJSONValue(field.Type)
+				}
+			}
+		}
+		b, _ := json.Marshal(body)
+		return strconv.Quote(string(b)), "application/json"
+	case request.HasFormBody():
+		form := url.Values{}
+		for _, field := range request.Fields {
+			for _, tag := range field.Tags {
+				if tag.Key == introspect.FieldKindForm {
+					form.Set(tag.Value, placeholderValue(field.Type))
+				}
+			}
+		}
+		return strconv.Quote(form.Encode()), "application/x-www-form-urlencoded"
+	}
+	return "", ""
+}
+
+func placeholderHeaders(route *apidoc.Route) []Header {
+	var headers []Header
+	if route.Request != nil {
+		for _, field := range route.Request.Fields {
+			for _, tag := range field.Tags {
+				if tag.Key == introspect.FieldKindHeader && !field.Optional {
+					headers = append(headers, Header{Name: tag.Value, Value: placeholderValue(field.Type)})
+				}
+			}
+		}
+	}
+	for _, name := range route.RequiredHeaders {
+		if !slices.ContainsFunc(headers, func(h Header) bool { return strings.EqualFold(h.Name, name) }) {
+			headers = append(headers, Header{Name: name, Value: "string"})
+		}
+	}
+	return headers
+}
+
+// placeholderValue returns a value accepted by params.Bind for a field of type ft.
+func placeholderValue(ft introspect.FieldType) string {
+	if ft.Array != nil {
+		return placeholderValue(ft.Array.ItemType)
+	}
+	if ft.Enum != nil {
+		return enumValue(*ft.Enum)
+	}
+
+	switch ft.Primitive {
+	case introspect.FieldTypePrimitiveInt, introspect.FieldTypePrimitiveFloat, introspect.FieldTypePrimitiveUnixTime,
+		introspect.FieldTypePrimitiveUnixMilliTime:
+		return "1"
+	case introspect.FieldTypePrimitiveBool:
+		return "true"
+	case introspect.FieldTypePrimitiveTime:
+		return "2006-01-02T15:04:05Z"
+	case introspect.FieldTypePrimitiveDuration:
+		return "1s"
+	default:
+		return "string"
+	}
+}
+
+func placeholderJSONValue(ft introspect.FieldType) any {
+	switch {
+	case ft.Array != nil:
+		return []any{placeholderJSONValue(ft.Array.ItemType)}
+	case ft.Map != nil:
+		return map[string]any{}
+	case ft.Enum != nil:
+		if len(ft.Enum.KeyValuesInt) > 0 {
+			v, _ := strconv.Atoi(enumValue(*ft.Enum))
+			return v
+		}
+		return enumValue(*ft.Enum)
+	case ft.Object != nil && ft.Primitive != introspect.FieldTypePrimitiveFile && ft.Primitive != introspect.FieldTypePrimitiveTime:
+		obj := make(map[string]any)
+		for _, field := range ft.Object.Fields {
+			if field.IsNotSerializable() {
+				continue
+			}
+			obj[field.ExposedName()] = placeholderJSONValue(field.Type)
+		}
+		return obj
+	}
+
+	switch ft.Primitive {
+	case introspect.FieldTypePrimitiveInt, introspect.FieldTypePrimitiveFloat, introspect.FieldTypePrimitiveUnixTime,
+		introspect.FieldTypePrimitiveUnixMilliTime:
+		return 1
+	case introspect.FieldTypePrimitiveBool:
+		return true
+	case introspect.FieldTypePrimitiveString, introspect.FieldTypePrimitiveTime, introspect.FieldTypePrimitiveDuration:
+		return placeholderValue(ft)
+	default:
+		return nil
+	}
+}
+
+// enumValue returns the first enum value in a stable order.
+func enumValue(enum introspect.FieldTypeEnum) string {
+	var values []string
+	for _, v := range enum.KeyValuesString {
+		values = append(values, v)
+	}
+	for _, v := range enum.KeyValuesInt {
+		values = append(values, strconv.Itoa(v))
+	}
+	if len(values) == 0 {
+		return "string"
+	}
+	slices.Sort(values)
+	return values[0]
+}
+
+// declaredStatuses returns a status code for each documented response, in documentation order.
+// Routes without documented responses are expected to answer 200.
+func declaredStatuses(responses []apidoc.StatusToResponse) []int {
+	var statuses []int
+	for _, response := range responses {
+		if response.StatusPattern == nil {
+			continue
+		}
+		for code := 100; code < 600; code++ {
+			if response.StatusPattern.MatchString(strconv.Itoa(code)) {
+				if !slices.Contains(statuses, code) {
+					statuses = append(statuses, code)
+				}
+				break
+			}
+		}
+	}
+	if len(statuses) == 0 {
+		return []int{200}
+	}
+	return statuses
+}
@@ -0,0 +1,71 @@
+package genroutetest
+
+import (
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+func TestFile(t *testing.T) {
+	handler := "UserHandler"
+	request := introspect.ObjectType{
+		TypeName: "UpdateUserRequest",
+		Fields: []introspect.Field{
+			{Name: "ID", Tags: []introspect.FieldTag{{Key: introspect.FieldKindPath, Value: "id"}}, Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt}},
+			{Name: "Notify", Tags: []introspect.FieldTag{{Key: introspect.FieldKindQuery, Value: "notify"}}, Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveBool}},
+			{Name: "Name", Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}}, Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}},
+			{Name: "Tenant", Tags: []introspect.FieldTag{{Key: introspect.FieldKindHeader, Value: "X-Tenant"}}, Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}},
+		},
+	}
+	routes := []*apidoc.Route{
+		{
+			Name:             "UpdateUser",
+			ParentStructName: &handler,
+			Request:          &request,
+			Paths:            map[string][]string{"/users/{id}": {"PATCH"}},
+			StatusToResponse: []apidoc.StatusToResponse{
+				{StatusPattern: regexp.MustCompile(`^2[0-9]{2}$`)},
+				{StatusPattern: regexp.MustCompile(`^404$`), IsError: true},
+			},
+		},
+		{
+			Name:             "ListUsers",
+			ParentStructName: &handler,
+			Request:          &introspect.ObjectType{},
+			Paths:            map[string][]string{"/users": {"GET"}},
+		},
+	}
+
+	content, err := File("v1handler", routes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), "user_handler_test.go", content, 0); err != nil {
+		t.Fatalf("Expected generated file to parse, got %v\n%s", err, content)
+	}
+
+	expected := []string{
+		"package v1handler",
+		"func TestUserHandlerUpdateUser(t *testing.T) {",
+		`t.Run("PATCH /users/{id}"`,
+		`mux.Handle("PATCH /users/{id}", h.UpdateUser())`,
+		`httptest.NewRequest("PATCH", "/users/1?notify=true", strings.NewReader("{\"name\":\"string\"}"))`,
+		`req.Header.Set("Content-Type", "application/json")`,
+		`req.Header.Set("X-Tenant", "string")`,
+		"expected := []int{200, 404}",
+		"func TestUserHandlerListUsers(t *testing.T) {",
+		`httptest.NewRequest("GET", "/users", nil)`,
+		"expected := []int{200}",
+	}
+	for _, s := range expected {
+		if !strings.Contains(content, s) {
+			t.Errorf("Expected generated file to contain %q\n%s", s, content)
+		}
+	}
+}
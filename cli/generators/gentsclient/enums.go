@@ -0,0 +1,118 @@
+package gentsclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"golang.org/x/exp/maps"
+)
+
+// EnumUsage tells whether an enum appears in request schemas, response schemas or both.
+type EnumUsage struct {
+	Request  bool `json:"request,omitempty"`
+	Response bool `json:"response,omitempty"`
+}
+
+// EnumSnapshot records the values of every generated enum, keyed by enum type name,
+// so two generations can be compared with DiffEnumSnapshots.
+type EnumSnapshot map[string]EnumSnapshotEntry
+
+type EnumSnapshotEntry struct {
+	Values []string `json:"values"`
+	EnumUsage
+}
+
+// EnumChange is an enum value removed between two snapshots. Removals are potentially breaking for
+// response schemas: a closed enum rejects responses still carrying the value.
+type EnumChange struct {
+	Enum     string
+	Value    string
+	Breaking bool
+}
+
+func (c EnumChange) String() string {
+	if c.Breaking {
+		return fmt.Sprintf("%s: value %s removed, potentially breaking for responses still containing it", c.Enum, c.Value)
+	}
+	return fmt.Sprintf("%s: value %s removed", c.Enum, c.Value)
+}
+
+func (gen *TypescriptClientGenerator) trackEnumUsage(typeName string, isRequest bool) {
+	usage := gen.enumUsage[typeName]
+	if isRequest {
+		usage.Request = true
+	} else {
+		usage.Response = true
+	}
+	gen.enumUsage[typeName] = usage
+}
+
+// EnumSnapshot returns the enums generated so far with their values and usage.
+func (gen *TypescriptClientGenerator) EnumSnapshot() EnumSnapshot {
+	snapshot := make(EnumSnapshot, len(gen.enums))
+	for typeName, enum := range gen.enums {
+		values := maps.Values(enum.KeyValuesString)
+		for _, v := range enum.KeyValuesInt {
+			values = append(values, strconv.Itoa(v))
+		}
+		slices.Sort(values)
+		snapshot[typeName] = EnumSnapshotEntry{Values: values, EnumUsage: gen.enumUsage[typeName]}
+	}
+	return snapshot
+}
+
+// DiffEnumSnapshots lists the enum values present in before and missing in after, sorted by enum and value.
+// Removed enums are not reported, only values of enums still generated.
+func DiffEnumSnapshots(before, after EnumSnapshot) []EnumChange {
+	var changes []EnumChange
+	typeNames := maps.Keys(before)
+	slices.Sort(typeNames)
+	for _, typeName := range typeNames {
+		current, ok := after[typeName]
+		if !ok {
+			continue
+		}
+		for _, value := range before[typeName].Values {
+			if !slices.Contains(current.Values, value) {
+				changes = append(changes, EnumChange{
+					Enum:     typeName,
+					Value:    value,
+					Breaking: current.Response || before[typeName].Response,
+				})
+			}
+		}
+	}
+	return changes
+}
+
+// ReadEnumSnapshot reads a snapshot written by WriteEnumSnapshot. A missing file is an empty snapshot.
+func ReadEnumSnapshot(filename string) (EnumSnapshot, error) {
+	b, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return EnumSnapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read enum snapshot %s: %w", filename, err)
+	}
+
+	var snapshot EnumSnapshot
+	if err := json.Unmarshal(b, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse enum snapshot %s: %w", filename, err)
+	}
+	return snapshot, nil
+}
+
+// WriteEnumSnapshot writes snapshot as indented JSON.
+func WriteEnumSnapshot(filename string, snapshot EnumSnapshot) error {
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, append(b, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write enum snapshot %s: %w", filename, err)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package gentsclient
 import (
 	"embed"
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 
@@ -20,8 +21,38 @@ type TypescriptClientGenerator struct {
 	isRequest      map[string]bool                  // schemaName -> true if request
 	rootImportPath string                           // import path of the root handler package
 	typeNamePrefix map[string]string                // TypeName -> prefix to apply when exporting
+	columnMetadata bool                             // emit sortable/filterable columns of response types
+	basePath       string                           // prefix prepended to every route path, e.g. /api/v1
+	trimSlash      bool                             // remove trailing slashes from route paths
+	unknownForAny  bool                             // emit unknown rather than any for empty interface fields
+	reactQuery     bool                             // emit a TanStack Query hook per route
+	enumStyle      EnumStyle                        // how enums are declared, const objects by default
+
+	zodImportPath   string // module imported for z and ZodSchema
+	fetcherTemplate []byte // fetcher and response handling code preceding the routes
+
+	openResponseEnums bool                                // response enums accept unknown values
+	openEnumSchemas   map[string]string                   // enum TypeName -> open schemaName
+	enums             map[string]introspect.FieldTypeEnum // enum TypeName -> enum
+	enumUsage         map[string]EnumUsage                // enum TypeName -> schemas using it
+
+	routeNames map[string]bool // names of schemas.ts used by the fetcher and route functions, see useName
 }
 
+// EnumStyle is the TypeScript declaration of enums.
+type EnumStyle string
+
+const (
+	// EnumStyleConst declares enums as const objects with a union type and a z.union of literals:
+	//
+	//	export const StatusEnum = { ACTIVE: 'active' } as const;
+	EnumStyleConst EnumStyle = "const"
+	// EnumStyleEnum declares enums as TypeScript enums validated with z.nativeEnum:
+	//
+	//	export enum StatusEnum { ACTIVE = 'active' }
+	EnumStyleEnum EnumStyle = "enum"
+)
+
 const indentStr = "  "
 
 //go:embed templates
@@ -37,20 +68,156 @@ func NewTypescriptClientGenerator(rootImportPath string, typeNamePrefix map[stri
 		isRequest:      make(map[string]bool),
 		rootImportPath: rootImportPath,
 		typeNamePrefix: typeNamePrefix,
+		enumStyle:      EnumStyleConst,
+		zodImportPath:  "zod",
+
+		openEnumSchemas: make(map[string]string),
+		enums:           make(map[string]introspect.FieldTypeEnum),
+		enumUsage:       make(map[string]EnumUsage),
+	}
+
+	t.routeNames = make(map[string]bool)
+	for _, name := range fetcherNames {
+		t.routeNames[name] = true
 	}
 
+	t.fetcherTemplate, _ = fs.ReadFile("templates/fetcher.ts.tmpl")
 	t.createErrorSchema()
 
 	return t
 }
 
+// EnableColumnMetadata makes the generator emit, for each response type having sortable or filterable
+// fields, a companion object listing them so data grids can configure their controls:
+//
+//	export const UserResponseColumns = {
+//	  sortable: ['id', 'created_at'],
+//	  filterable: ['id', 'email'],
+//	} as const;
+func (gen *TypescriptClientGenerator) EnableColumnMetadata(enabled bool) {
+	gen.columnMetadata = enabled
+}
+
+// SetOpenResponseEnums makes response schemas accept enum values unknown to the client, so a backend
+// adding a value does not break parsing of responses. Request enums stay closed:
+//
+//	export const statusOpenEnumSchema = z.union([statusEnumSchema, z.string()]);
+//
+// Must be called before adding schemas.
+func (gen *TypescriptClientGenerator) SetOpenResponseEnums(open bool) {
+	gen.openResponseEnums = open
+}
+
+func (gen *TypescriptClientGenerator) isOpenEnum(isRequest bool) bool {
+	return gen.openResponseEnums && !isRequest
+}
+
+// SetBasePath sets a prefix prepended to the path of every route, for APIs mounted behind
+// a gateway (e.g. /api/v1) whose prefix is not part of the route annotations.
+func (gen *TypescriptClientGenerator) SetBasePath(basePath string) {
+	gen.basePath = basePath
+}
+
+// SetTrimTrailingSlash removes the trailing slash of route paths so clients always call /users rather than
+// /users/. Only use it with a server ignoring trailing slashes (server.ignore_trailing_slash), the generated
+// path notes the declared one.
+func (gen *TypescriptClientGenerator) SetTrimTrailingSlash(trim bool) {
+	gen.trimSlash = trim
+}
+
+// SetUnknownForAny makes empty interface fields z.unknown() and unknown instead of z.any() and any,
+// so consumers have to narrow them before use.
+func (gen *TypescriptClientGenerator) SetUnknownForAny(unknown bool) {
+	gen.unknownForAny = unknown
+}
+
+// SetReactQuery makes the generator emit, next to each route function, a TanStack Query hook wrapping it:
+// useQuery for GET routes, keyed by path and request, and useMutation for other methods. The client then
+// imports @tanstack/react-query (v5). Must be called before adding routes.
+func (gen *TypescriptClientGenerator) SetReactQuery(enabled bool) {
+	gen.reactQuery = enabled
+}
+
+// SetEnumStyle sets how enums are declared, EnumStyleConst by default. Must be called before adding schemas.
+func (gen *TypescriptClientGenerator) SetEnumStyle(style EnumStyle) {
+	gen.enumStyle = style
+}
+
+// SetZodImportPath sets the module z and ZodSchema are imported from, "zod" by default, e.g. for a fork.
+func (gen *TypescriptClientGenerator) SetZodImportPath(path string) {
+	gen.zodImportPath = path
+}
+
+// fetcherNames are the names of schemas.ts the fetcher relies on, imported by the routes.ts of Files.
+var fetcherNames = []string{"ErrorResponse", "ResponseParseError"}
+
+// SetFetcherTemplate replaces the embedded fetcher, emitted verbatim before the route functions. A custom
+// fetcher must keep the declarations route functions rely on: Fetcher, FetcherOptions, handleResponse,
+// the set* helpers and the client binding functions. Of the schemas and errors, it can only use
+// ErrorResponse and ResponseParseError, the other ones are not imported by the routes.ts of Files.
+func (gen *TypescriptClientGenerator) SetFetcherTemplate(template []byte) {
+	gen.fetcherTemplate = template
+}
+
 func (gen *TypescriptClientGenerator) indent(n int) string {
 	return strings.Repeat(indentStr, n)
 }
 
 func (gen *TypescriptClientGenerator) File() string {
 	var sb strings.Builder
-	sb.WriteString("import { z, ZodSchema } from 'zod';\n\n")
+	sb.WriteString(gen.zodImport())
+	sb.WriteString(gen.reactQueryImport())
+	sb.WriteString("\n")
+	sb.WriteString(gen.schemasCode())
+	sb.WriteString(gen.routesCode())
+	return sb.String()
+}
+
+// Files returns the client split into logical files keyed by file name, for apps importing parts of it:
+//   - schemas.ts holds the Zod schemas, their TypeScript types and the column metadata
+//   - routes.ts holds the fetcher and the route functions, in a namespace per tag or handler
+//   - index.ts re-exports both
+func (gen *TypescriptClientGenerator) Files() map[string]string {
+	schemas := gen.schemasCode()
+	routes := gen.routesCode()
+
+	var sb strings.Builder
+	sb.WriteString(gen.zodImport())
+	sb.WriteString(gen.reactQueryImport())
+	var names []string
+	for _, name := range exportedNames(schemas) {
+		if gen.routeNames[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sb.WriteString(fmt.Sprintf("import { %s } from './schemas';\n", strings.Join(names, ", ")))
+	}
+	sb.WriteString("\n")
+	sb.WriteString(routes)
+
+	return map[string]string{
+		"schemas.ts": gen.zodImport() + "\n" + schemas,
+		"routes.ts":  sb.String(),
+		"index.ts":   "export * from './schemas';\nexport * from './routes';\n",
+	}
+}
+
+// zodImport returns the import of zod, from SetZodImportPath.
+func (gen *TypescriptClientGenerator) zodImport() string {
+	return fmt.Sprintf("import { z, ZodSchema } from '%s';\n", gen.zodImportPath)
+}
+
+func (gen *TypescriptClientGenerator) reactQueryImport() string {
+	if !gen.reactQuery {
+		return ""
+	}
+	return "import { useMutation, useQuery, type UseMutationOptions, type UseQueryOptions } from '@tanstack/react-query';\n"
+}
+
+// schemasCode generates the schemas, their types and the column metadata.
+func (gen *TypescriptClientGenerator) schemasCode() string {
+	var sb strings.Builder
 	sb.WriteString("export type ValueOf<T> = T[keyof T];\n\n")
 	for _, key := range gen.schemaOrder {
 		sb.WriteString(gen.schemaCode[key])
@@ -60,8 +227,16 @@ func (gen *TypescriptClientGenerator) File() string {
 	sb.WriteString(gen.createInterfaces())
 	sb.WriteString("\n")
 
-	b, _ := fs.ReadFile("templates/fetcher.ts.tmpl")
-	sb.WriteString(string(b))
+	if gen.columnMetadata {
+		sb.WriteString(gen.createColumnMetadata())
+	}
+	return sb.String()
+}
+
+// routesCode generates the fetcher, the route functions grouped by namespace and the client factories.
+func (gen *TypescriptClientGenerator) routesCode() string {
+	var sb strings.Builder
+	sb.Write(gen.fetcherTemplate)
 	sb.WriteString("\n")
 
 	namespaces := maps.Keys(gen.routeCode)
@@ -77,5 +252,87 @@ func (gen *TypescriptClientGenerator) File() string {
 		sb.WriteString("}\n")
 	}
 
+	sb.WriteString("\n")
+	sb.WriteString(gen.createClientFactory(namespaces))
+	return sb.String()
+}
+
+var exportPattern = regexp.MustCompile(`(?m)^export (?:abstract )?(?:const|let|function|class|interface|type|enum|namespace) ([A-Za-z_$][\w$]*)`)
+
+// exportedNames lists the top-level identifiers exported by generated code, in order of declaration.
+func exportedNames(code string) []string {
+	var names []string
+	for _, match := range exportPattern.FindAllStringSubmatch(code, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// useName records a name of schemas.ts used by a route function, so that routes.ts imports it, and returns it.
+func (gen *TypescriptClientGenerator) useName(name string) string {
+	gen.routeNames[name] = true
+	return name
+}
+
+// createColumnMetadata generates the sortable/filterable columns object of every response type having some.
+func (gen *TypescriptClientGenerator) createColumnMetadata() string {
+	var sb strings.Builder
+	for _, schemaName := range gen.schemaOrder {
+		obj, ok := gen.objects[schemaName]
+		if !ok || gen.isRequest[schemaName] {
+			continue
+		}
+
+		var sortable, filterable []string
+		for _, field := range obj.Fields {
+			if field.IsNotSerializable() {
+				continue
+			}
+			name := fmt.Sprintf("'%s'", field.ExposedName())
+			if field.Sortable {
+				sortable = append(sortable, name)
+			}
+			if field.Filterable {
+				filterable = append(filterable, name)
+			}
+		}
+		if len(sortable) == 0 && len(filterable) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("export const %sColumns = {\n", gen.schemaNameToExportedType(schemaName)))
+		sb.WriteString(fmt.Sprintf("%ssortable: [%s],\n", gen.indent(1), strings.Join(sortable, ", ")))
+		sb.WriteString(fmt.Sprintf("%sfilterable: [%s],\n", gen.indent(1), strings.Join(filterable, ", ")))
+		sb.WriteString("} as const;\n\n")
+	}
+	return sb.String()
+}
+
+// createClientFactory generates createClient, which binds every client namespace to a fetcher
+// wrapped with the given interceptors:
+//
+//	const client = createClient(fetcher, { onRequest: addTraceHeader, onResponse: refreshOn401 });
+//	await client.user.getUser({ ... });
+//
+// createSettledClient does the same with error responses resolved instead of thrown, see settle:
+//
+//	const result = await createSettledClient(fetcher).user.getUser({ ... });
+func (gen *TypescriptClientGenerator) createClientFactory(namespaces []string) string {
+	return gen.clientFactory("createClient", "bindClient", namespaces) + "\n" +
+		gen.clientFactory("createSettledClient", "bindSettledClient", namespaces)
+}
+
+func (gen *TypescriptClientGenerator) clientFactory(name, bind string, namespaces []string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("export function %s(fetcher: Fetcher, interceptors: ClientInterceptors = {}) {\n", name))
+	sb.WriteString(fmt.Sprintf("%sconst interceptedFetcher = withInterceptors(fetcher, interceptors);\n", gen.indent(1)))
+	sb.WriteString(fmt.Sprintf("%sreturn {\n", gen.indent(1)))
+	for _, ns := range namespaces {
+		sb.WriteString(fmt.Sprintf("%s%s: %s(%sClient, interceptedFetcher),\n", gen.indent(2), str.ToCamelCase(ns), bind, str.ToPascalCase(ns)))
+	}
+	sb.WriteString(fmt.Sprintf("%s};\n", gen.indent(1)))
+	sb.WriteString("}\n")
 	return sb.String()
 }
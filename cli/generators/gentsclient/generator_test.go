@@ -1,9 +1,11 @@
 package gentsclient
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
+	"reflect"
 	"regexp"
 
 	"github.com/alexisvisco/goframe/core/helpers/introspect"
@@ -357,6 +359,11 @@ func TestRouteWithRequestFields(t *testing.T) {
 	if !strings.Contains(result, "safeParse(request)") {
 		t.Error("Expected request parsing logic, but found no safeParse")
 	}
+
+	// Verify that the routes file imports the error thrown by the parsing logic
+	if routes := generator.Files()["routes.ts"]; !strings.Contains(routes, "RequestParseError, ResponseParseError") {
+		t.Error("Expected routes.ts to import RequestParseError")
+	}
 }
 
 func TestHasRequestFieldsWithOnlyCtxTags(t *testing.T) {
@@ -887,3 +894,1396 @@ func TestEnumInNestedStructure(t *testing.T) {
 		t.Error("Expected events field to be Record<string, Event>")
 	}
 }
+
+func TestMSWHandlers(t *testing.T) {
+	generator := NewMSWGenerator()
+
+	userObj := introspect.ObjectType{
+		TypeName: "test.UserResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "ID",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id"}},
+			},
+			{
+				Name: "Age",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "age"}},
+			},
+			{
+				Name: "Tags",
+				Type: introspect.FieldType{
+					Array: &introspect.FieldTypeArray{ItemType: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}},
+				},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "tags"}},
+			},
+			{
+				Name: "Secret",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "-"}},
+			},
+		},
+	}
+
+	generator.AddRoute(apidoc.Route{
+		Name:  "GetUser",
+		Paths: map[string][]string{"/users/{id}": {"GET"}},
+		StatusToResponse: []apidoc.StatusToResponse{
+			{StatusPattern: regexp.MustCompile(`^2[0-9]{2}$`), Response: &userObj},
+		},
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:  "CreateUser",
+		Paths: map[string][]string{"/users": {"POST"}},
+		StatusToResponse: []apidoc.StatusToResponse{
+			{StatusPattern: regexp.MustCompile(`^201$`), Response: &userObj},
+		},
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:  "DeleteUser",
+		Paths: map[string][]string{"/users/{id}": {"DELETE"}},
+	})
+
+	result := generator.File()
+
+	expectations := []string{
+		"import { http, HttpResponse } from 'msw';",
+		"export function createHandlers(baseUrl: string = '*') {",
+		"http.get(`${baseUrl}/users/:id`, () => {",
+		"http.post(`${baseUrl}/users`, () => {",
+		"}, { status: 201 });",
+		"\"id\": 'string',",
+		"\"age\": 0,",
+		"\"tags\": ['string'],",
+		"http.delete(`${baseUrl}/users/:id`, () => {",
+		"return new HttpResponse(null, { status: 204 });",
+	}
+	for _, expected := range expectations {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected generated handlers to contain %q\n%s", expected, result)
+		}
+	}
+
+	if strings.Contains(result, "Secret") || strings.Contains(result, "\"-\"") {
+		t.Error("Expected non serializable fields to be excluded from example responses")
+	}
+}
+
+func TestTaggedRoutesNamespaces(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+
+	handler := "InvoiceHandler"
+	generator.AddRoute(apidoc.Route{
+		Name:             "ListInvoices",
+		PackagePath:      "test/pkg",
+		ParentStructName: &handler,
+		Paths:            map[string][]string{"/invoices": {"GET"}},
+		Tags:             []string{"Billing", "Reports"},
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:             "GetInvoice",
+		PackagePath:      "test/pkg",
+		ParentStructName: &handler,
+		Paths:            map[string][]string{"/invoices/{id}": {"GET"}},
+		Tags:             []string{},
+	})
+
+	result := generator.File()
+
+	for _, ns := range []string{"BillingClient", "ReportsClient", "InvoiceClient"} {
+		if !strings.Contains(result, "export namespace "+ns+" {") {
+			t.Errorf("Expected namespace %s in generated code", ns)
+		}
+	}
+
+	if strings.Count(result, "export async function listInvoices(") != 2 {
+		t.Error("Expected listInvoices to be generated once per tag")
+	}
+	if strings.Count(result, "export async function getInvoice(") != 1 {
+		t.Error("Expected untagged getInvoice to be generated in the handler namespace only")
+	}
+}
+
+func TestRouteContentTypes(t *testing.T) {
+	requestObj := introspect.ObjectType{
+		TypeName: "test.ImportFeedRequest",
+		Fields: []introspect.Field{
+			{
+				Name: "Title",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "title"}},
+			},
+		},
+	}
+	responseObj := introspect.ObjectType{
+		TypeName: "test.ImportFeedResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "ID",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id"}},
+			},
+		},
+	}
+
+	build := func(requestContentType, responseContentType string) string {
+		generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+		generator.AddSchema("", true, requestObj)
+		generator.AddSchema("", false, responseObj)
+		generator.AddRoute(apidoc.Route{
+			Name:    "importFeed",
+			Request: &requestObj,
+			Paths:   map[string][]string{"/feeds": {"POST"}},
+			StatusToResponse: []apidoc.StatusToResponse{
+				{StatusPattern: regexp.MustCompile(`^2[0-9]{2}$`), Response: &responseObj},
+			},
+			RequestContentType:  requestContentType,
+			ResponseContentType: responseContentType,
+		})
+		return generator.File()
+	}
+
+	t.Run("JSON by default", func(t *testing.T) {
+		result := build(apidoc.DefaultContentType, apidoc.DefaultContentType)
+		if !strings.Contains(result, "setRequestBody(options, safeRequest.body);") {
+			t.Error("Expected JSON request body serialization")
+		}
+		if strings.Contains(result, "setHeader(options, 'Accept'") {
+			t.Error("Expected no Accept header for JSON responses")
+		}
+	})
+
+	t.Run("explicit XML", func(t *testing.T) {
+		result := build("application/xml", "application/xml")
+		if !strings.Contains(result, "setRequestBody(options, safeRequest.body, 'application/xml');") {
+			t.Error("Expected XML request body serialization")
+		}
+		if !strings.Contains(result, "setHeader(options, 'Accept', 'application/xml');") {
+			t.Error("Expected Accept header for XML responses")
+		}
+		if !strings.Contains(result, "{ pattern: /^2[0-9]{2}$/, schema: importFeedResponseSchema, raw: 'text' }") {
+			t.Errorf("Expected XML responses to be read as text without JSON parsing, got:\n%s", result)
+		}
+		if !strings.Contains(result, "Promise<{data: string, status: number, headers: Headers}>") {
+			t.Errorf("Expected XML responses to be typed as string, got:\n%s", result)
+		}
+	})
+
+	t.Run("request content type with parameters", func(t *testing.T) {
+		result := build("application/xml; charset=utf-8", apidoc.DefaultContentType)
+		if !strings.Contains(result, "setRequestBody(options, safeRequest.body, 'application/xml; charset=utf-8', 'application/xml');") {
+			t.Errorf("Expected the body to be serialized according to the media type, got:\n%s", result)
+		}
+		result = build("Application/JSON; charset=utf-8", apidoc.DefaultContentType)
+		if !strings.Contains(result, "setRequestBody(options, safeRequest.body);") {
+			t.Errorf("Expected JSON request body serialization, got:\n%s", result)
+		}
+	})
+
+	t.Run("JSON with a charset", func(t *testing.T) {
+		for _, contentType := range []string{"application/json; charset=utf-8", "application/problem+json"} {
+			result := build(apidoc.DefaultContentType, contentType)
+			if !strings.Contains(result, "{ pattern: /^2[0-9]{2}$/, schema: importFeedResponseSchema }") {
+				t.Errorf("Expected %s responses to be parsed with their schema, got:\n%s", contentType, result)
+			}
+			if strings.Contains(result, "setHeader(options, 'Accept'") {
+				t.Errorf("Expected no Accept header for %s responses", contentType)
+			}
+		}
+	})
+}
+
+func TestClientFactoryWithInterceptors(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+
+	userHandler := "UserHandler"
+	orderHandler := "OrderHandler"
+	generator.AddRoute(apidoc.Route{
+		Name:             "ListUsers",
+		PackagePath:      "test/pkg",
+		ParentStructName: &userHandler,
+		Paths:            map[string][]string{"/users": {"GET"}},
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:             "ListOrders",
+		PackagePath:      "test/pkg",
+		ParentStructName: &orderHandler,
+		Paths:            map[string][]string{"/orders": {"GET"}},
+	})
+
+	result := generator.File()
+
+	for _, expected := range []string{
+		"export interface ClientInterceptors {",
+		"export function withInterceptors(fetcher: Fetcher, interceptors: ClientInterceptors = {}): Fetcher {",
+		"headers: Object.fromEntries(new Headers(options.headers)) };",
+		"export function createClient(fetcher: Fetcher, interceptors: ClientInterceptors = {}) {",
+		"const interceptedFetcher = withInterceptors(fetcher, interceptors);",
+		"order: bindClient(OrderClient, interceptedFetcher),",
+		"user: bindClient(UserClient, interceptedFetcher),",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected generated code to contain %q", expected)
+		}
+	}
+
+	if strings.Index(result, "export namespace UserClient {") > strings.Index(result, "export function createClient(") {
+		t.Error("Expected createClient to be generated after the client namespaces")
+	}
+}
+
+func TestColumnMetadata(t *testing.T) {
+	responseObj := introspect.ObjectType{
+		TypeName: "test.UserResponse",
+		Fields: []introspect.Field{
+			{
+				Name:       "ID",
+				Type:       introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags:       []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id"}},
+				Sortable:   true,
+				Filterable: true,
+			},
+			{
+				Name:     "CreatedAt",
+				Type:     introspect.FieldType{Primitive: introspect.FieldTypePrimitiveTime},
+				Tags:     []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "created_at"}},
+				Sortable: true,
+			},
+			{
+				Name: "Bio",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "bio"}},
+			},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, responseObj)
+	if strings.Contains(generator.File(), "UserResponseColumns") {
+		t.Error("Expected no column metadata when the option is disabled")
+	}
+
+	generator.EnableColumnMetadata(true)
+	result := generator.File()
+	expected := "export const UserResponseColumns = {\n  sortable: ['id', 'created_at'],\n  filterable: ['id'],\n} as const;"
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected column metadata %q in generated code", expected)
+	}
+}
+
+func TestBasePath(t *testing.T) {
+	route := apidoc.Route{
+		Name:  "GetUser",
+		Paths: map[string][]string{"/users/{id}": {"GET"}},
+	}
+
+	for _, basePath := range []string{"/api/v1", "api/v1/", "/api/v1/"} {
+		generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+		generator.SetBasePath(basePath)
+		generator.AddRoute(route)
+		if result := generator.File(); !strings.Contains(result, "path: '/api/v1/users/{id}',") {
+			t.Errorf("Base path %q: expected route path to be prefixed", basePath)
+		}
+
+		mswGenerator := NewMSWGenerator()
+		mswGenerator.SetBasePath(basePath)
+		mswGenerator.AddRoute(route)
+		if result := mswGenerator.File(); !strings.Contains(result, "http.get(`${baseUrl}/api/v1/users/:id`") {
+			t.Errorf("Base path %q: expected MSW handler path to be prefixed", basePath)
+		}
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddRoute(route)
+	if result := generator.File(); !strings.Contains(result, "path: '/users/{id}',") {
+		t.Error("Expected route path to be unchanged without base path")
+	}
+}
+
+func TestTrimTrailingSlash(t *testing.T) {
+	route := apidoc.Route{
+		Name:  "ListUsers",
+		Paths: map[string][]string{"/users/": {"GET"}},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.SetTrimTrailingSlash(true)
+	generator.AddRoute(route)
+	if result := generator.File(); !strings.Contains(result, "path: '/users', // declared as /users/, the server ignores trailing slashes") {
+		t.Error("Expected route path to be trimmed with a note of the declared path")
+	}
+
+	mswGenerator := NewMSWGenerator()
+	mswGenerator.SetTrimTrailingSlash(true)
+	mswGenerator.AddRoute(route)
+	if result := mswGenerator.File(); !strings.Contains(result, "http.get(`${baseUrl}/users`") {
+		t.Error("Expected MSW handler path to be trimmed")
+	}
+
+	generator = NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddRoute(route)
+	if result := generator.File(); !strings.Contains(result, "path: '/users/',") {
+		t.Error("Expected route path to be unchanged by default")
+	}
+}
+
+func TestUnixTimeFields(t *testing.T) {
+	// timeformat only applies to parameters bound from strings, JSON fields stay RFC 3339 dates
+	requestObj := introspect.ObjectType{
+		TypeName: "test.ListEventsRequest",
+		Fields: []introspect.Field{
+			{
+				Name: "CreatedAt",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveTime},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindQuery, Value: "created_at"}},
+			},
+			{
+				Name: "StartsAt",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveUnixTime},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindQuery, Value: "starts_at"}},
+			},
+			{
+				Name: "EndsAt",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveUnixMilliTime},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindQuery, Value: "ends_at"}},
+			},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", true, requestObj)
+	result := generator.File()
+
+	for _, expected := range []string{
+		"const unixDateSchema = z.number().transform((n) => new Date(n * 1000));",
+		"created_at: dateSchema",
+		"starts_at: unixDateSchema",
+		"starts_at: Date",
+		"const unixMilliDateSchema = z.number().transform((n) => new Date(n));",
+		"ends_at: unixMilliDateSchema",
+		"ends_at: Date",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected %q in generated code:\n%s", expected, result)
+		}
+	}
+	for _, unexpected := range []string{"export interface UnixDate ", "export interface UnixMilliDate "} {
+		if strings.Contains(result, unexpected) {
+			t.Errorf("Expected no %q in generated code:\n%s", unexpected, result)
+		}
+	}
+}
+
+func TestPackageFiles(t *testing.T) {
+	if _, err := PackageFiles(PackageOptions{}); err == nil {
+		t.Error("Expected an error without package name")
+	}
+
+	files, err := PackageFiles(PackageOptions{Name: "@acme/api-client", ClientFile: "sdk/api.ts"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var pkg struct {
+		Name             string            `json:"name"`
+		Version          string            `json:"version"`
+		Main             string            `json:"main"`
+		Types            string            `json:"types"`
+		Scripts          map[string]string `json:"scripts"`
+		PeerDependencies map[string]string `json:"peerDependencies"`
+	}
+	if err := json.Unmarshal([]byte(files["package.json"]), &pkg); err != nil {
+		t.Fatalf("Expected valid package.json, got %v", err)
+	}
+	if pkg.Name != "@acme/api-client" || pkg.Version != "0.1.0" {
+		t.Errorf("Expected name and default version, got %s@%s", pkg.Name, pkg.Version)
+	}
+	if pkg.Main != "./dist/api.js" || pkg.Types != "./dist/api.d.ts" {
+		t.Errorf("Expected entry points built from the client file, got %s and %s", pkg.Main, pkg.Types)
+	}
+	if pkg.Scripts["build"] == "" {
+		t.Error("Expected a build script")
+	}
+	if _, ok := pkg.PeerDependencies["zod"]; !ok {
+		t.Error("Expected zod as a peer dependency")
+	}
+	if _, ok := pkg.PeerDependencies["@tanstack/react-query"]; ok {
+		t.Error("Expected no TanStack Query dependency without ReactQuery")
+	}
+
+	hooksFiles, err := PackageFiles(PackageOptions{Name: "@acme/api-client", ReactQuery: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var hooksPkg struct {
+		PeerDependencies map[string]string `json:"peerDependencies"`
+		DevDependencies  map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal([]byte(hooksFiles["package.json"]), &hooksPkg); err != nil {
+		t.Fatalf("Expected valid package.json, got %v", err)
+	}
+	if hooksPkg.PeerDependencies["@tanstack/react-query"] == "" || hooksPkg.DevDependencies["@tanstack/react-query"] == "" {
+		t.Errorf("Expected TanStack Query as a dependency with ReactQuery, got %v", hooksPkg.PeerDependencies)
+	}
+
+	var tsconfig struct {
+		CompilerOptions map[string]any `json:"compilerOptions"`
+		Include         []string       `json:"include"`
+	}
+	if err := json.Unmarshal([]byte(files["tsconfig.json"]), &tsconfig); err != nil {
+		t.Fatalf("Expected valid tsconfig.json, got %v", err)
+	}
+	if len(tsconfig.Include) != 1 || tsconfig.Include[0] != "api.ts" {
+		t.Errorf("Expected tsconfig to include api.ts, got %v", tsconfig.Include)
+	}
+	if tsconfig.CompilerOptions["declaration"] != true {
+		t.Error("Expected declaration files to be emitted")
+	}
+}
+
+func TestOpenResponseEnums(t *testing.T) {
+	statusEnum := &introspect.FieldTypeEnum{
+		TypeName:        "test.Status",
+		KeyValuesString: map[string]string{"StatusOpen": "open"},
+	}
+	statusField := introspect.Field{
+		Name: "Status",
+		Type: introspect.FieldType{Enum: statusEnum},
+		Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "status"}},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.SetOpenResponseEnums(true)
+	generator.AddSchema("", true, introspect.ObjectType{TypeName: "test.UpdateRequest", Fields: []introspect.Field{statusField}})
+	generator.AddSchema("", false, introspect.ObjectType{TypeName: "test.IssueResponse", Fields: []introspect.Field{statusField}})
+	result := generator.File()
+
+	for _, expected := range []string{
+		"export const statusOpenEnumSchema = z.union([statusEnumSchema, z.string()]);",
+		"status: statusEnumSchema,",
+		"status: statusOpenEnumSchema,",
+		"status: StatusEnum | (string & {});",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected %q in generated code:\n%s", expected, result)
+		}
+	}
+
+	snapshot := generator.EnumSnapshot()
+	entry := snapshot["test.Status"]
+	if !entry.Request || !entry.Response {
+		t.Errorf("Expected Status to be used by requests and responses, got %+v", entry.EnumUsage)
+	}
+}
+
+func TestDiffEnumSnapshots(t *testing.T) {
+	before := EnumSnapshot{
+		"test.Status":   {Values: []string{"closed", "open"}, EnumUsage: EnumUsage{Response: true}},
+		"test.Priority": {Values: []string{"high", "low"}, EnumUsage: EnumUsage{Request: true}},
+		"test.Removed":  {Values: []string{"a"}},
+	}
+	after := EnumSnapshot{
+		"test.Status":   {Values: []string{"open"}, EnumUsage: EnumUsage{Response: true}},
+		"test.Priority": {Values: []string{"high"}, EnumUsage: EnumUsage{Request: true}},
+	}
+
+	changes := DiffEnumSnapshots(before, after)
+	expected := []EnumChange{
+		{Enum: "test.Priority", Value: "low", Breaking: false},
+		{Enum: "test.Status", Value: "closed", Breaking: true},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes %v, got %v", expected, changes)
+	}
+}
+
+func TestNamedRoutesProduceDistinctFunctions(t *testing.T) {
+	route := apidoc.Route{
+		Name: "List",
+		Paths: map[string][]string{
+			"/users":  {"GET"},
+			"/admins": {"GET"},
+			"/people": {"GET"},
+		},
+		NamedRoutes: map[string]map[string]string{
+			"/users":  {"GET": "ListUsers"},
+			"/admins": {"GET": "List"},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddRoute(route)
+	result := generator.File()
+
+	expected := map[string]string{
+		"listUsers": "/users",
+		"list":      "/admins",
+		"getList":   "/people",
+	}
+	for fnName, path := range expected {
+		re := regexp.MustCompile(`export async function ` + fnName + `\(fetcher: Fetcher\)[^\n]*\n[^\n]*\n\s*path: '` + path + `'`)
+		if !re.MatchString(result) {
+			t.Errorf("Expected function %s for path %s in generated code:\n%s", fnName, path, result)
+		}
+	}
+}
+
+func TestSyncCursorHelper(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	result := generator.File()
+
+	for _, expected := range []string{
+		"export interface CursorStore {",
+		"export async function syncCursor<P>(options: SyncCursorOptions<P>): Promise<number> {",
+		"let cursor = await options.store.load();",
+		"await options.store.save(nextCursor);",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected generated code to contain %q", expected)
+		}
+	}
+
+	// The cursor must only be saved once the page is processed
+	if strings.Index(result, "await options.onPage(data);") > strings.Index(result, "await options.store.save(nextCursor);") {
+		t.Error("Expected the cursor to be saved after the page is processed")
+	}
+}
+
+func TestSerializeRequestHelpers(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	result := generator.File()
+
+	for _, expected := range []string{
+		"export interface SerializedRequest {",
+		"export function serializeRequest(options: FetcherOptions): SerializedRequest {",
+		"export async function captureRequest(call: (fetcher: Fetcher) => Promise<unknown>): Promise<SerializedRequest> {",
+		"captured = serializeRequest(options ?? { path: '' });",
+		"return JSON.parse(body);",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected generated code to contain %q", expected)
+		}
+	}
+}
+
+func TestSettledClient(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddRoute(apidoc.Route{
+		Name:  "GetUser",
+		Paths: map[string][]string{"/users/{id}": {"GET"}},
+	})
+	result := generator.File()
+
+	for _, expected := range []string{
+		"export async function settle<T>(call: Promise<{ data: T, status: number, headers: Headers }>): Promise<ClientResult<T>> {",
+		"return { ok: false, data: error, status: error.statusCode ?? 0, headers: error.headers ?? new Headers() };",
+		"errorResponse.headers = response.headers;",
+		"export function createSettledClient(fetcher: Fetcher, interceptors: ClientInterceptors = {}) {",
+		"root: bindSettledClient(RootClient, interceptedFetcher),",
+		"root: bindClient(RootClient, interceptedFetcher),",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected generated code to contain %q", expected)
+		}
+	}
+}
+
+func TestUnknownForAny(t *testing.T) {
+	responseObj := introspect.ObjectType{
+		TypeName: "test.EventResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "Payload",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveAny},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "payload"}},
+			},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, responseObj)
+	result := generator.File()
+	if !strings.Contains(result, "payload: z.any()") || strings.Contains(result, "z.unknown()") {
+		t.Error("Expected empty interface fields to be z.any() by default")
+	}
+
+	generator = NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.SetUnknownForAny(true)
+	generator.AddSchema("", false, responseObj)
+	result = generator.File()
+	if !strings.Contains(result, "payload: z.unknown()") {
+		t.Errorf("Expected empty interface fields to be z.unknown(), got:\n%s", result)
+	}
+	if !strings.Contains(result, "payload: unknown") && !strings.Contains(result, "payload?: unknown") {
+		t.Errorf("Expected the TS type of empty interface fields to be unknown")
+	}
+}
+
+func TestZodSchemaOverride(t *testing.T) {
+	requestObj := introspect.ObjectType{
+		TypeName: "test.CreateUserRequest",
+		Fields: []introspect.Field{
+			{
+				Name:      "Username",
+				Type:      introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags:      []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "username"}},
+				ZodSchema: "z.string().min(3).max(20)",
+			},
+			{
+				Name: "Email",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "email"}},
+			},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", true, requestObj)
+	result := generator.File()
+
+	if !strings.Contains(result, "username: z.string().min(3).max(20),") {
+		t.Errorf("Expected the zod tag to be used verbatim, got:\n%s", result)
+	}
+	if !strings.Contains(result, "email: z.string(),") {
+		t.Errorf("Expected fields without zod tag to be inferred, got:\n%s", result)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	fooObj := introspect.ObjectType{
+		TypeName: "test.Foo",
+		Fields: []introspect.Field{
+			{
+				Name: "Name",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}},
+			},
+		},
+	}
+
+	responseObj := introspect.ObjectType{
+		TypeName: "test.MapsResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "Labels",
+				Type: introspect.FieldType{
+					Primitive: introspect.FieldTypePrimitiveMap,
+					Map: &introspect.FieldTypeMap{
+						Key:   introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt},
+						Value: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+					},
+				},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "labels"}},
+			},
+			{
+				Name: "Foos",
+				Type: introspect.FieldType{
+					Primitive: introspect.FieldTypePrimitiveMap,
+					Map: &introspect.FieldTypeMap{
+						Key:   introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+						Value: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveObject, Object: &fooObj},
+					},
+				},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "foos"}},
+			},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, responseObj)
+	result := generator.File()
+
+	if !strings.Contains(result, `labels: z.record(z.string().regex(/^-?\d+$/), z.string()),`) {
+		t.Errorf("Expected int keys to be validated as decimal strings, got:\n%s", result)
+	}
+	if strings.Contains(result, "z.record(z.number()") {
+		t.Errorf("Expected no z.number() record key, JSON object keys are strings")
+	}
+	if !strings.Contains(result, "labels: Record<string, string>") {
+		t.Errorf("Expected the TS type of int keyed maps to use string keys, got:\n%s", result)
+	}
+	if !strings.Contains(result, "foos: z.record(z.string(), fooSchema),") {
+		t.Errorf("Expected string keyed maps of structs to reference the struct schema, got:\n%s", result)
+	}
+}
+
+func TestJSONStringOption(t *testing.T) {
+	idField := introspect.Field{
+		Name: "ID",
+		Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt},
+		Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id", Options: []string{"string"}}},
+	}
+	countField := introspect.Field{
+		Name: "Count",
+		Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt},
+		Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "count"}},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", true, introspect.ObjectType{TypeName: "test.UpdateRequest", Fields: []introspect.Field{idField, countField}})
+	generator.AddSchema("", false, introspect.ObjectType{TypeName: "test.UpdateResponse", Fields: []introspect.Field{idField, countField}})
+	result := generator.File()
+
+	if !strings.Contains(result, "id: z.coerce.number().transform(String),") {
+		t.Errorf("Expected request string-encoded numbers to be sent as strings, got:\n%s", result)
+	}
+	if !strings.Contains(result, "  id: z.coerce.number(),") {
+		t.Errorf("Expected response string-encoded numbers to be coerced, got:\n%s", result)
+	}
+	if !strings.Contains(result, "count: z.number(),") {
+		t.Errorf("Expected plain numbers to stay z.number()")
+	}
+	if !strings.Contains(result, "id: number;") {
+		t.Errorf("Expected the TS type of string-encoded numbers to be number")
+	}
+}
+
+func TestReactQueryHooks(t *testing.T) {
+	userObj := introspect.ObjectType{
+		TypeName: "test.UserResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "ID",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id"}},
+			},
+		},
+	}
+	getRequest := introspect.ObjectType{
+		TypeName: "test.GetUserRequest",
+		Fields: []introspect.Field{
+			{
+				Name: "ID",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindPath, Value: "id"}},
+			},
+		},
+	}
+	createRequest := introspect.ObjectType{
+		TypeName: "test.CreateUserRequest",
+		Fields: []introspect.Field{
+			{
+				Name: "Email",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "email"}},
+			},
+		},
+	}
+	ok := []apidoc.StatusToResponse{{StatusPattern: regexp.MustCompile(`^2[0-9]{2}$`), Response: &userObj}}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.SetReactQuery(true)
+	generator.AddSchema("", true, getRequest, createRequest)
+	generator.AddSchema("", false, userObj)
+	generator.AddRoute(apidoc.Route{Name: "getUser", Request: &getRequest, Paths: map[string][]string{"/users/{id}": {"GET"}}, StatusToResponse: ok})
+	generator.AddRoute(apidoc.Route{Name: "createUser", Request: &createRequest, Paths: map[string][]string{"/users": {"POST"}}, StatusToResponse: ok})
+	generator.AddRoute(apidoc.Route{Name: "listUsers", Paths: map[string][]string{"/users": {"GET"}}, StatusToResponse: ok})
+	result := generator.File()
+
+	expected := []string{
+		"import { useMutation, useQuery, type UseMutationOptions, type UseQueryOptions } from '@tanstack/react-query';",
+		"export function getUserQueryKey(request: GetUserRequest) {",
+		"return ['/users/{id}', request] as const;",
+		"export function useGetUser(fetcher: Fetcher, request: GetUserRequest, options?: Omit<UseQueryOptions<Awaited<ReturnType<typeof getUser>>>, 'queryKey' | 'queryFn'>) {",
+		"queryFn: () => getUser(fetcher, request),",
+		"export function useCreateUser(fetcher: Fetcher, options?: Omit<UseMutationOptions<Awaited<ReturnType<typeof createUser>>, Error, CreateUserRequest>, 'mutationFn'>) {",
+		"mutationFn: (request: CreateUserRequest) => createUser(fetcher, request),",
+		"export function useListUsers(fetcher: Fetcher, options?: Omit<UseQueryOptions<Awaited<ReturnType<typeof listUsers>>>, 'queryKey' | 'queryFn'>) {",
+		"queryKey: listUsersQueryKey(),",
+	}
+	for _, code := range expected {
+		if !strings.Contains(result, code) {
+			t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+		}
+	}
+
+	hook := func(name string) string {
+		start := strings.Index(result, "export function "+name+"(")
+		if start == -1 {
+			t.Fatalf("Expected hook %s to be generated", name)
+		}
+		return result[start : start+strings.Index(result[start:], "\n  }")]
+	}
+	if h := hook("useGetUser"); !strings.Contains(h, "useQuery(") || strings.Contains(h, "useMutation(") {
+		t.Errorf("Expected the GET hook to use useQuery, got:\n%s", h)
+	}
+	if h := hook("useCreateUser"); !strings.Contains(h, "useMutation(") || strings.Contains(h, "useQuery(") {
+		t.Errorf("Expected the POST hook to use useMutation, got:\n%s", h)
+	}
+
+	plain := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	plain.AddRoute(apidoc.Route{Name: "listUsers", Paths: map[string][]string{"/users": {"GET"}}})
+	if result := plain.File(); strings.Contains(result, "@tanstack/react-query") || strings.Contains(result, "useListUsers") {
+		t.Errorf("Expected no hooks without SetReactQuery")
+	}
+}
+
+func TestFiles(t *testing.T) {
+	userObj := introspect.ObjectType{
+		TypeName: "test.UserResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "ID",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id"}},
+			},
+		},
+	}
+	ok := []apidoc.StatusToResponse{{StatusPattern: regexp.MustCompile(`^2[0-9]{2}$`), Response: &userObj}}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, userObj)
+	generator.AddRoute(apidoc.Route{Name: "getUser", Tags: []string{"users"}, Paths: map[string][]string{"/users/me": {"GET"}}, StatusToResponse: ok})
+	generator.AddRoute(apidoc.Route{Name: "listPosts", Tags: []string{"posts"}, Paths: map[string][]string{"/posts": {"GET"}}, StatusToResponse: ok})
+	files := generator.Files()
+
+	if len(files) != 3 {
+		t.Fatalf("Expected schemas.ts, routes.ts and index.ts, got %d files", len(files))
+	}
+
+	schemas, routes, index := files["schemas.ts"], files["routes.ts"], files["index.ts"]
+	if !strings.Contains(schemas, "export const userResponseSchema = z.object({") || !strings.Contains(schemas, "export interface UserResponse {") {
+		t.Errorf("Expected schemas and types in schemas.ts, got:\n%s", schemas)
+	}
+	if strings.Contains(schemas, "export namespace") || strings.Contains(schemas, "async function getUser(") {
+		t.Errorf("Expected no route functions in schemas.ts")
+	}
+
+	for ns, fn := range map[string]string{"UsersClient": "getUser", "PostsClient": "listPosts"} {
+		start := strings.Index(routes, "export namespace "+ns+" {")
+		if start == -1 {
+			t.Fatalf("Expected namespace %s in routes.ts", ns)
+		}
+		end := start + strings.Index(routes[start:], "\n}\n")
+		if !strings.Contains(routes[start:end], "export async function "+fn+"(") {
+			t.Errorf("Expected %s in namespace %s", fn, ns)
+		}
+	}
+	// RequestParseError is only thrown by routes having a request
+	if !strings.Contains(routes, "import { ErrorResponse, FetchError, ResponseParseError, userResponseSchema, UserResponse } from './schemas';") {
+		t.Errorf("Expected routes.ts to import the schema names it uses, got:\n%s", routes[:strings.Index(routes, "export type FetcherOptions")])
+	}
+	if strings.Contains(routes, "export const userResponseSchema") {
+		t.Errorf("Expected no schemas in routes.ts")
+	}
+
+	if !strings.Contains(index, "export * from './schemas';") || !strings.Contains(index, "export * from './routes';") {
+		t.Errorf("Expected index.ts to re-export schemas and routes, got:\n%s", index)
+	}
+}
+
+func TestNullableFields(t *testing.T) {
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	fields := []introspect.Field{
+		{Name: "Name", Type: stringType, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "name"}}},
+		{Name: "Nickname", Type: stringType, Optional: true, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "nickname", Options: []string{"omitempty"}}}},
+		{Name: "Bio", Type: stringType, Optional: true, Nullable: true, Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "bio"}}},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, introspect.ObjectType{TypeName: "test.ProfileResponse", Fields: fields})
+	generator.AddSchema("", true, introspect.ObjectType{TypeName: "test.UpdateProfileRequest", Fields: fields})
+	result := generator.File()
+
+	expected := []string{
+		// response
+		"  name: z.string(),\n",
+		"  nickname: z.string().optional(),\n",
+		"  bio: z.string().nullable(),\n",
+		"  name: string;\n",
+		"  nickname?: string;\n",
+		"  bio: string | null;\n",
+		// request, a missing key decodes to nil
+		"      bio: z.string().optional(),\n",
+		"      bio?: string;\n",
+	}
+	for _, code := range expected {
+		if !strings.Contains(result, code) {
+			t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+		}
+	}
+	if strings.Contains(result, "bio: z.string().nullable().optional()") || strings.Contains(result, "bio?: string | null") {
+		t.Errorf("Expected nullable response fields not to be optional")
+	}
+}
+
+func TestZodValidations(t *testing.T) {
+	validate := func(rules ...string) introspect.FieldTag {
+		return introspect.FieldTag{Key: introspect.FieldKindValidate, Options: rules}
+	}
+	jsonTag := func(name string) introspect.FieldTag {
+		return introspect.FieldTag{Key: introspect.FieldKindJSON, Value: name}
+	}
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+
+	requestObj := introspect.ObjectType{
+		TypeName: "test.CreateOrderRequest",
+		Fields: []introspect.Field{
+			{Name: "Quantity", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt}, Tags: []introspect.FieldTag{jsonTag("quantity"), validate("required", "min=1", "max=100")}},
+			{Name: "Code", Type: stringType, Tags: []introspect.FieldTag{jsonTag("code"), validate("len=6")}},
+			{Name: "Name", Type: stringType, Tags: []introspect.FieldTag{jsonTag("name"), validate("min=3", "max=20")}},
+			{Name: "Email", Type: stringType, Tags: []introspect.FieldTag{jsonTag("email"), validate("required", "email")}},
+			{Name: "Website", Type: stringType, Optional: true, Tags: []introspect.FieldTag{jsonTag("website"), validate("omitempty", "url")}},
+			{
+				Name: "Tags",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveArray, Array: &introspect.FieldTypeArray{ItemType: stringType}},
+				Tags: []introspect.FieldTag{jsonTag("tags"), validate("max=5", "dive", "min=2")},
+			},
+			{Name: "Ratio", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveFloat}, Tags: []introspect.FieldTag{jsonTag("ratio"), validate("min=0.5", "email", "gt=0")}},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", true, requestObj)
+	result := generator.File()
+
+	expected := []string{
+		"quantity: z.number().min(1).max(100),",
+		"code: z.string().length(6),",
+		"name: z.string().min(3).max(20),",
+		"email: z.string().email(),",
+		"website: z.string().url().optional(),",
+		"tags: z.array(z.string()).max(5),",
+		"ratio: z.number().min(0.5),",
+	}
+	for _, code := range expected {
+		if !strings.Contains(result, code) {
+			t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+		}
+	}
+}
+
+func TestEnumStyle(t *testing.T) {
+	statusEnum := &introspect.FieldTypeEnum{
+		TypeName:        "test.Status",
+		KeyValuesString: map[string]string{"StatusActive": "active"},
+	}
+	priorityEnum := &introspect.FieldTypeEnum{
+		TypeName:     "test.Priority",
+		KeyValuesInt: map[string]int{"PriorityHigh": 2},
+	}
+	responseObj := introspect.ObjectType{
+		TypeName: "test.TaskResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "Status",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveEnum, Enum: statusEnum},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "status"}},
+			},
+			{
+				Name: "Priority",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveEnum, Enum: priorityEnum},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "priority"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		style      EnumStyle
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name: "const",
+			expected: []string{
+				"export const StatusEnum = {\n  ACTIVE: 'active',\n} as const;\n",
+				"export type StatusEnum = ValueOf<typeof StatusEnum>;\n",
+				"export const statusEnumSchema = z.union([z.literal('active')]);\n",
+				"export const PriorityEnum = {\n  HIGH: 2,\n} as const;\n",
+			},
+			unexpected: []string{"export enum", "z.nativeEnum"},
+		},
+		{
+			name:  "enum",
+			style: EnumStyleEnum,
+			expected: []string{
+				"export enum StatusEnum {\n  ACTIVE = 'active',\n}\n",
+				"export const statusEnumSchema = z.nativeEnum(StatusEnum);\n",
+				"export enum PriorityEnum {\n  HIGH = 2,\n}\n",
+				"export const priorityEnumSchema = z.nativeEnum(PriorityEnum);\n",
+				"status: statusEnumSchema,",
+				"status: StatusEnum;",
+			},
+			unexpected: []string{"as const", "ValueOf<typeof StatusEnum>", "z.literal("},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+			if tt.style != "" {
+				generator.SetEnumStyle(tt.style)
+			}
+			generator.AddSchema("", false, responseObj)
+			result := generator.File()
+
+			for _, code := range tt.expected {
+				if !strings.Contains(result, code) {
+					t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+				}
+			}
+			for _, code := range tt.unexpected {
+				if strings.Contains(result, code) {
+					t.Errorf("Expected generated client not to contain %q", code)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddRoute(apidoc.Route{
+		Name:            "listArticles",
+		Paths:           map[string][]string{"/articles": {"GET"}},
+		ResponseHeaders: []string{"X-Total-Count", "ETag"},
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:  "deleteArticle",
+		Paths: map[string][]string{"/articles/{id}": {"DELETE"}},
+	})
+	result := generator.File()
+
+	if !strings.Contains(result, "export interface TypedHeaders<K extends string> extends Headers {") {
+		t.Errorf("Expected the TypedHeaders interface in the fetcher")
+	}
+	if !strings.Contains(result, "export async function listArticles(fetcher: Fetcher): Promise<{data: void, status: number, headers: TypedHeaders<'X-Total-Count' | 'ETag'>}> {") {
+		t.Errorf("Expected documented response headers in the return type, got:\n%s", result)
+	}
+	if !strings.Contains(result, "export async function deleteArticle(fetcher: Fetcher): Promise<{data: void, status: number, headers: Headers}> {") {
+		t.Errorf("Expected plain Headers without documented response headers")
+	}
+}
+
+func TestBinaryResponses(t *testing.T) {
+	reportObj := introspect.ObjectType{
+		TypeName: "test.ReportResponse",
+		Fields: []introspect.Field{
+			{
+				Name: "ID",
+				Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString},
+				Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "id"}},
+			},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, reportObj)
+	generator.AddRoute(apidoc.Route{
+		Name:                "downloadInvoice",
+		Paths:               map[string][]string{"/invoices/{id}/pdf": {"GET"}},
+		ResponseContentType: "application/pdf",
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:  "downloadReport",
+		Paths: map[string][]string{"/reports/{id}": {"GET"}},
+		StatusToResponse: []apidoc.StatusToResponse{
+			{StatusPattern: regexp.MustCompile(`^200$`), Response: &reportObj},
+			{StatusPattern: regexp.MustCompile(`^4[0-9]{2}$`), IsError: true},
+		},
+		ResponseContentType: "image/png",
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:                "exportFeed",
+		Paths:               map[string][]string{"/feed": {"GET"}},
+		ResponseContentType: "application/atom+xml",
+	})
+	result := generator.File()
+
+	expected := []string{
+		"export async function downloadInvoice(fetcher: Fetcher): Promise<{data: Blob, status: number, headers: Headers}> {",
+		"[{ pattern: /^2[0-9]{2}$/, schema: z.any(), raw: 'blob' }];",
+		"setHeader(options, 'Accept', 'application/pdf');",
+		"export async function downloadReport(fetcher: Fetcher): Promise<{data: Blob, status: number, headers: Headers}> {",
+		"[{ pattern: /^200$/, schema: reportResponseSchema, raw: 'blob' }];",
+		"validatedData = await response.data.blob();",
+	}
+	for _, code := range expected {
+		if !strings.Contains(result, code) {
+			t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+		}
+	}
+	start := strings.Index(result, "export async function exportFeed(")
+	if start == -1 {
+		t.Fatalf("Expected exportFeed to be generated")
+	}
+	if feed := result[start : start+strings.Index(result[start:], "\n  }\n")]; strings.Contains(feed, "Blob") || strings.Contains(feed, "raw: 'blob'") {
+		t.Errorf("Expected XML responses not to be read as a Blob, got:\n%s", feed)
+	}
+
+	for contentType, binary := range map[string]bool{
+		"application/pdf":          true,
+		"application/octet-stream": true,
+		"image/png":                true,
+		"application/json":         false,
+		"application/problem+json": false,
+		"application/xml":          false,
+		"text/csv; charset=utf-8":  false,
+	} {
+		if got := isBinaryContentType(contentType); got != binary {
+			t.Errorf("isBinaryContentType(%q): expected %v, got %v", contentType, binary, got)
+		}
+	}
+}
+
+func TestZodImportPathAndFetcherTemplate(t *testing.T) {
+	fetcher := "// custom fetcher\nexport type Fetcher = (options?: FetcherOptions) => Promise<FetcherResponse>;\n"
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.SetZodImportPath("@acme/zod")
+	generator.SetFetcherTemplate([]byte(fetcher))
+	generator.AddRoute(apidoc.Route{Name: "listUsers", Paths: map[string][]string{"/users": {"GET"}}})
+	result := generator.File()
+
+	if !strings.HasPrefix(result, "import { z, ZodSchema } from '@acme/zod';\n") {
+		t.Errorf("Expected zod to be imported from the custom path, got:\n%s", result[:strings.Index(result, "\n")])
+	}
+	if strings.Contains(result, "from 'zod'") {
+		t.Errorf("Expected no import of the default zod module")
+	}
+	if !strings.Contains(result, "\n"+fetcher+"\nexport namespace RootClient {") {
+		t.Errorf("Expected the custom fetcher verbatim before the routes, got:\n%s", result)
+	}
+	if strings.Contains(result, "async function handleResponse(") {
+		t.Errorf("Expected the embedded fetcher to be replaced")
+	}
+
+	if files := generator.Files(); !strings.HasPrefix(files["schemas.ts"], "import { z, ZodSchema } from '@acme/zod';") {
+		t.Errorf("Expected split files to import zod from the custom path")
+	}
+
+	defaults := NewTypescriptClientGenerator("test/pkg", map[string]string{}).File()
+	if !strings.HasPrefix(defaults, "import { z, ZodSchema } from 'zod';\n") || !strings.Contains(defaults, "async function handleResponse(") {
+		t.Errorf("Expected the default zod import and fetcher")
+	}
+}
+
+func TestRouteDocComments(t *testing.T) {
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddRoute(apidoc.Route{
+		Name:        "listUsers",
+		Paths:       map[string][]string{"/users": {"GET"}},
+		Description: "Lists the users.\nMatches users/*/admins too */",
+	})
+	generator.AddRoute(apidoc.Route{
+		Name:       "listUsersV1",
+		Paths:      map[string][]string{"/v1/users": {"GET"}},
+		Deprecated: true,
+	})
+	result := generator.File()
+
+	expected := "  /**\n   * Lists the users.\n   * Matches users/*\\/admins too *\\/\n   *\n   * GET /users\n   */\n  export async function listUsers(fetcher: Fetcher)"
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected JSDoc with the escaped description, method and path:\n%s\ngot:\n%s", expected, result)
+	}
+
+	expected = "  /**\n   * GET /v1/users\n   * @deprecated\n   */\n  export async function listUsersV1(fetcher: Fetcher)"
+	if !strings.Contains(result, expected) {
+		t.Errorf("Expected JSDoc with @deprecated:\n%s\ngot:\n%s", expected, result)
+	}
+	if strings.Count(result, "@deprecated") != 1 {
+		t.Errorf("Expected only the deprecated route to be flagged")
+	}
+}
+
+func TestZodDefaults(t *testing.T) {
+	queryTag := func(name string) introspect.FieldTag {
+		return introspect.FieldTag{Key: introspect.FieldKindQuery, Value: name}
+	}
+	defaultValue := func(value string) *string {
+		return &value
+	}
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	intType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt}
+	statusEnum := &introspect.FieldTypeEnum{
+		TypeName:        "test.Status",
+		KeyValuesString: map[string]string{"StatusActive": "active"},
+	}
+
+	requestObj := introspect.ObjectType{
+		TypeName: "test.SearchRequest",
+		Fields: []introspect.Field{
+			{Name: "Limit", Type: intType, Default: defaultValue("30"), Tags: []introspect.FieldTag{queryTag("limit")}},
+			{Name: "Region", Type: stringType, Default: defaultValue(`eu-"west"`), Tags: []introspect.FieldTag{queryTag("region")}},
+			{Name: "Active", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveBool}, Default: defaultValue("true"), Tags: []introspect.FieldTag{queryTag("active")}},
+			{Name: "Page", Type: intType, Optional: true, Default: defaultValue("1"), Tags: []introspect.FieldTag{queryTag("page")}},
+			{
+				Name:    "Ids",
+				Type:    introspect.FieldType{Primitive: introspect.FieldTypePrimitiveArray, Array: &introspect.FieldTypeArray{ItemType: intType}},
+				Default: defaultValue("1, 2,"),
+				Tags:    []introspect.FieldTag{queryTag("ids")},
+			},
+			{Name: "Status", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString, Enum: statusEnum}, Default: defaultValue("active"), Tags: []introspect.FieldTag{queryTag("status")}},
+			{Name: "Offset", Type: intType, Default: defaultValue("none"), Tags: []introspect.FieldTag{queryTag("offset")}},
+			{Name: "Cursor", Type: stringType, Tags: []introspect.FieldTag{queryTag("cursor")}},
+		},
+	}
+	responseObj := introspect.ObjectType{
+		TypeName: "test.SearchResponse",
+		Fields: []introspect.Field{
+			{Name: "Total", Type: intType, Default: defaultValue("0"), Tags: []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: "total"}}},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", true, requestObj)
+	generator.AddSchema("", false, responseObj)
+	result := generator.File()
+
+	expected := []string{
+		"limit: z.number().default(30),",
+		`region: z.string().default("eu-\"west\""),`,
+		"active: z.boolean().default(true),",
+		"page: z.number().default(1),",
+		"ids: z.array(z.number()).default([1, 2]),",
+		`.default("active"),`,
+		"offset: z.number(),",
+		"cursor: z.string(),",
+		"total: z.number(),",
+		"limit?: number;",
+		"active?: boolean;",
+		"offset: number;",
+		"cursor: string;",
+		"total: number;",
+	}
+	for _, code := range expected {
+		if !strings.Contains(result, code) {
+			t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+		}
+	}
+
+	generator = NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.SetEnumStyle(EnumStyleEnum)
+	generator.AddSchema("", true, requestObj)
+	if result := generator.File(); strings.Contains(result, `.default("active")`) {
+		t.Errorf("Expected no literal default for TypeScript enums")
+	}
+}
+
+func TestFileIsDeterministic(t *testing.T) {
+	tag := func(kind introspect.FieldKind, name string) []introspect.FieldTag {
+		return []introspect.FieldTag{{Key: kind, Value: name}}
+	}
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	statusType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString, Enum: &introspect.FieldTypeEnum{
+		TypeName:        "test.Status",
+		KeyValuesString: map[string]string{"StatusActive": "active", "StatusBanned": "banned", "StatusPending": "pending", "StatusDeleted": "deleted"},
+	}}
+	priorityType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveInt, Enum: &introspect.FieldTypeEnum{
+		TypeName:     "test.Priority",
+		KeyValuesInt: map[string]int{"PriorityLow": 1, "PriorityMedium": 2, "PriorityHigh": 3, "PriorityUrgent": 4},
+	}}
+
+	generate := func() string {
+		generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+		request := introspect.ObjectType{
+			TypeName: "test.UpdateUserRequest",
+			Fields: []introspect.Field{
+				{Name: "ID", Type: stringType, Tags: tag(introspect.FieldKindPath, "id")},
+				{Name: "Query", Type: stringType, Tags: tag(introspect.FieldKindQuery, "q")},
+				{Name: "Token", Type: stringType, Tags: tag(introspect.FieldKindHeader, "X-Token")},
+				{Name: "Session", Type: stringType, Tags: tag(introspect.FieldKindCookie, "session")},
+				{Name: "Status", Type: statusType, Tags: tag(introspect.FieldKindJSON, "status")},
+			},
+		}
+		generator.AddSchema("", true, request)
+		for _, name := range []string{"User", "Team", "Project", "Invoice", "Comment"} {
+			generator.AddSchema("", false, introspect.ObjectType{
+				TypeName: "test." + name,
+				Fields: []introspect.Field{
+					{Name: "Name", Type: stringType, Tags: tag(introspect.FieldKindJSON, "name")},
+					{Name: "Priority", Type: priorityType, Tags: tag(introspect.FieldKindJSON, "priority")},
+				},
+			})
+			generator.AddRoute(apidoc.Route{Name: "get" + name, Paths: map[string][]string{"/" + name: {"GET", "DELETE"}}})
+		}
+		generator.AddRoute(apidoc.Route{Name: "updateUser", Paths: map[string][]string{"/users/{id}": {"PUT"}}, Request: &request})
+		return generator.File()
+	}
+
+	expected := generate()
+	for i := 0; i < 10; i++ {
+		if result := generate(); result != expected {
+			t.Fatalf("Expected the same output on every run, got:\n%s\nthen:\n%s", expected, result)
+		}
+	}
+
+	if !strings.Contains(expected, "z.union([z.literal('active'), z.literal('banned'), z.literal('deleted'), z.literal('pending')])") {
+		t.Errorf("Expected enum literals sorted by value")
+	}
+	if user, team := strings.Index(expected, "export interface User {"), strings.Index(expected, "export interface Team {"); user == -1 || user > team {
+		t.Errorf("Expected interfaces in the order schemas were added")
+	}
+}
+
+func TestDiscriminatedUnions(t *testing.T) {
+	jsonTag := func(name string) []introspect.FieldTag {
+		return []introspect.FieldTag{{Key: introspect.FieldKindJSON, Value: name}}
+	}
+	stringType := introspect.FieldType{Primitive: introspect.FieldTypePrimitiveString}
+	card := &introspect.ObjectType{
+		TypeName: "test.CardPayment",
+		Fields: []introspect.Field{
+			{Name: "Type", Type: stringType, Discriminator: "card", Tags: jsonTag("type")},
+			{Name: "Last4", Type: stringType, Tags: jsonTag("last4")},
+		},
+	}
+	bank := &introspect.ObjectType{
+		TypeName: "test.BankPayment",
+		Fields: []introspect.Field{
+			{Name: "Type", Type: stringType, Discriminator: "bank", Tags: jsonTag("type")},
+			{Name: "IBAN", Type: stringType, Tags: jsonTag("iban")},
+		},
+	}
+	payment := &introspect.ObjectType{
+		TypeName: "test.Payment",
+		Fields:   []introspect.Field{},
+		Union:    &introspect.ObjectUnion{Discriminator: "type", Variants: []*introspect.ObjectType{card, bank}},
+	}
+	checkout := introspect.ObjectType{
+		TypeName: "test.Checkout",
+		Fields: []introspect.Field{
+			{Name: "Payment", Type: introspect.FieldType{Primitive: introspect.FieldTypePrimitiveObject, Object: payment}, Tags: jsonTag("payment")},
+		},
+	}
+	route := apidoc.Route{
+		Name:  "getPayment",
+		Paths: map[string][]string{"/payments/{id}": {"GET"}},
+		StatusToResponse: []apidoc.StatusToResponse{
+			{StatusPattern: regexp.MustCompile("^200$"), Response: payment},
+		},
+	}
+
+	generator := NewTypescriptClientGenerator("test/pkg", map[string]string{})
+	generator.AddSchema("", false, *payment, checkout)
+	generator.AddRoute(route)
+	result := generator.File()
+
+	expected := []string{
+		"export const paymentSchema = z.discriminatedUnion('type', [cardPaymentSchema, bankPaymentSchema]);",
+		"type: z.literal('card'),",
+		"type: z.literal('bank'),",
+		"payment: paymentSchema,",
+		"export type Payment = CardPayment | BankPayment;",
+		"type: 'card';",
+		"payment: Payment;",
+		"Promise<{data: Payment, status: number, headers: Headers}>",
+		"{ pattern: /^200$/, schema: paymentSchema }",
+	}
+	for _, code := range expected {
+		if !strings.Contains(result, code) {
+			t.Errorf("Expected generated client to contain %q, got:\n%s", code, result)
+		}
+	}
+	if strings.Index(result, "export const paymentSchema") < strings.Index(result, "export const bankPaymentSchema") {
+		t.Errorf("Expected the variants to be declared before the union")
+	}
+
+	mswGenerator := NewMSWGenerator()
+	mswGenerator.AddRoute(route)
+	if handlers := mswGenerator.File(); !strings.Contains(handlers, `"type": 'card',`) {
+		t.Errorf("Expected the mock response to be the first variant, got:\n%s", handlers)
+	}
+}
@@ -0,0 +1,203 @@
+package gentsclient
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexisvisco/goframe/core/helpers/introspect"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+// MSWGenerator generates Mock Service Worker (https://mswjs.io) request handlers from routes.
+// Each handler matches the method and path of a route and answers with an example body
+// built from the route's success response type.
+type MSWGenerator struct {
+	handlers  map[string]string // "path METHOD" -> handler code
+	basePath  string            // prefix prepended to every route path, see TypescriptClientGenerator.SetBasePath
+	trimSlash bool              // see TypescriptClientGenerator.SetTrimTrailingSlash
+}
+
+func NewMSWGenerator() *MSWGenerator {
+	return &MSWGenerator{
+		handlers: make(map[string]string),
+	}
+}
+
+// SetBasePath sets a prefix prepended to the path of every route.
+func (gen *MSWGenerator) SetBasePath(basePath string) {
+	gen.basePath = basePath
+}
+
+// SetTrimTrailingSlash removes the trailing slash of route paths, matching the client requests.
+func (gen *MSWGenerator) SetTrimTrailingSlash(trim bool) {
+	gen.trimSlash = trim
+}
+
+var pathParamRegex = regexp.MustCompile(`\{([^}]+)\}`)
+
+func (gen *MSWGenerator) AddRoute(route apidoc.Route) {
+	for path, methods := range route.Paths {
+		for _, method := range methods {
+			gen.handlers[path+" "+method] = gen.buildHandler(route, path, method)
+		}
+	}
+}
+
+func (gen *MSWGenerator) File() string {
+	var sb strings.Builder
+	sb.WriteString("import { http, HttpResponse } from 'msw';\n\n")
+	sb.WriteString("export function createHandlers(baseUrl: string = '*') {\n")
+	sb.WriteString(indentStr + "return [\n")
+
+	keys := make([]string, 0, len(gen.handlers))
+	for key := range gen.handlers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		sb.WriteString(gen.handlers[key])
+	}
+
+	sb.WriteString(indentStr + "];\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("export const handlers = createHandlers();\n")
+	return sb.String()
+}
+
+func (gen *MSWGenerator) buildHandler(route apidoc.Route, path, method string) string {
+	fullPath := joinBasePath(gen.basePath, path)
+	if gen.trimSlash {
+		fullPath = trimTrailingSlash(fullPath)
+	}
+	mswPath := pathParamRegex.ReplaceAllString(fullPath, ":$1")
+	status, body := gen.exampleResponse(route.StatusToResponse)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%shttp.%s(`${baseUrl}%s`, () => {\n", strings.Repeat(indentStr, 2), strings.ToLower(method), mswPath))
+	if body == "" {
+		sb.WriteString(fmt.Sprintf("%sreturn new HttpResponse(null, { status: %d });\n", strings.Repeat(indentStr, 3), status))
+	} else {
+		sb.WriteString(fmt.Sprintf("%sreturn HttpResponse.json(%s, { status: %d });\n", strings.Repeat(indentStr, 3), body, status))
+	}
+	sb.WriteString(strings.Repeat(indentStr, 2) + "}),\n")
+	return sb.String()
+}
+
+// exampleResponse picks the first documented success response and returns a status code matching its
+// pattern along with an example body. The body is empty when the route has no response type.
+func (gen *MSWGenerator) exampleResponse(responses []apidoc.StatusToResponse) (int, string) {
+	for _, response := range responses {
+		if response.IsError || response.IsRedirect || response.StatusPattern == nil {
+			continue
+		}
+		status := exampleStatus(response.StatusPattern)
+		if status == 0 {
+			continue
+		}
+		if response.Response == nil {
+			return status, ""
+		}
+		return status, exampleObject(*response.Response, 3, map[string]bool{})
+	}
+	return 204, ""
+}
+
+// exampleStatus returns the first HTTP status code matched by pattern, preferring 200.
+func exampleStatus(pattern *regexp.Regexp) int {
+	if pattern.MatchString("200") {
+		return 200
+	}
+	for code := 100; code < 600; code++ {
+		if pattern.MatchString(strconv.Itoa(code)) {
+			return code
+		}
+	}
+	return 0
+}
+
+func exampleObject(obj introspect.ObjectType, depth int, visited map[string]bool) string {
+	if !obj.IsAnonymous {
+		if visited[obj.TypeName] {
+			return "null"
+		}
+		visited[obj.TypeName] = true
+		defer delete(visited, obj.TypeName)
+	}
+	if obj.Union != nil && len(obj.Union.Variants) > 0 {
+		return exampleObject(*obj.Union.Variants[0], depth, visited)
+	}
+
+	var fields []string
+	for _, field := range obj.Fields {
+		if field.IsNotSerializable() || field.IsCtx() {
+			continue
+		}
+		value := exampleValue(field.Type, depth+1, visited)
+		if field.Discriminator != "" {
+			value = fmt.Sprintf("'%s'", field.Discriminator)
+		}
+		fields = append(fields, fmt.Sprintf("%s%s: %s,\n",
+			strings.Repeat(indentStr, depth+1), strconv.Quote(field.ExposedName()), value))
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{\n" + strings.Join(fields, "") + strings.Repeat(indentStr, depth) + "}"
+}
+
+func exampleValue(ft introspect.FieldType, depth int, visited map[string]bool) string {
+	switch {
+	case ft.Array != nil:
+		item := exampleValue(ft.Array.ItemType, depth, visited)
+		if item == "null" {
+			return "[]"
+		}
+		return "[" + item + "]"
+	case ft.Map != nil:
+		return "{}"
+	case ft.Enum != nil:
+		return exampleEnumValue(*ft.Enum)
+	case ft.Primitive == introspect.FieldTypePrimitiveTime:
+		return "'1970-01-01T00:00:00Z'"
+	case ft.Primitive == introspect.FieldTypePrimitiveUnixTime, ft.Primitive == introspect.FieldTypePrimitiveUnixMilliTime:
+		return "0"
+	case ft.Object != nil && ft.Primitive != introspect.FieldTypePrimitiveFile:
+		return exampleObject(*ft.Object, depth, visited)
+	}
+
+	switch ft.Primitive {
+	case introspect.FieldTypePrimitiveString:
+		return "'string'"
+	case introspect.FieldTypePrimitiveInt, introspect.FieldTypePrimitiveFloat, introspect.FieldTypePrimitiveDuration:
+		return "0"
+	case introspect.FieldTypePrimitiveBool:
+		return "false"
+	default:
+		return "null"
+	}
+}
+
+// exampleEnumValue returns the first enum value in a stable order.
+func exampleEnumValue(enum introspect.FieldTypeEnum) string {
+	if len(enum.KeyValuesString) > 0 {
+		values := make([]string, 0, len(enum.KeyValuesString))
+		for _, v := range enum.KeyValuesString {
+			values = append(values, v)
+		}
+		slices.Sort(values)
+		return fmt.Sprintf("'%s'", values[0])
+	}
+	if len(enum.KeyValuesInt) > 0 {
+		values := make([]int, 0, len(enum.KeyValuesInt))
+		for _, v := range enum.KeyValuesInt {
+			values = append(values, v)
+		}
+		slices.Sort(values)
+		return strconv.Itoa(values[0])
+	}
+	return "null"
+}
@@ -0,0 +1,75 @@
+package gentsclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// PackageOptions describes the npm package wrapping a generated client.
+type PackageOptions struct {
+	Name       string // npm package name, e.g. @acme/api-client
+	Version    string // defaults to 0.1.0
+	ClientFile string // file name of the generated client, e.g. client.ts
+	ReactQuery bool   // the client has TanStack Query hooks, see SetReactQuery
+}
+
+// PackageFiles returns the package.json and tsconfig.json scaffolding that turns the generated
+// client into a publishable npm package, keyed by file name. The files are meant to be written
+// in the directory of the client file.
+func PackageFiles(opts PackageOptions) (map[string]string, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+	if opts.Version == "" {
+		opts.Version = "0.1.0"
+	}
+	if opts.ClientFile == "" {
+		opts.ClientFile = "client.ts"
+	}
+
+	file := filepath.Base(opts.ClientFile)
+	data := map[string]any{
+		"Name":       opts.Name,
+		"Version":    opts.Version,
+		"File":       file,
+		"Module":     strings.TrimSuffix(file, filepath.Ext(file)),
+		"ReactQuery": opts.ReactQuery,
+	}
+
+	files := make(map[string]string)
+	for _, name := range []string{"package.json", "tsconfig.json"} {
+		content, err := executePackageTemplate("templates/"+name+".tmpl", data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", name, err)
+		}
+		files[name] = content
+	}
+	return files, nil
+}
+
+func executePackageTemplate(name string, data any) (string, error) {
+	b, err := fs.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"json": func(v string) (string, error) {
+			b, err := json.Marshal(v)
+			return string(b), err
+		},
+	}).Parse(string(b))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
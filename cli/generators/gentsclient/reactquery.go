@@ -0,0 +1,63 @@
+package gentsclient
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexisvisco/goframe/core/helpers/str"
+	"github.com/alexisvisco/goframe/http/apidoc"
+)
+
+// buildRouteHook generates the TanStack Query hook of a route function: GET routes become queries keyed
+// by their path and request, other methods become mutations taking the request as variables.
+//
+//	const { data } = useGetUser(fetcher, { pathParams: { id } });
+//	queryClient.invalidateQueries({ queryKey: getUserQueryKey({ pathParams: { id } }) });
+func (gen *TypescriptClientGenerator) buildRouteHook(route apidoc.Route, path, method, fnName string, hasRequest bool) string {
+	var requestType string
+	if hasRequest {
+		requestType = gen.schemaNameToExportedType(gen.lookup[route.Request.TypeName])
+	}
+	hookName := "use" + str.ToPascalCase(fnName)
+	dataType := fmt.Sprintf("Awaited<ReturnType<typeof %s>>", fnName)
+
+	sb := strings.Builder{}
+	if strings.EqualFold(method, "GET") {
+		keyName := fnName + "QueryKey"
+		if hasRequest {
+			sb.WriteString(fmt.Sprintf("export function %s(request: %s) {\n", keyName, requestType))
+			sb.WriteString(fmt.Sprintf("%sreturn ['%s', request] as const;\n", gen.indent(1), path))
+		} else {
+			sb.WriteString(fmt.Sprintf("export function %s() {\n", keyName))
+			sb.WriteString(fmt.Sprintf("%sreturn ['%s'] as const;\n", gen.indent(1), path))
+		}
+		sb.WriteString("}\n\n")
+
+		options := fmt.Sprintf("options?: Omit<UseQueryOptions<%s>, 'queryKey' | 'queryFn'>", dataType)
+		if hasRequest {
+			sb.WriteString(fmt.Sprintf("export function %s(fetcher: Fetcher, request: %s, %s) {\n", hookName, requestType, options))
+			sb.WriteString(fmt.Sprintf("%sreturn useQuery({\n", gen.indent(1)))
+			sb.WriteString(fmt.Sprintf("%squeryKey: %s(request),\n", gen.indent(2), keyName))
+			sb.WriteString(fmt.Sprintf("%squeryFn: () => %s(fetcher, request),\n", gen.indent(2), fnName))
+		} else {
+			sb.WriteString(fmt.Sprintf("export function %s(fetcher: Fetcher, %s) {\n", hookName, options))
+			sb.WriteString(fmt.Sprintf("%sreturn useQuery({\n", gen.indent(1)))
+			sb.WriteString(fmt.Sprintf("%squeryKey: %s(),\n", gen.indent(2), keyName))
+			sb.WriteString(fmt.Sprintf("%squeryFn: () => %s(fetcher),\n", gen.indent(2), fnName))
+		}
+	} else {
+		if hasRequest {
+			sb.WriteString(fmt.Sprintf("export function %s(fetcher: Fetcher, options?: Omit<UseMutationOptions<%s, Error, %s>, 'mutationFn'>) {\n", hookName, dataType, requestType))
+			sb.WriteString(fmt.Sprintf("%sreturn useMutation({\n", gen.indent(1)))
+			sb.WriteString(fmt.Sprintf("%smutationFn: (request: %s) => %s(fetcher, request),\n", gen.indent(2), requestType, fnName))
+		} else {
+			sb.WriteString(fmt.Sprintf("export function %s(fetcher: Fetcher, options?: Omit<UseMutationOptions<%s, Error, void>, 'mutationFn'>) {\n", hookName, dataType))
+			sb.WriteString(fmt.Sprintf("%sreturn useMutation({\n", gen.indent(1)))
+			sb.WriteString(fmt.Sprintf("%smutationFn: () => %s(fetcher),\n", gen.indent(2), fnName))
+		}
+	}
+	sb.WriteString(fmt.Sprintf("%s...options,\n", gen.indent(2)))
+	sb.WriteString(fmt.Sprintf("%s});\n", gen.indent(1)))
+	sb.WriteString("}")
+	return sb.String()
+}
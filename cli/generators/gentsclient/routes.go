@@ -2,6 +2,8 @@ package gentsclient
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/alexisvisco/goframe/core/helpers/str"
@@ -21,7 +23,21 @@ func (gen *TypescriptClientGenerator) hasRequestFields(route apidoc.Route) bool
 	return false
 }
 
+// AddRoute adds the client functions of a route. Tagged routes are added to the namespace of each
+// of their tags, other routes are grouped by handler struct and sub-package.
 func (gen *TypescriptClientGenerator) AddRoute(route apidoc.Route) {
+	if len(route.Tags) > 0 {
+		for _, tag := range route.Tags {
+			gen.addRouteToNamespace(str.ToCamelCase(tag), route)
+		}
+		return
+	}
+
+	gen.addRouteToNamespace(gen.routeNamespace(route), route)
+}
+
+// routeNamespace derives the namespace of an untagged route from its handler struct and sub-package.
+func (gen *TypescriptClientGenerator) routeNamespace(route apidoc.Route) string {
 	ns := "root"
 	if route.ParentStructName != nil {
 		ns = strings.TrimSuffix(*route.ParentStructName, "Handler")
@@ -40,52 +56,104 @@ func (gen *TypescriptClientGenerator) AddRoute(route apidoc.Route) {
 		}
 	}
 
-	ns = str.ToCamelCase(ns)
+	return str.ToCamelCase(ns)
+}
 
+func (gen *TypescriptClientGenerator) addRouteToNamespace(ns string, route apidoc.Route) {
 	if _, ok := gen.routeCode[ns]; !ok {
 		gen.routeCode[ns] = make(map[string]string)
 	}
 
-	for path, methods := range route.Paths {
-		for _, method := range methods {
-			baseName := route.Name
-
-			// Check if there's a specific name for this path/method combination
-			if route.NamedRoutes != nil {
-				if methodMap, pathExists := route.NamedRoutes[path]; pathExists {
-					if namedRoute, methodExists := methodMap[method]; methodExists && namedRoute != "" {
-						baseName = namedRoute
-					}
-				}
-			}
+	for _, endpoint := range routeEndpoints(route) {
+		path, method, baseName := endpoint.path, endpoint.method, endpoint.name
 
-			// Generate the function name
-			fnName := str.ToCamelCase(baseName)
+		// Generate the function name
+		fnName := str.ToCamelCase(baseName)
 
-			// Handle function name conflicts by prefixing with method
-			if _, exists := gen.routeCode[ns][fnName]; exists {
-				fnName = str.ToCamelCase(strings.ToLower(method) + "_" + baseName)
-			}
+		// Handle function name conflicts by prefixing with method
+		if _, exists := gen.routeCode[ns][fnName]; exists {
+			fnName = str.ToCamelCase(strings.ToLower(method) + "_" + baseName)
+		}
 
-			// If there's still a conflict, make it unique by adding the path
-			if _, exists := gen.routeCode[ns][fnName]; exists {
-				// Create a unique identifier from path and method
-				pathPart := strings.ReplaceAll(strings.ReplaceAll(path, "/", "_"), "{", "")
-				pathPart = strings.ReplaceAll(pathPart, "}", "")
-				pathPart = strings.Trim(pathPart, "_")
-				fnName = str.ToCamelCase(strings.ToLower(method) + "_" + pathPart + "_" + baseName)
-			}
+		// If there's still a conflict, make it unique by adding the path
+		if _, exists := gen.routeCode[ns][fnName]; exists {
+			// Create a unique identifier from path and method
+			pathPart := strings.ReplaceAll(strings.ReplaceAll(path, "/", "_"), "{", "")
+			pathPart = strings.ReplaceAll(pathPart, "}", "")
+			pathPart = strings.Trim(pathPart, "_")
+			fnName = str.ToCamelCase(strings.ToLower(method) + "_" + pathPart + "_" + baseName)
+		}
+
+		// Generate the function code
+		code := gen.buildRouteFunction(route, joinBasePath(gen.basePath, path), method, fnName)
+		gen.routeCode[ns][fnName] = code
+	}
+}
 
-			// Generate the function code
-			code := gen.buildRouteFunction(route, path, method, fnName)
-			gen.routeCode[ns][fnName] = code
+// routeDocComment renders the JSDoc of a route function: its description, method and path, and a
+// @deprecated tag for deprecated routes.
+func routeDocComment(route apidoc.Route, path, method string) string {
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	if description := strings.TrimSpace(route.Description); description != "" {
+		for _, line := range strings.Split(description, "\n") {
+			sb.WriteString(strings.TrimRight(" * "+escapeDocComment(line), " ") + "\n")
 		}
+		sb.WriteString(" *\n")
 	}
+	sb.WriteString(fmt.Sprintf(" * %s %s\n", method, escapeDocComment(path)))
+	if route.Deprecated {
+		sb.WriteString(" * @deprecated\n")
+	}
+	sb.WriteString(" */\n")
+	return sb.String()
+}
+
+// escapeDocComment prevents text from closing the comment it is written in.
+func escapeDocComment(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// routeEndpoint is a path and method of a route with the name of its client function.
+type routeEndpoint struct {
+	path   string
+	method string
+	name   string
+	named  bool // name comes from a name= annotation rather than the handler
+}
+
+// routeEndpoints lists the endpoints of a route, named ones first so their explicit names are kept
+// and handler-derived names are the ones disambiguated on conflicts. The order is stable across runs.
+func routeEndpoints(route apidoc.Route) []routeEndpoint {
+	var endpoints []routeEndpoint
+	for path, methods := range route.Paths {
+		for _, method := range methods {
+			endpoint := routeEndpoint{path: path, method: method, name: route.Name}
+			if namedRoute := route.NamedRoutes[path][method]; namedRoute != "" {
+				endpoint.name = namedRoute
+				endpoint.named = true
+			}
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		a, b := endpoints[i], endpoints[j]
+		if a.named != b.named {
+			return a.named
+		}
+		if a.path != b.path {
+			return a.path < b.path
+		}
+		return a.method < b.method
+	})
+	return endpoints
 }
 
 func (gen *TypescriptClientGenerator) buildRouteFunction(route apidoc.Route, path, method, fnName string) string {
 	sb := strings.Builder{}
 	responseType := gen.createResponseType(route)
+	headersType := responseHeadersType(route)
 	hasRequest := gen.hasRequestFields(route)
 
 	// Double-check that we have a schema in lookup if hasRequest is true
@@ -96,26 +164,38 @@ func (gen *TypescriptClientGenerator) buildRouteFunction(route apidoc.Route, pat
 		}
 	}
 
+	// The errors rethrown by the catch clause below
+	for _, name := range []string{"ErrorResponse", "FetchError", "ResponseParseError"} {
+		gen.useName(name)
+	}
+
+	sb.WriteString(routeDocComment(route, path, method))
 	if hasRequest {
-		sb.WriteString(fmt.Sprintf("export async function %s(fetcher: Fetcher, request: %s): Promise<{data: %s, status: number, headers: Headers}> {\n",
+		sb.WriteString(fmt.Sprintf("export async function %s(fetcher: Fetcher, request: %s): Promise<{data: %s, status: number, headers: %s}> {\n",
 			fnName,
-			gen.schemaNameToExportedType(gen.lookup[route.Request.TypeName]),
+			gen.useName(gen.schemaNameToExportedType(gen.lookup[route.Request.TypeName])),
 			responseType,
+			headersType,
 		))
-		sb.WriteString(fmt.Sprintf("%sconst parseResult = %s.safeParse(request);\n", gen.indent(1), gen.lookup[route.Request.TypeName]))
+		sb.WriteString(fmt.Sprintf("%sconst parseResult = %s.safeParse(request);\n", gen.indent(1), gen.useName(gen.lookup[route.Request.TypeName])))
 		sb.WriteString(fmt.Sprintf("%sif (!parseResult.success) {\n", gen.indent(1)))
-		sb.WriteString(fmt.Sprintf("%sthrow new RequestParseError(parseResult.error);\n", gen.indent(2)))
+		sb.WriteString(fmt.Sprintf("%sthrow new %s(parseResult.error);\n", gen.indent(2), gen.useName("RequestParseError")))
 		sb.WriteString(fmt.Sprintf("%s}\n", gen.indent(1)))
 		sb.WriteString(fmt.Sprintf("%sconst safeRequest = parseResult.data;\n", gen.indent(1)))
 	} else {
-		sb.WriteString(fmt.Sprintf("export async function %s(fetcher: Fetcher): Promise<{data: %s, status: number, headers: Headers}> {\n",
+		sb.WriteString(fmt.Sprintf("export async function %s(fetcher: Fetcher): Promise<{data: %s, status: number, headers: %s}> {\n",
 			fnName,
 			responseType,
+			headersType,
 		))
 	}
 
 	sb.WriteString(fmt.Sprintf("%slet options : FetcherOptions = {\n", gen.indent(1)))
-	sb.WriteString(fmt.Sprintf("%spath: '%s',\n", gen.indent(2), path))
+	if trimmed := trimTrailingSlash(path); gen.trimSlash && trimmed != path {
+		sb.WriteString(fmt.Sprintf("%spath: '%s', // declared as %s, the server ignores trailing slashes\n", gen.indent(2), trimmed, path))
+	} else {
+		sb.WriteString(fmt.Sprintf("%spath: '%s',\n", gen.indent(2), path))
+	}
 	sb.WriteString(fmt.Sprintf("%smethod: '%s',\n", gen.indent(2), method))
 	sb.WriteString(fmt.Sprintf("%s}\n\n", gen.indent(1)))
 
@@ -133,11 +213,24 @@ func (gen *TypescriptClientGenerator) buildRouteFunction(route apidoc.Route, pat
 			sb.WriteString(fmt.Sprintf("%ssetCookies(options, safeRequest.cookies);\n", gen.indent(1)))
 		}
 		if route.Request.HasBody() {
-			sb.WriteString(fmt.Sprintf("%ssetRequestBody(options, safeRequest.body);\n", gen.indent(1)))
+			// The body is serialized according to the media type, the content type is sent with its parameters
+			mediaType := apidoc.MediaType(route.RequestContentType)
+			switch {
+			case mediaType == apidoc.DefaultContentType:
+				sb.WriteString(fmt.Sprintf("%ssetRequestBody(options, safeRequest.body);\n", gen.indent(1)))
+			case mediaType == route.RequestContentType:
+				sb.WriteString(fmt.Sprintf("%ssetRequestBody(options, safeRequest.body, '%s');\n", gen.indent(1), route.RequestContentType))
+			default:
+				sb.WriteString(fmt.Sprintf("%ssetRequestBody(options, safeRequest.body, '%s', '%s');\n", gen.indent(1), route.RequestContentType, mediaType))
+			}
 		}
 	}
 
-	sb.WriteString(fmt.Sprintf("\n%sconst statusesAllowedToSchema: { pattern: RegExp, schema: ZodSchema<any>, raw?: boolean }[] = [%s];\n", gen.indent(1), gen.getAllowedStatusCodesToSchema(route.StatusToResponse)))
+	if !apidoc.IsJSONContentType(route.ResponseContentType) {
+		sb.WriteString(fmt.Sprintf("%ssetHeader(options, 'Accept', '%s');\n", gen.indent(1), route.ResponseContentType))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n%sconst statusesAllowedToSchema: { pattern: RegExp, schema: ZodSchema<any>, raw?: boolean | 'blob' | 'text' }[] = [%s];\n", gen.indent(1), gen.getAllowedStatusCodesToSchema(route.StatusToResponse, route.ResponseContentType)))
 
 	var constCall string
 	if hasRequest {
@@ -165,9 +258,26 @@ func (gen *TypescriptClientGenerator) buildRouteFunction(route apidoc.Route, pat
 	}
 	sb.WriteString(fmt.Sprintf("%s%s\n", gen.indent(1), constCall))
 	sb.WriteString("}")
+	if gen.reactQuery {
+		sb.WriteString("\n\n")
+		sb.WriteString(gen.buildRouteHook(route, path, method, fnName, hasRequest))
+	}
 	return sb.String()
 }
 
+// responseHeadersType returns the type of the response headers, completing the names documented with
+// response_header=, e.g. TypedHeaders<'X-Total-Count' | 'ETag'>.
+func responseHeadersType(route apidoc.Route) string {
+	if len(route.ResponseHeaders) == 0 {
+		return "Headers"
+	}
+	names := make([]string, len(route.ResponseHeaders))
+	for i, header := range route.ResponseHeaders {
+		names[i] = fmt.Sprintf("'%s'", header)
+	}
+	return fmt.Sprintf("TypedHeaders<%s>", strings.Join(names, " | "))
+}
+
 func (gen *TypescriptClientGenerator) createResponseType(route apidoc.Route) string {
 	var responses []apidoc.StatusToResponse
 	for _, response := range route.StatusToResponse {
@@ -175,6 +285,11 @@ func (gen *TypescriptClientGenerator) createResponseType(route apidoc.Route) str
 			responses = append(responses, response)
 		}
 	}
+	binary := isBinaryContentType(route.ResponseContentType)
+	text := !binary && !apidoc.IsJSONContentType(route.ResponseContentType)
+	if len(responses) == 0 && binary {
+		return "Blob"
+	}
 	if len(responses) == 0 {
 		return "void"
 	}
@@ -182,8 +297,16 @@ func (gen *TypescriptClientGenerator) createResponseType(route apidoc.Route) str
 	for _, resp := range responses {
 		if resp.IsRedirect {
 			types = append(types, "any")
+		} else if binary {
+			if !slices.Contains(types, "Blob") {
+				types = append(types, "Blob")
+			}
+		} else if text {
+			if !slices.Contains(types, "string") {
+				types = append(types, "string")
+			}
 		} else {
-			typ := gen.schemaNameToExportedType(gen.lookup[resp.Response.TypeName])
+			typ := gen.useName(gen.schemaNameToExportedType(gen.lookup[resp.Response.TypeName]))
 			types = append(types, typ)
 		}
 	}
@@ -202,10 +325,14 @@ func (gen *TypescriptClientGenerator) getFirstRoutePath(paths map[string][]strin
 	return ""
 }
 
-func (gen *TypescriptClientGenerator) getAllowedStatusCodesToSchema(responses []apidoc.StatusToResponse) string {
-	if len(responses) == 0 {
-		return ""
-	}
+// getAllowedStatusCodesToSchema builds the status pattern to schema table of a route.
+// Bodies of text and XML responses are read as a string (raw: 'text'), binary ones as a Blob (raw: 'blob'),
+// with a 2xx entry when the route documents no response. Untyped bodies are handed over without being
+// parsed (raw: true).
+func (gen *TypescriptClientGenerator) getAllowedStatusCodesToSchema(responses []apidoc.StatusToResponse, responseContentType string) string {
+	binary := isBinaryContentType(responseContentType)
+	text := !binary && !apidoc.IsJSONContentType(responseContentType)
+
 	var items []string
 	for _, response := range responses {
 		if response.IsError {
@@ -221,17 +348,56 @@ func (gen *TypescriptClientGenerator) getAllowedStatusCodesToSchema(responses []
 		} else if response.Response != nil {
 			schemaName := gen.lookup[response.Response.TypeName]
 			if schemaName != "" {
-				schema = schemaName
+				schema = gen.useName(schemaName)
 			} else {
 				schema = "z.any()"
 			}
 		}
 		raw := ""
-		if schema == "z.any()" {
+		switch {
+		case binary && !response.IsRedirect:
+			raw = ", raw: 'blob'"
+		case text && !response.IsRedirect:
+			raw = ", raw: 'text'"
+		case schema == "z.any()":
 			raw = ", raw: true"
 		}
 		item := fmt.Sprintf("{ pattern: %s, schema: %s%s }", pattern, schema, raw)
 		items = append(items, item)
 	}
+	if binary && len(items) == 0 {
+		items = append(items, "{ pattern: /^2[0-9]{2}$/, schema: z.any(), raw: 'blob' }")
+	}
 	return strings.Join(items, ",\n")
 }
+
+// isBinaryContentType tells whether responses of contentType are read as a Blob: anything but JSON, XML and text,
+// e.g. application/pdf, application/octet-stream or image/png.
+func isBinaryContentType(contentType string) bool {
+	mediaType := apidoc.MediaType(contentType)
+	switch {
+	case apidoc.IsJSONContentType(mediaType),
+		strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "/json"),
+		strings.HasSuffix(mediaType, "/xml"), strings.HasSuffix(mediaType, "+xml"):
+		return false
+	}
+	return true
+}
+
+// trimTrailingSlash removes the trailing slashes of path, the root path is kept as is.
+func trimTrailingSlash(path string) string {
+	if trimmed := strings.TrimRight(path, "/"); trimmed != "" {
+		return trimmed
+	}
+	return path
+}
+
+// joinBasePath prefixes path with basePath, avoiding duplicated or missing slashes.
+func joinBasePath(basePath, path string) string {
+	basePath = strings.Trim(basePath, "/")
+	if basePath == "" {
+		return path
+	}
+	return "/" + basePath + "/" + strings.TrimPrefix(path, "/")
+}
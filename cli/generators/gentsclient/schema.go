@@ -1,12 +1,16 @@
 package gentsclient
 
 import (
+	"cmp"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/alexisvisco/goframe/core/helpers/introspect"
 	"github.com/alexisvisco/goframe/core/helpers/str"
+	"golang.org/x/exp/maps"
 )
 
 // hasOnlyCtxTags returns true if the field has only ctx tags and no other serializable tags
@@ -69,7 +73,11 @@ func (gen *TypescriptClientGenerator) AddSchema(prefix string, isRequest bool, o
 		}
 
 		gen.lookup[lookupKey] = schemaName
-		gen.schemaCode[schemaName] = gen.generateZodSchema(schemaName, objectType, isRequest)
+		if objectType.Union != nil {
+			gen.schemaCode[schemaName] = gen.generateUnionSchema(schemaName, objectType, isRequest)
+		} else {
+			gen.schemaCode[schemaName] = gen.generateZodSchema(schemaName, objectType, isRequest)
+		}
 		gen.objects[schemaName] = objectType
 		gen.isRequest[schemaName] = isRequest
 		gen.schemaOrder = append(gen.schemaOrder, schemaName)
@@ -102,8 +110,22 @@ func (gen *TypescriptClientGenerator) generateZodSchema(schemaName string, obj i
 		if field.IsNotSerializable() || field.IsCtx() {
 			continue
 		}
-		zodType := gen.zodFieldType(field.Type, obj.TypeName, field.Name)
-		if field.Optional {
+		// a zod tag overrides the inferred schema, the TypeScript type is still inferred from the Go type
+		zodType := field.ZodSchema
+		if zodType == "" && field.Discriminator != "" {
+			zodType = fmt.Sprintf("z.literal('%s')", field.Discriminator)
+		} else if zodType == "" && field.HasJSONStringOption() && isNumber(field.Type) {
+			zodType = zodQuotedNumber(isRequest)
+		} else if zodType == "" {
+			zodType = gen.zodFieldType(field.Type, obj.TypeName, field.Name, isRequest) + zodValidations(field)
+		}
+		// a default already accepts a missing value, .optional() would keep undefined in the parsed request
+		defaultValue := gen.zodDefault(field, isRequest)
+		if defaultValue != "" {
+			zodType = fmt.Sprintf("%s.default(%s)", zodType, defaultValue)
+		} else if isNullable(field, isRequest) {
+			zodType = fmt.Sprintf("%s.nullable()", zodType)
+		} else if field.Optional {
 			zodType = fmt.Sprintf("%s.optional()", zodType)
 		}
 
@@ -163,8 +185,8 @@ func (gen *TypescriptClientGenerator) generateZodSchema(schemaName string, obj i
 			sb.WriteString(gen.indent(1) + "}),\n")
 		}
 
-		for key, value := range fields {
-			if key != "bodyForm" && key != "bodyJson" && value.Len() > 0 {
+		for _, key := range requestParamsOrder {
+			if value := fields[key]; value != nil && value.Len() > 0 {
 				sb.WriteString(fmt.Sprintf("%s%s: z.object({\n", gen.indent(1), key))
 				sb.WriteString(gen.addIndent(value.String(), 2))
 				sb.WriteString(gen.indent(1) + "}),\n")
@@ -182,6 +204,17 @@ func (gen *TypescriptClientGenerator) generateZodSchema(schemaName string, obj i
 	return sb.String()
 }
 
+// generateUnionSchema generates the schema of a discriminated union, after the schemas of its variants.
+func (gen *TypescriptClientGenerator) generateUnionSchema(schemaName string, obj introspect.ObjectType, isRequest bool) string {
+	var variants []string
+	for _, variant := range obj.Union.Variants {
+		gen.AddSchema("", isRequest, *variant)
+		variants = append(variants, gen.lookup[variant.TypeName])
+	}
+	return fmt.Sprintf("export const %s = z.discriminatedUnion('%s', [%s]);\n",
+		schemaName, obj.Union.Discriminator, strings.Join(variants, ", "))
+}
+
 // hasRecursiveReference checks if an object type contains a recursive reference to itself
 func (gen *TypescriptClientGenerator) hasRecursiveReference(obj introspect.ObjectType, targetTypeName string) bool {
 	return gen.checkFieldsForRecursion(obj.Fields, targetTypeName, make(map[string]bool))
@@ -226,19 +259,23 @@ func (gen *TypescriptClientGenerator) checkFieldTypeForRecursion(ft introspect.F
 	return false
 }
 
-func (gen *TypescriptClientGenerator) zodFieldType(ft introspect.FieldType, parentTypeName, fieldName string) string {
+func (gen *TypescriptClientGenerator) zodFieldType(ft introspect.FieldType, parentTypeName, fieldName string, isRequest bool) string {
 	zodFieldStr := strings.Builder{}
 	excludedObjectPrimitive := []introspect.FieldTypePrimitive{
 		introspect.FieldTypePrimitiveFile,
 		introspect.FieldTypePrimitiveTime,
 	}
 	if ft.Array != nil {
-		zodFieldStr.WriteString(fmt.Sprintf("z.array(%s)", gen.zodFieldType(ft.Array.ItemType, parentTypeName, fieldName)))
+		zodFieldStr.WriteString(fmt.Sprintf("z.array(%s)", gen.zodFieldType(ft.Array.ItemType, parentTypeName, fieldName, isRequest)))
 	} else if ft.Map != nil {
-		zodFieldStr.WriteString(fmt.Sprintf("z.record(%s, %s)", gen.zodFieldType(ft.Map.Key, parentTypeName, fieldName), gen.zodFieldType(ft.Map.Value, parentTypeName, fieldName)))
+		zodFieldStr.WriteString(fmt.Sprintf("z.record(%s, %s)", gen.zodRecordKeyType(ft.Map.Key, parentTypeName, fieldName, isRequest), gen.zodFieldType(ft.Map.Value, parentTypeName, fieldName, isRequest)))
 	} else if ft.Enum != nil {
 		gen.createEnumSchema("", *ft.Enum)
+		gen.trackEnumUsage(ft.Enum.TypeName, isRequest)
 		refSchema := gen.lookup[ft.Enum.TypeName]
+		if gen.isOpenEnum(isRequest) {
+			refSchema = gen.openEnumSchemas[ft.Enum.TypeName]
+		}
 		zodFieldStr.WriteString(refSchema)
 	} else if ft.Object != nil && !slices.Contains(excludedObjectPrimitive, ft.Primitive) {
 		if ft.Object.IsAnonymous {
@@ -269,12 +306,22 @@ func (gen *TypescriptClientGenerator) zodFieldType(ft introspect.FieldType, pare
 		case introspect.FieldTypePrimitiveBool:
 			zodFieldStr.WriteString("z.boolean()")
 		case introspect.FieldTypePrimitiveAny:
-			zodFieldStr.WriteString("z.any()")
+			if gen.unknownForAny {
+				zodFieldStr.WriteString("z.unknown()")
+			} else {
+				zodFieldStr.WriteString("z.any()")
+			}
 		case introspect.FieldTypePrimitiveFile:
 			zodFieldStr.WriteString("z.instanceof(File)")
 		case introspect.FieldTypePrimitiveTime:
 			gen.createDateSchema()
 			zodFieldStr.WriteString("dateSchema")
+		case introspect.FieldTypePrimitiveUnixTime:
+			gen.createUnixDateSchema()
+			zodFieldStr.WriteString("unixDateSchema")
+		case introspect.FieldTypePrimitiveUnixMilliTime:
+			gen.createUnixMilliDateSchema()
+			zodFieldStr.WriteString("unixMilliDateSchema")
 		case introspect.FieldTypePrimitiveDuration:
 			gen.createDurationSchema()
 			zodFieldStr.WriteString("durationSchema")
@@ -285,6 +332,142 @@ func (gen *TypescriptClientGenerator) zodFieldType(ft introspect.FieldType, pare
 	return zodFieldStr.String()
 }
 
+// zodRecordKeyType returns the key schema of a record. JSON object keys are always strings: encoding/json
+// writes integer keys, including integer enums, as decimal strings, validated as such since z.number() would
+// reject every key. Keys marshaled as text (encoding.TextMarshaler) are plain strings.
+func (gen *TypescriptClientGenerator) zodRecordKeyType(key introspect.FieldType, parentTypeName, fieldName string, isRequest bool) string {
+	switch {
+	case isStringRecordKey(key) && key.Enum != nil:
+		return gen.zodFieldType(key, parentTypeName, fieldName, isRequest)
+	case key.Primitive == introspect.FieldTypePrimitiveInt,
+		key.Enum != nil && len(key.Enum.KeyValuesInt) > 0:
+		return "z.string().regex(/^-?\\d+$/)"
+	default:
+		return "z.string()"
+	}
+}
+
+// zodValidations translates the common rules of a validate tag to Zod modifiers of a string, number or
+// array field, e.g. validate:"min=1,max=100" gives .min(1).max(100). Supported rules are min, max, len,
+// email, url and uuid, others are ignored as well as the rules after dive, which apply to slice items.
+func zodValidations(field introspect.Field) string {
+	isString := field.Type.Enum == nil && field.Type.Primitive == introspect.FieldTypePrimitiveString
+	isArray := field.Type.Array != nil
+	if !isString && !isArray && !isNumber(field.Type) {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, rule := range field.ValidateRules() {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "dive":
+			return sb.String()
+		case "min", "max":
+			if _, err := strconv.ParseFloat(param, 64); err == nil {
+				sb.WriteString(fmt.Sprintf(".%s(%s)", name, param))
+			}
+		case "len":
+			if _, err := strconv.Atoi(param); err == nil && (isString || isArray) {
+				sb.WriteString(fmt.Sprintf(".length(%s)", param))
+			}
+		case "email", "url", "uuid":
+			if isString {
+				sb.WriteString(fmt.Sprintf(".%s()", name))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// zodDefault returns the TypeScript literal of the default tag of a request field, e.g. 30, "x", true or
+// ["a", "b"] for a slice. Responses have no default as the server fills them, and values that do not
+// match the field type are left out rather than producing an invalid schema.
+func (gen *TypescriptClientGenerator) zodDefault(field introspect.Field, isRequest bool) string {
+	if !isRequest || field.Default == nil {
+		return ""
+	}
+	if field.Type.Array != nil {
+		var items []string
+		for _, value := range strings.Split(*field.Default, ",") {
+			if value = strings.TrimSpace(value); value == "" {
+				continue // skipped by the binder too
+			}
+			item := gen.zodDefaultLiteral(field.Type.Array.ItemType, value)
+			if item == "" {
+				return ""
+			}
+			items = append(items, item)
+		}
+		return fmt.Sprintf("[%s]", strings.Join(items, ", "))
+	}
+	return gen.zodDefaultLiteral(field.Type, *field.Default)
+}
+
+// zodDefaultLiteral returns the TypeScript literal of a string, number, boolean or enum default value.
+// Members of TypeScript enums are not assignable from literals, their defaults are left out.
+func (gen *TypescriptClientGenerator) zodDefaultLiteral(ft introspect.FieldType, value string) string {
+	if ft.Enum != nil {
+		if gen.enumStyle == EnumStyleEnum {
+			return ""
+		}
+		for _, v := range ft.Enum.KeyValuesString {
+			if v == value {
+				literal, _ := json.Marshal(value)
+				return string(literal)
+			}
+		}
+		for _, v := range ft.Enum.KeyValuesInt {
+			if strconv.Itoa(v) == value {
+				return value
+			}
+		}
+		return ""
+	}
+
+	switch ft.Primitive {
+	case introspect.FieldTypePrimitiveInt, introspect.FieldTypePrimitiveFloat:
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	case introspect.FieldTypePrimitiveBool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return strconv.FormatBool(b)
+		}
+	case introspect.FieldTypePrimitiveString:
+		literal, _ := json.Marshal(value)
+		return string(literal)
+	}
+	return ""
+}
+
+// isNullable tells whether a field is always present with null for nil. It only concerns responses,
+// requests keep such fields optional since a missing key decodes to nil.
+func isNullable(field introspect.Field, isRequest bool) bool {
+	return field.Nullable && !isRequest
+}
+
+// zodQuotedNumber returns the schema of a number exchanged as a JSON string with the json string option:
+// responses are coerced to numbers and requests are sent back as strings, as encoding/json expects them.
+func zodQuotedNumber(isRequest bool) string {
+	if isRequest {
+		return "z.coerce.number().transform(String)"
+	}
+	return "z.coerce.number()"
+}
+
+func isNumber(ft introspect.FieldType) bool {
+	return ft.Enum == nil && (ft.Primitive == introspect.FieldTypePrimitiveInt || ft.Primitive == introspect.FieldTypePrimitiveFloat)
+}
+
+// isStringRecordKey tells whether a map key is exchanged as is: a string or a string enum.
+func isStringRecordKey(key introspect.FieldType) bool {
+	if key.Enum != nil {
+		return len(key.Enum.KeyValuesString) > 0
+	}
+	return key.Primitive == introspect.FieldTypePrimitiveString
+}
+
 func (gen *TypescriptClientGenerator) createEnumSchema(prefix string, enum introspect.FieldTypeEnum) {
 	if _, ok := gen.lookup[enum.TypeName]; ok {
 		return
@@ -304,35 +487,72 @@ func (gen *TypescriptClientGenerator) createEnumSchema(prefix string, enum intro
 	enumName := str.ToPascalCase(name) + "Enum"
 	enumSchemaName := str.ToCamelCase(name) + "EnumSchema"
 
+	nativeEnum := gen.enumStyle == EnumStyleEnum
+	separator := ": "
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("export const %s = {\n", enumName))
-	for k, value := range enum.KeyValuesString {
+	if nativeEnum {
+		separator = " = "
+		sb.WriteString(fmt.Sprintf("export enum %s {\n", enumName))
+	} else {
+		sb.WriteString(fmt.Sprintf("export const %s = {\n", enumName))
+	}
+	for _, k := range sortedKeysByValue(enum.KeyValuesString) {
+		value := enum.KeyValuesString[k]
 		key := strings.TrimPrefix(k, typeName)
 		if key == "" {
 			key = k
 		}
-		sb.WriteString(fmt.Sprintf("%s%s: '%s',\n", gen.indent(1), strings.ToUpper(str.ToSnakeCase(key)), value))
+		sb.WriteString(fmt.Sprintf("%s%s%s'%s',\n", gen.indent(1), strings.ToUpper(str.ToSnakeCase(key)), separator, value))
 	}
-	for k, value := range enum.KeyValuesInt {
+	for _, k := range sortedKeysByValue(enum.KeyValuesInt) {
+		value := enum.KeyValuesInt[k]
 		key := strings.TrimPrefix(k, typeName)
 		if key == "" {
 			key = k
 		}
-		sb.WriteString(fmt.Sprintf("%s%s: %d,\n", gen.indent(1), strings.ToUpper(str.ToSnakeCase(key)), value))
+		sb.WriteString(fmt.Sprintf("%s%s%s%d,\n", gen.indent(1), strings.ToUpper(str.ToSnakeCase(key)), separator, value))
+	}
+	if nativeEnum {
+		sb.WriteString("}\n")
+		sb.WriteString(fmt.Sprintf("export const %s = z.nativeEnum(%s);\n", enumSchemaName, enumName))
+	} else {
+		sb.WriteString("} as const;\n")
+		sb.WriteString(fmt.Sprintf("export type %s = ValueOf<typeof %s>;\n", enumName, enumName))
+		gen.writeEnumUnion(&sb, enumSchemaName, enum)
 	}
-	sb.WriteString("} as const;\n")
-	sb.WriteString(fmt.Sprintf("export type %s = ValueOf<typeof %s>;\n", enumName, enumName))
 
+	// Open variant accepting values unknown to this client, used by response schemas
+	if gen.openResponseEnums {
+		openSchemaName := str.ToCamelCase(name) + "OpenEnumSchema"
+		fallback := "z.string()"
+		if len(enum.KeyValuesInt) > 0 {
+			fallback = "z.number()"
+		}
+		sb.WriteString(fmt.Sprintf("export const %s = z.union([%s, %s]);\n", openSchemaName, enumSchemaName, fallback))
+		gen.openEnumSchemas[enum.TypeName] = openSchemaName
+	}
+
+	gen.enums[enum.TypeName] = enum
+	gen.lookup[enum.TypeName] = enumSchemaName
+	gen.schemaCode[enumSchemaName] = sb.String()
+	gen.objects[enumSchemaName] = introspect.ObjectType{}
+	gen.schemaOrder = append(gen.schemaOrder, enumSchemaName)
+}
+
+// writeEnumUnion writes the schema of a const object enum, a union of its literal values.
+func (gen *TypescriptClientGenerator) writeEnumUnion(sb *strings.Builder, enumSchemaName string, enum introspect.FieldTypeEnum) {
 	sb.WriteString(fmt.Sprintf("export const %s = z.union([", enumSchemaName))
 	first := true
-	for _, value := range enum.KeyValuesString {
+	for _, k := range sortedKeysByValue(enum.KeyValuesString) {
+		value := enum.KeyValuesString[k]
 		if !first {
 			sb.WriteString(", ")
 		}
 		sb.WriteString(fmt.Sprintf("z.literal('%s')", value))
 		first = false
 	}
-	for _, value := range enum.KeyValuesInt {
+	for _, k := range sortedKeysByValue(enum.KeyValuesInt) {
+		value := enum.KeyValuesInt[k]
 		if !first {
 			sb.WriteString(", ")
 		}
@@ -340,11 +560,16 @@ func (gen *TypescriptClientGenerator) createEnumSchema(prefix string, enum intro
 		first = false
 	}
 	sb.WriteString("]);\n")
+}
 
-	gen.lookup[enum.TypeName] = enumSchemaName
-	gen.schemaCode[enumSchemaName] = sb.String()
-	gen.objects[enumSchemaName] = introspect.ObjectType{}
-	gen.schemaOrder = append(gen.schemaOrder, enumSchemaName)
+// sortedKeysByValue returns the keys of the values of an enum sorted by value, then by key for aliases,
+// which gives enum members in the same order on every run.
+func sortedKeysByValue[V cmp.Ordered](values map[string]V) []string {
+	keys := maps.Keys(values)
+	slices.SortFunc(keys, func(a, b string) int {
+		return cmp.Or(cmp.Compare(values[a], values[b]), cmp.Compare(a, b))
+	})
+	return keys
 }
 
 func (gen *TypescriptClientGenerator) createDurationSchema() {
@@ -372,6 +597,28 @@ func (gen *TypescriptClientGenerator) createDateSchema() {
 	gen.schemaOrder = append(gen.schemaOrder, "dateSchema")
 }
 
+// createUnixDateSchema creates the schema of times exchanged as Unix timestamps in seconds.
+func (gen *TypescriptClientGenerator) createUnixDateSchema() {
+	if _, ok := gen.lookup["unixDateSchema"]; ok {
+		return
+	}
+	gen.lookup["unixDateSchema"] = "unixDateSchema"
+	gen.schemaCode["unixDateSchema"] = "const unixDateSchema = z.number().transform((n) => new Date(n * 1000));\n"
+	gen.objects["unixDateSchema"] = introspect.ObjectType{}
+	gen.schemaOrder = append(gen.schemaOrder, "unixDateSchema")
+}
+
+// createUnixMilliDateSchema creates the schema of times exchanged as Unix timestamps in milliseconds.
+func (gen *TypescriptClientGenerator) createUnixMilliDateSchema() {
+	if _, ok := gen.lookup["unixMilliDateSchema"]; ok {
+		return
+	}
+	gen.lookup["unixMilliDateSchema"] = "unixMilliDateSchema"
+	gen.schemaCode["unixMilliDateSchema"] = "const unixMilliDateSchema = z.number().transform((n) => new Date(n));\n"
+	gen.objects["unixMilliDateSchema"] = introspect.ObjectType{}
+	gen.schemaOrder = append(gen.schemaOrder, "unixMilliDateSchema")
+}
+
 func (gen *TypescriptClientGenerator) createErrorSchema() {
 	if _, ok := gen.lookup["errorSchema"]; ok {
 		return
@@ -401,10 +648,15 @@ func (gen *TypescriptClientGenerator) schemaNameToExportedType(name string) stri
 	return str.ToPascalCase(name)
 }
 
+// requestParamsOrder is the order of the parameter groups of request schemas and interfaces, after the body.
+var requestParamsOrder = []string{"pathParams", "searchParams", "headers", "cookies"}
+
 func (gen *TypescriptClientGenerator) createInterfaces() string {
-	excludedSchemas := []string{"errorSchema", "dateSchema", "durationSchema"}
+	excludedSchemas := []string{"errorSchema", "dateSchema", "unixDateSchema", "unixMilliDateSchema", "durationSchema"}
 	var sb strings.Builder
-	for schemaName, obj := range gen.objects {
+	// schemaOrder rather than the objects map, so that the output is the same from one run to another
+	for _, schemaName := range gen.schemaOrder {
+		obj := gen.objects[schemaName]
 		if slices.Contains(excludedSchemas, schemaName) {
 			continue
 		}
@@ -412,6 +664,14 @@ func (gen *TypescriptClientGenerator) createInterfaces() string {
 			continue
 		}
 		interfaceName := gen.schemaNameToExportedType(schemaName)
+		if obj.Union != nil {
+			var variants []string
+			for _, variant := range obj.Union.Variants {
+				variants = append(variants, gen.schemaNameToExportedType(gen.lookup[variant.TypeName]))
+			}
+			sb.WriteString(fmt.Sprintf("export type %s = %s;\n", interfaceName, strings.Join(variants, " | ")))
+			continue
+		}
 		// Generate an ISO TypeScript interface mirroring the schema structure
 		sb.WriteString(fmt.Sprintf("export interface %s {\n", interfaceName))
 
@@ -433,9 +693,12 @@ func (gen *TypescriptClientGenerator) createInterfaces() string {
 				if field.IsNotSerializable() || hasOnlyCtxTags(field) {
 					continue
 				}
-				tsType := gen.tsFieldType(field.Type, obj.TypeName, field.Name)
+				tsType := gen.tsFieldType(field.Type, obj.TypeName, field.Name, gen.isRequest[schemaName])
+				if field.Discriminator != "" {
+					tsType = fmt.Sprintf("'%s'", field.Discriminator)
+				}
 				optional := ""
-				if field.Optional {
+				if field.Optional || gen.zodDefault(field, true) != "" {
 					optional = "?"
 				}
 				for _, t := range field.Tags {
@@ -483,8 +746,7 @@ func (gen *TypescriptClientGenerator) createInterfaces() string {
 				sb.WriteString(gen.indent(1) + "};\n")
 			}
 
-			order := []string{"pathParams", "searchParams", "headers", "cookies"}
-			for _, key := range order {
+			for _, key := range requestParamsOrder {
 				if fields[key] != nil && fields[key].Len() > 0 {
 					sb.WriteString(fmt.Sprintf("%s%s: {\n", gen.indent(1), key))
 					sb.WriteString(gen.addIndent(fields[key].String(), 2))
@@ -496,9 +758,14 @@ func (gen *TypescriptClientGenerator) createInterfaces() string {
 				if field.IsNotSerializable() || hasOnlyCtxTags(field) {
 					continue
 				}
-				tsType := gen.tsFieldType(field.Type, obj.TypeName, field.Name)
+				tsType := gen.tsFieldType(field.Type, obj.TypeName, field.Name, gen.isRequest[schemaName])
+				if field.Discriminator != "" {
+					tsType = fmt.Sprintf("'%s'", field.Discriminator)
+				}
 				optional := ""
-				if field.Optional {
+				if isNullable(field, gen.isRequest[schemaName]) {
+					tsType += " | null"
+				} else if field.Optional {
 					optional = "?"
 				}
 				sb.WriteString(fmt.Sprintf("%s%s%s: %s;\n", gen.indent(1), field.ExposedName(), optional, tsType))
@@ -510,14 +777,25 @@ func (gen *TypescriptClientGenerator) createInterfaces() string {
 	return sb.String()
 }
 
-func (gen *TypescriptClientGenerator) tsFieldType(ft introspect.FieldType, parentTypeName, fieldName string) string {
+func (gen *TypescriptClientGenerator) tsFieldType(ft introspect.FieldType, parentTypeName, fieldName string, isRequest bool) string {
 	if ft.Array != nil {
-		return fmt.Sprintf("Array<%s>", gen.tsFieldType(ft.Array.ItemType, parentTypeName, fieldName))
+		return fmt.Sprintf("Array<%s>", gen.tsFieldType(ft.Array.ItemType, parentTypeName, fieldName, isRequest))
 	} else if ft.Map != nil {
-		return fmt.Sprintf("Record<%s, %s>", gen.tsFieldType(ft.Map.Key, parentTypeName, fieldName), gen.tsFieldType(ft.Map.Value, parentTypeName, fieldName))
+		keyType := "string" // non-string keys are exchanged as strings, see zodRecordKeyType
+		if isStringRecordKey(ft.Map.Key) && ft.Map.Key.Enum != nil {
+			keyType = gen.tsFieldType(ft.Map.Key, parentTypeName, fieldName, isRequest)
+		}
+		return fmt.Sprintf("Record<%s, %s>", keyType, gen.tsFieldType(ft.Map.Value, parentTypeName, fieldName, isRequest))
 	} else if ft.Enum != nil {
 		enumSchema := gen.lookup[ft.Enum.TypeName]
-		return gen.schemaNameToExportedType(enumSchema)
+		enumType := gen.schemaNameToExportedType(enumSchema)
+		if gen.isOpenEnum(isRequest) {
+			if len(ft.Enum.KeyValuesInt) > 0 {
+				return fmt.Sprintf("%s | (number & {})", enumType)
+			}
+			return fmt.Sprintf("%s | (string & {})", enumType)
+		}
+		return enumType
 	} else if ft.Object != nil && ft.Primitive != introspect.FieldTypePrimitiveFile && ft.Primitive != introspect.FieldTypePrimitiveTime {
 		if ft.Object.IsAnonymous {
 			// Generate name for anonymous struct based on parent type and field name
@@ -547,10 +825,13 @@ func (gen *TypescriptClientGenerator) tsFieldType(ft introspect.FieldType, paren
 		case introspect.FieldTypePrimitiveBool:
 			return "boolean"
 		case introspect.FieldTypePrimitiveAny:
+			if gen.unknownForAny {
+				return "unknown"
+			}
 			return "any"
 		case introspect.FieldTypePrimitiveFile:
 			return "File"
-		case introspect.FieldTypePrimitiveTime:
+		case introspect.FieldTypePrimitiveTime, introspect.FieldTypePrimitiveUnixTime, introspect.FieldTypePrimitiveUnixMilliTime:
 			return "Date"
 		case introspect.FieldTypePrimitiveDuration:
 			return "Duration"
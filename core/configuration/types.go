@@ -18,6 +18,9 @@ type (
 		Host string `yaml:"host"`
 		Port string `yaml:"port"`
 		URL  string `yaml:"url"`
+
+		// IgnoreTrailingSlash serves /users/ and /users with the same route, see middlewares.TrailingSlash
+		IgnoreTrailingSlash bool `yaml:"ignore_trailing_slash"`
 	}
 
 	Logging struct {
@@ -3,10 +3,12 @@ package introspect
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"golang.org/x/tools/go/packages"
 )
@@ -16,49 +18,69 @@ type Field struct {
 	Tags     []FieldTag `json:"tags"` // Changed from Tag to Tags (list)
 	Type     FieldType  `json:"type"`
 	Optional bool       `json:"optional"` // New field for optional detection
+
+	// Nullable is set on pointers of the JSON body written as null when nil, i.e. without omitempty or omitzero.
+	// Such fields are Optional too, as the absence of the key decodes to nil.
+	Nullable bool `json:"nullable,omitempty"`
+
+	// Sortable and Filterable are column hints for generated clients, derived from GORM index tags
+	// (index, uniqueIndex, primaryKey) or set explicitly with goframe:"sortable,filterable".
+	Sortable   bool `json:"sortable,omitempty"`
+	Filterable bool `json:"filterable,omitempty"`
+
+	// ZodSchema is the Zod schema set with a zod tag (e.g. zod:"z.string().min(3)"), generated clients use it
+	// verbatim instead of inferring one from the field type.
+	ZodSchema string `json:"zod_schema,omitempty"`
+
+	// Default is the raw value of the default tag (e.g. default:"30"), applied by the params binder when the
+	// request leaves the field empty. Slice defaults are comma separated. It is nil without a default tag.
+	Default *string `json:"default,omitempty"`
+
+	// Discriminator is the value of the discriminator tag (e.g. discriminator:"card"), set on the field telling
+	// the variants of a union apart, see ObjectType.Union.
+	Discriminator string `json:"discriminator,omitempty"`
+
+	// Doc is the Go doc comment of the field, or its trailing line comment.
+	Doc string `json:"doc,omitempty"`
+
+	// depth is the number of embedded structs the field is promoted through, 0 for a declared field.
+	depth int
 }
 
 func (f Field) ExposedName() string {
 	// if json tag is present, use that as the name
 	// otherwise use query, path, header, etc. tags until the Name
+	if name, tagged := f.taggedName(); tagged {
+		return name
+	}
+	return f.Name
+}
 
+// taggedName returns the name given to the field by its json, query, path, header, form, cookie or file tag.
+func (f Field) taggedName() (string, bool) {
 	for _, tag := range f.Tags {
-		if tag.Key == FieldKindJSON && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindQuery && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindPath && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindHeader && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindForm && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindCookie && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindFile && tag.Value != "" {
-			return tag.Value
-		}
-		if tag.Key == FieldKindFiles && tag.Value != "" {
-			return tag.Value
+		switch tag.Key {
+		case FieldKindJSON:
+			if tag.Value != "" && !tag.IsJSONIgnore() {
+				return tag.Value, true
+			}
+		case FieldKindQuery, FieldKindPath, FieldKindHeader, FieldKindForm, FieldKindCookie, FieldKindFile, FieldKindFiles:
+			if tag.Value != "" {
+				return tag.Value, true
+			}
 		}
 	}
-
-	return f.Name
+	return "", false
 }
 
-// IsNotSerializable Field is not serializable if it has json:"-" tag and no other field kind tags
+// IsNotSerializable Field is not serializable if it has json:"-" tag and no other field kind tags.
+// json:"-," and json:"-,omitempty" name the field "-" and are serializable, like with encoding/json.
 func (f Field) IsNotSerializable() bool {
 	var hasJSONTag bool
 	var hasOtherTags bool
 
 	for _, tag := range f.Tags {
-		if tag.Key == FieldKindJSON && tag.Value == "-" {
+		if tag.IsJSONIgnore() {
 			hasJSONTag = true
 		} else if _, exists := tags[tag.Key]; exists {
 			hasOtherTags = true
@@ -68,6 +90,36 @@ func (f Field) IsNotSerializable() bool {
 	return hasJSONTag && !hasOtherTags
 }
 
+// ValidateRules returns the rules of the validate tag, e.g. [required min=1 max=100].
+func (f Field) ValidateRules() []string {
+	for _, tag := range f.Tags {
+		if tag.Key == FieldKindValidate {
+			return tag.Options
+		}
+	}
+	return nil
+}
+
+// HasJSONStringOption tells whether the json tag has the string option, with which encoding/json
+// exchanges numbers and booleans as JSON strings, e.g. json:"id,string" gives "42".
+func (f Field) HasJSONStringOption() bool {
+	return f.hasJSONOption("string")
+}
+
+// HasOmitempty tells whether the json tag has the omitempty option.
+func (f Field) HasOmitempty() bool {
+	return f.hasJSONOption("omitempty")
+}
+
+func (f Field) hasJSONOption(option string) bool {
+	for _, tag := range f.Tags {
+		if tag.Key == FieldKindJSON && tag.HasOption(option) {
+			return true
+		}
+	}
+	return false
+}
+
 func (f Field) PathParam() (*FieldTag, bool) {
 	for _, tag := range f.Tags {
 		if tag.Key == FieldKindPath {
@@ -108,6 +160,7 @@ const (
 	FieldKindFiles    FieldKind = "files"
 	FieldKindOptional FieldKind = "optional"
 	FieldKindCtx      FieldKind = "ctx"
+	FieldKindValidate FieldKind = "validate" // validation rules, all in Options, e.g. validate:"min=1,max=100"
 )
 
 var tags = map[FieldKind]struct{}{
@@ -129,16 +182,51 @@ type FieldTag struct {
 	Options []string  `json:"options"` // Additional options like "omitempty", "required", etc.
 }
 
+// IsJSONIgnore tells whether the tag is json:"-", which leaves the field out of JSON.
+// A "-" followed by options, as in json:"-,", is the name of the field instead.
+func (t FieldTag) IsJSONIgnore() bool {
+	return t.Key == FieldKindJSON && t.Value == "-" && len(t.Options) == 0
+}
+
+// HasOption tells whether the tag has an option, e.g. omitempty in json:"name,omitempty".
+func (t FieldTag) HasOption(option string) bool {
+	return slices.Contains(t.Options, option)
+}
+
 type ObjectType struct {
 	TypeName    string  `json:"type_name"`
 	IsAnonymous bool    `json:"is_anonymous,omitempty"`
 	Fields      []Field `json:"fields"`
+	Doc         string  `json:"doc,omitempty"` // The Go doc comment of the struct type
+
+	// Union is set on interfaces implemented by structs told apart by a discriminator field, the object
+	// then has no fields and its value is one of the variants.
+	Union *ObjectUnion `json:"union,omitempty"`
+}
+
+// ObjectUnion lists the variants of a discriminated union.
+type ObjectUnion struct {
+	Discriminator string        `json:"discriminator"` // exposed name of the field telling variants apart, e.g. type
+	Variants      []*ObjectType `json:"variants"`
+}
+
+// DiscriminatorField returns the field of a union variant tagged discriminator.
+func (o ObjectType) DiscriminatorField() (Field, bool) {
+	for _, field := range o.Fields {
+		if field.Discriminator != "" {
+			return field, true
+		}
+	}
+	return Field{}, false
 }
 
 // Generic helper method to check for any field kind
 func (o ObjectType) hasFieldKind(kinds ...string) bool {
 	for _, field := range o.Fields {
 		for _, tag := range field.Tags {
+			if tag.IsJSONIgnore() {
+				continue
+			}
 			has := slices.Contains(kinds, tag.Key)
 			if has {
 				return true
@@ -216,6 +304,9 @@ const (
 	FieldTypePrimitiveEnum     FieldTypePrimitive = "enum"
 	FieldTypePrimitiveAny      FieldTypePrimitive = "any"
 	FieldTypePrimitiveFile     FieldTypePrimitive = "file"
+	FieldTypePrimitiveUnixTime FieldTypePrimitive = "unix_time" // time.Time exchanged as Unix seconds, see timeformat:"unix"
+
+	FieldTypePrimitiveUnixMilliTime FieldTypePrimitive = "unix_milli_time" // time.Time exchanged as Unix milliseconds, see timeformat:"unixmilli"
 )
 
 // ParseContext holds the parsing state to prevent circular references
@@ -225,44 +316,37 @@ type ParseContext struct {
 	Packages    map[string]*packages.Package
 	EnumsParsed map[string]bool // key: package path - tracks which packages have had enums parsed
 	RootPath    string
+	ModulePath  string // module at RootPath, read from its go.mod when empty
 }
 
+// ParseStruct parses a struct of the module at rootPath. Every call loads the packages again, use a Parser
+// to parse several structs.
 func ParseStruct(rootPath, relPkgPath, structName string) (*ObjectType, error) {
-	ctx := &ParseContext{
+	return NewParser(rootPath).ParseStruct(relPkgPath, structName)
+}
+
+// Parser parses the structs of a module. It keeps the loaded packages and the parsed types and enums across
+// calls, which makes parsing the many request and response types of a generator run much faster:
+// create one per run. A Parser is not safe for concurrent use.
+type Parser struct {
+	*ParseContext
+}
+
+// NewParser creates a Parser for the module at rootPath.
+func NewParser(rootPath string) *Parser {
+	return &Parser{ParseContext: &ParseContext{
 		Visited:     make(map[string]*ObjectType),
 		Enums:       make(map[string]*FieldTypeEnum),
 		Packages:    make(map[string]*packages.Package),
 		EnumsParsed: make(map[string]bool),
 		RootPath:    rootPath,
-	}
-
-	// Load the target package
-	pkg, err := ctx.LoadPackage(relPkgPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load package %s: %w", relPkgPath, err)
-	}
-
-	// Find the struct type
-	obj := pkg.Types.Scope().Lookup(structName)
-	if obj == nil {
-		return nil, fmt.Errorf("struct %s not found in package %s", structName, relPkgPath)
-	}
-
-	namedType, ok := obj.Type().(*types.Named)
-	if !ok {
-		return nil, fmt.Errorf("%s is not a named type", structName)
-	}
-
-	structType, ok := namedType.Underlying().(*types.Struct)
-	if !ok {
-		return nil, fmt.Errorf("%s is not a struct type", structName)
-	}
-
-	// Parse Enums for this package
-	ctx.ParseEnums(pkg)
+	}}
+}
 
-	// Parse the struct
-	return ctx.parseStruct(pkg, structType, namedType)
+// ParseStruct parses a struct by package path and struct name. Parsing the same struct again returns the
+// same *ObjectType, callers must not modify it.
+func (p *Parser) ParseStruct(relPkgPath, structName string) (*ObjectType, error) {
+	return p.ParseStructByName(relPkgPath, structName)
 }
 
 func (ctx *ParseContext) LoadPackage(relPkgPath string) (*packages.Package, error) {
@@ -298,7 +382,7 @@ func (ctx *ParseContext) LoadPackage(relPkgPath string) (*packages.Package, erro
 func (ctx *ParseContext) parseStruct(pkg *packages.Package, structType *types.Struct, namedType *types.Named) (*ObjectType, error) {
 	// Check if already Visited (circular reference protection)
 	// Use the actual package where the type is defined, not the package being processed
-	typeKey := fmt.Sprintf("%s.%s", namedType.Obj().Pkg().Path(), namedType.Obj().Name())
+	typeKey := namedTypeKey(namedType)
 	if obj, visited := ctx.Visited[typeKey]; visited {
 		return obj, nil
 	}
@@ -325,32 +409,72 @@ func (ctx *ParseContext) parseStruct(pkg *packages.Package, structType *types.St
 	var astStruct *ast.StructType
 	for _, file := range actualPkg.Syntax {
 		ast.Inspect(file, func(n ast.Node) bool {
-			if ts, ok := n.(*ast.TypeSpec); ok && ts.Name.Name == namedType.Obj().Name() {
+			genDecl, ok := n.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				return astStruct == nil
+			}
+			for _, spec := range genDecl.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Name.Name != namedType.Obj().Name() {
+					continue
+				}
 				if st, ok := ts.Type.(*ast.StructType); ok {
 					astStruct = st
+					// The doc of a type declared alone (type X struct) is attached to its declaration
+					doc := ts.Doc
+					if doc == nil && !genDecl.Lparen.IsValid() {
+						doc = genDecl.Doc
+					}
+					obj.Doc = strings.TrimSpace(doc.Text())
 					return false
 				}
 			}
-			return true
+			return false
 		})
 		if astStruct != nil {
 			break
 		}
 	}
 
-	// Parse each field
+	// One AST field declares several struct fields when they share a type (A, B string)
+	var astFields []*ast.Field
+	if astStruct != nil {
+		for _, astField := range astStruct.Fields.List {
+			for range max(len(astField.Names), 1) {
+				astFields = append(astFields, astField)
+			}
+		}
+	}
+
+	// Parse each field, the fields of embedded structs are flattened after the fields of the struct
+	var embedded []Field
 	for i := 0; i < structType.NumFields(); i++ {
 		field := structType.Field(i)
 		structTag := structType.Tag(i)
 
-		if !field.Exported() {
+		if !field.Exported() && !field.Embedded() {
 			continue // Skip unexported fields
 		}
+		if isExcludedFromCodegen(structTag) || isQuerySetField(structTag) {
+			continue
+		}
+
+		promoted, ok, err := ctx.embeddedFields(actualPkg, field, structTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse embedded field %s: %w", field.Name(), err)
+		}
+		if ok {
+			embedded = append(embedded, promoted...)
+			continue
+		}
+		if !field.Exported() {
+			continue // Skip unexported fields, the fields of unexported embedded structs are still promoted
+		}
 
 		// Get AST field for additional info
 		var astField *ast.Field
-		if astStruct != nil && i < len(astStruct.Fields.List) {
-			astField = astStruct.Fields.List[i]
+		if i < len(astFields) {
+			astField = astFields[i]
 		}
 
 		parsedField, err := ctx.parseField(actualPkg, field, structTag, astField)
@@ -360,10 +484,147 @@ func (ctx *ParseContext) parseStruct(pkg *packages.Package, structType *types.St
 
 		obj.Fields = append(obj.Fields, *parsedField)
 	}
+	obj.Fields = appendPromotedFields(obj.Fields, embedded)
 
 	return obj, nil
 }
 
+// embeddedFields returns the fields of an embedded struct that encoding/json flattens into its parent:
+// an embedded struct, or pointer to a struct, without a json name or tagged json:",inline".
+// ok is false for other fields, which are parsed as regular fields.
+func (ctx *ParseContext) embeddedFields(pkg *packages.Package, field *types.Var, structTag string) (fields []Field, ok bool, err error) {
+	if !field.Embedded() {
+		return nil, false, nil
+	}
+
+	name, options, _ := strings.Cut(reflect.StructTag(structTag).Get("json"), ",")
+	if name != "" && !slices.Contains(strings.Split(options, ","), "inline") {
+		return nil, false, nil
+	}
+
+	t := field.Type()
+	if pointer, isPointer := t.(*types.Pointer); isPointer {
+		if !field.Exported() {
+			return nil, false, nil // encoding/json ignores pointers to unexported struct types
+		}
+		t = pointer.Elem()
+	}
+	if _, isStruct := t.Underlying().(*types.Struct); !isStruct {
+		return nil, false, nil
+	}
+
+	fieldType, err := ctx.parseType(pkg, t)
+	if err != nil {
+		return nil, false, err
+	}
+	if fieldType.Object == nil {
+		return nil, false, nil // structs exchanged as primitives, like time.Time
+	}
+
+	// The parsed struct is shared with its other uses, its fields are copied one level deeper
+	fields = make([]Field, len(fieldType.Object.Fields))
+	for i, promoted := range fieldType.Object.Fields {
+		promoted.depth++
+		fields[i] = promoted
+	}
+	return fields, true, nil
+}
+
+// appendPromotedFields appends the fields promoted from embedded structs to the fields declared by their
+// parent, resolving name conflicts as encoding/json does: a field declared by the parent wins over promoted
+// fields with the same exposed name, then the least nested promoted fields win. Promoted fields conflicting
+// at the same depth are all dropped, unless exactly one of them is named by a tag.
+func appendPromotedFields(fields, promoted []Field) []Field {
+	declared := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		declared[field.ExposedName()] = true
+	}
+
+	candidates := make(map[string][]Field, len(promoted))
+	var names []string
+	for _, field := range promoted {
+		name := field.ExposedName()
+		if declared[name] {
+			continue
+		}
+		if _, seen := candidates[name]; !seen {
+			names = append(names, name)
+		}
+		candidates[name] = append(candidates[name], field)
+	}
+
+	for _, name := range names {
+		if field, ok := dominantField(candidates[name]); ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// dominantField returns the field encoding/json keeps among promoted fields with the same exposed name.
+func dominantField(fields []Field) (Field, bool) {
+	depth := fields[0].depth
+	for _, field := range fields[1:] {
+		depth = min(depth, field.depth)
+	}
+
+	var shallowest, tagged []Field
+	for _, field := range fields {
+		if field.depth != depth {
+			continue
+		}
+		shallowest = append(shallowest, field)
+		if _, ok := field.taggedName(); ok {
+			tagged = append(tagged, field)
+		}
+	}
+	if len(shallowest) == 1 {
+		return shallowest[0], true
+	}
+	if len(tagged) == 1 {
+		return tagged[0], true
+	}
+	return Field{}, false
+}
+
+// namedTypeKey returns the package qualified name of a named type.
+// The type arguments of an instantiated generic type are appended to its name, Response[Page[User]] is
+// ResponsePageUser: each instantiation is parsed with its own field types and keeps a name generated
+// clients can use as an identifier.
+func namedTypeKey(named *types.Named) string {
+	return fmt.Sprintf("%s.%s", named.Obj().Pkg().Path(), instanceName(named))
+}
+
+// instanceName returns the name of a named type followed by the names of its type arguments.
+func instanceName(named *types.Named) string {
+	name := named.Obj().Name()
+	args := named.TypeArgs()
+	for i := 0; i < args.Len(); i++ {
+		name += typeArgName(args.At(i))
+	}
+	return name
+}
+
+// typeArgName returns the name of a type argument as it appears in instanceName.
+func typeArgName(t types.Type) string {
+	switch typ := t.(type) {
+	case *types.Named:
+		return instanceName(typ)
+	case *types.Basic:
+		return strings.ToUpper(typ.Name()[:1]) + typ.Name()[1:]
+	case *types.Pointer:
+		return typeArgName(typ.Elem())
+	case *types.Slice:
+		return typeArgName(typ.Elem()) + "List"
+	case *types.Array:
+		return typeArgName(typ.Elem()) + "List"
+	case *types.Map:
+		return typeArgName(typ.Key()) + typeArgName(typ.Elem()) + "Map"
+	default:
+		return "Any"
+	}
+}
+
 func (ctx *ParseContext) parseField(pkg *packages.Package, field *types.Var, structTag string, astField *ast.Field) (*Field, error) {
 	fieldName := field.Name()
 
@@ -398,17 +659,122 @@ func (ctx *ParseContext) parseField(pkg *packages.Package, field *types.Var, str
 		}
 	}
 
+	tag := reflect.StructTag(structTag)
+	if fieldType.Primitive == FieldTypePrimitiveTime && isBoundFromString(tag, fieldTags) {
+		fieldType.Primitive = timeFormatPrimitive(tag)
+	}
+
 	// Determine if field is optional
 	optional := ctx.isFieldOptional(fieldTags, isPointer)
+	nullable := ctx.isFieldNullable(fieldTags, isPointer)
+
+	sortable, filterable := ctx.parseColumnHints(structTag)
 
 	return &Field{
-		Name:     fieldName,
-		Tags:     fieldTags,
-		Type:     *fieldType,
-		Optional: optional,
+		Name:       fieldName,
+		Tags:       fieldTags,
+		Type:       *fieldType,
+		Optional:   optional,
+		Nullable:   nullable,
+		Sortable:   sortable,
+		Filterable: filterable,
+		ZodSchema:  strings.TrimSpace(tag.Get("zod")),
+		Default:    fieldDefault(tag),
+		Doc:        fieldDoc(astField),
+
+		Discriminator: tag.Get("discriminator"),
 	}, nil
 }
 
+// isBoundFromString tells whether params binds a field from a string, a query, form, path, header or cookie
+// parameter. The time format tag only applies to such fields, JSON bodies keep the RFC 3339 dates of encoding/json.
+func isBoundFromString(tag reflect.StructTag, fieldTags []FieldTag) bool {
+	if _, hasJSON := tag.Lookup("json"); hasJSON {
+		return false
+	}
+	for _, fieldTag := range fieldTags {
+		switch fieldTag.Key {
+		case FieldKindQuery, FieldKindForm, FieldKindPath, FieldKindHeader, FieldKindCookie:
+			return true
+		}
+	}
+	return false
+}
+
+// timeFormatPrimitive returns the primitive of a time field bound from a string, following the layout of its time
+// format tag as params binds it: Unix timestamps in seconds or milliseconds, RFC 3339 dates, or strings of a
+// custom layout.
+func timeFormatPrimitive(tag reflect.StructTag) FieldTypePrimitive {
+	layout, ok := tag.Lookup("timeformat")
+	switch {
+	case !ok, layout == time.RFC3339, layout == time.RFC3339Nano:
+		return FieldTypePrimitiveTime
+	case layout == "unix":
+		return FieldTypePrimitiveUnixTime
+	case layout == "unixmilli":
+		return FieldTypePrimitiveUnixMilliTime
+	default:
+		return FieldTypePrimitiveString
+	}
+}
+
+// fieldDefault returns the value of the default tag, nil when the field has none.
+func fieldDefault(tag reflect.StructTag) *string {
+	if value, ok := tag.Lookup("default"); ok {
+		return &value
+	}
+	return nil
+}
+
+// fieldDoc returns the doc comment of a field, or its trailing line comment when it has none.
+func fieldDoc(astField *ast.Field) string {
+	if astField == nil {
+		return ""
+	}
+	if astField.Doc != nil {
+		return strings.TrimSpace(astField.Doc.Text())
+	}
+	return strings.TrimSpace(astField.Comment.Text())
+}
+
+// isExcludedFromCodegen tells whether a field is tagged codegen:"-". Such fields are left out of generated
+// clients and schemas while still being serialized at runtime, unlike json:"-".
+func isExcludedFromCodegen(structTag string) bool {
+	return reflect.StructTag(structTag).Get("codegen") == "-"
+}
+
+// isQuerySetField tells whether a field is tagged queryset. Such fields only report whether a query
+// parameter was sent, clients have nothing to send for them and they are left out of generated code.
+func isQuerySetField(structTag string) bool {
+	_, ok := reflect.StructTag(structTag).Lookup("queryset")
+	return ok
+}
+
+// parseColumnHints tells whether a field can be used to sort or filter a listing.
+// Indexed GORM columns are both sortable and filterable, the goframe tag sets them explicitly.
+func (ctx *ParseContext) parseColumnHints(structTag string) (sortable, filterable bool) {
+	tag := reflect.StructTag(structTag)
+
+	for _, setting := range strings.Split(tag.Get("gorm"), ";") {
+		name, _, _ := strings.Cut(setting, ":")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "index", "uniqueindex", "unique", "primarykey", "primary_key":
+			sortable, filterable = true, true
+		}
+	}
+
+	for _, option := range strings.Split(tag.Get("goframe"), ",") {
+		switch strings.TrimSpace(option) {
+		case "sortable":
+			sortable = true
+		case "filterable":
+			filterable = true
+		}
+	}
+
+	return sortable, filterable
+}
+
 func (ctx *ParseContext) parseFieldTags(structTag string) []FieldTag {
 	var fieldTags []FieldTag
 
@@ -468,6 +834,14 @@ func (ctx *ParseContext) parseFieldTags(structTag string) []FieldTag {
 		})
 	}
 
+	// Validation rules do not name the field, they are all kept as options
+	if value := tag.Get("validate"); value != "" {
+		fieldTags = append(fieldTags, FieldTag{
+			Key:     FieldKindValidate,
+			Options: strings.Split(value, ","),
+		})
+	}
+
 	return fieldTags
 }
 
@@ -483,7 +857,10 @@ func (ctx *ParseContext) isFieldOptional(tags []FieldTag, isPointer bool) bool {
 			return true
 		}
 
-		// Check for omitempty or omitzero options in any tag
+		// Check for omitempty or omitzero options in any tag, validate:"omitempty" only skips validation
+		if tag.Key == FieldKindValidate {
+			continue
+		}
 		for _, option := range tag.Options {
 			if option == "omitempty" || option == "omitzero" {
 				return true
@@ -494,6 +871,20 @@ func (ctx *ParseContext) isFieldOptional(tags []FieldTag, isPointer bool) bool {
 	return false
 }
 
+// isFieldNullable tells whether encoding/json writes the field as null: a pointer of the JSON body
+// without omitempty or omitzero.
+func (ctx *ParseContext) isFieldNullable(tags []FieldTag, isPointer bool) bool {
+	if !isPointer {
+		return false
+	}
+	for _, tag := range tags {
+		if tag.Key == FieldKindJSON && !tag.IsJSONIgnore() {
+			return !tag.HasOption("omitempty") && !tag.HasOption("omitzero")
+		}
+	}
+	return false
+}
+
 func (ctx *ParseContext) parseFieldKind(structTag string) FieldKind {
 	if structTag == "" {
 		return FieldKindJSON // default
@@ -570,6 +961,11 @@ func (ctx *ParseContext) parseBasicType(basic *types.Basic) (*FieldType, error)
 }
 
 func (ctx *ParseContext) parseNamedType(pkg *packages.Package, named *types.Named) (*FieldType, error) {
+	// error is the only named type without a package, its value is not exchanged as JSON
+	if named.Obj().Pkg() == nil {
+		return &FieldType{Primitive: FieldTypePrimitiveAny}, nil
+	}
+
 	// Check for special time types
 	pkgPath := named.Obj().Pkg().Path()
 	typeName := named.Obj().Name()
@@ -583,6 +979,11 @@ func (ctx *ParseContext) parseNamedType(pkg *packages.Package, named *types.Name
 		}
 	}
 
+	// json.RawMessage holds arbitrary JSON, its []byte underlying type says nothing about its content
+	if pkgPath == "encoding/json" && typeName == "RawMessage" {
+		return &FieldType{Primitive: FieldTypePrimitiveAny}, nil
+	}
+
 	// Check if this is an enum type
 	enumKey := fmt.Sprintf("%s.%s", pkgPath, typeName)
 	if enum, exists := ctx.Enums[enumKey]; exists {
@@ -618,12 +1019,26 @@ func (ctx *ParseContext) parseNamedType(pkg *packages.Package, named *types.Name
 			Primitive: FieldTypePrimitiveObject,
 			Object:    obj,
 		}, nil
+	case *types.Interface:
+		obj, err := ctx.parseUnion(pkg, named, underlying)
+		if err != nil || obj == nil {
+			return &FieldType{Primitive: FieldTypePrimitiveAny}, err
+		}
+		return &FieldType{
+			Primitive: FieldTypePrimitiveObject,
+			Object:    obj,
+		}, nil
 	default:
 		return ctx.parseType(pkg, underlying)
 	}
 }
 
 func (ctx *ParseContext) parseSliceType(pkg *packages.Package, slice *types.Slice) (*FieldType, error) {
+	// encoding/json writes []byte as a base64 string
+	if elem, ok := slice.Elem().(*types.Basic); ok && elem.Kind() == types.Uint8 {
+		return &FieldType{Primitive: FieldTypePrimitiveString}, nil
+	}
+
 	elemType, err := ctx.parseType(pkg, slice.Elem())
 	if err != nil {
 		return nil, err
@@ -668,13 +1083,29 @@ func (ctx *ParseContext) parseStructType(pkg *packages.Package, structType *type
 		Fields:      []Field{},
 	}
 
+	var embedded []Field
 	for i := 0; i < structType.NumFields(); i++ {
 		field := structType.Field(i)
 		structTag := structType.Tag(i)
 
-		if !field.Exported() {
+		if !field.Exported() && !field.Embedded() {
 			continue // Skip unexported fields
 		}
+		if isExcludedFromCodegen(structTag) || isQuerySetField(structTag) {
+			continue
+		}
+
+		promoted, ok, err := ctx.embeddedFields(pkg, field, structTag)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			embedded = append(embedded, promoted...)
+			continue
+		}
+		if !field.Exported() {
+			continue // Skip unexported fields, the fields of unexported embedded structs are still promoted
+		}
 
 		parsedField, err := ctx.parseField(pkg, field, structTag, nil)
 		if err != nil {
@@ -683,6 +1114,7 @@ func (ctx *ParseContext) parseStructType(pkg *packages.Package, structType *type
 
 		obj.Fields = append(obj.Fields, *parsedField)
 	}
+	obj.Fields = appendPromotedFields(obj.Fields, embedded)
 
 	return &FieldType{
 		Primitive: FieldTypePrimitiveObject,
@@ -690,7 +1122,8 @@ func (ctx *ParseContext) parseStructType(pkg *packages.Package, structType *type
 	}, nil
 }
 
-// ParseStructByName parses a struct by package path and struct name using the existing context
+// ParseStructByName parses a struct by package path and struct name using the existing context.
+// An interface is parsed as a union of its implementations, see parseUnion.
 func (ctx *ParseContext) ParseStructByName(relPkgPath, structName string) (*ObjectType, error) {
 	// Load the target package
 	pkg, err := ctx.LoadPackage(relPkgPath)
@@ -709,14 +1142,25 @@ func (ctx *ParseContext) ParseStructByName(relPkgPath, structName string) (*Obje
 		return nil, fmt.Errorf("%s is not a named type", structName)
 	}
 
+	// Parse enums for this package if not already done
+	ctx.ParseEnums(pkg)
+
+	if iface, ok := namedType.Underlying().(*types.Interface); ok {
+		union, err := ctx.parseUnion(pkg, namedType, iface)
+		if err != nil {
+			return nil, err
+		}
+		if union == nil {
+			return nil, fmt.Errorf("interface %s has no variant with a discriminator field", structName)
+		}
+		return union, nil
+	}
+
 	structType, ok := namedType.Underlying().(*types.Struct)
 	if !ok {
 		return nil, fmt.Errorf("%s is not a struct type", structName)
 	}
 
-	// Parse enums for this package if not already done
-	ctx.ParseEnums(pkg)
-
 	// Parse the struct
 	return ctx.parseStruct(pkg, structType, namedType)
 }
@@ -1,6 +1,7 @@
 package introspect
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
@@ -264,3 +265,779 @@ func getEnumKeys(enums map[string]*FieldTypeEnum) []string {
 	}
 	return keys
 }
+
+func TestParseColumnHints(t *testing.T) {
+	ctx := &ParseContext{}
+
+	tests := []struct {
+		tag        string
+		sortable   bool
+		filterable bool
+	}{
+		{tag: `json:"name"`},
+		{tag: `json:"id" gorm:"primaryKey"`, sortable: true, filterable: true},
+		{tag: `json:"email" gorm:"type:varchar(255);uniqueIndex"`, sortable: true, filterable: true},
+		{tag: `json:"created_at" gorm:"index:idx_created_at,sort:desc"`, sortable: true, filterable: true},
+		{tag: `json:"status" goframe:"filterable"`, filterable: true},
+		{tag: `json:"rank" goframe:"sortable,filterable"`, sortable: true, filterable: true},
+	}
+
+	for _, tt := range tests {
+		sortable, filterable := ctx.parseColumnHints(tt.tag)
+		if sortable != tt.sortable || filterable != tt.filterable {
+			t.Errorf("Tag %s: expected sortable=%v filterable=%v, got sortable=%v filterable=%v",
+				tt.tag, tt.sortable, tt.filterable, sortable, filterable)
+		}
+	}
+}
+
+func TestCodegenExcludedFields(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/account", `package account
+type Account struct {
+	ID       string `+"`json:\"id\"`"+`
+	Revision int    `+"`json:\"revision\" codegen:\"-\"`"+`
+	Meta     struct {
+		Source string `+"`json:\"source\"`"+`
+		Shard  int    `+"`json:\"shard\" codegen:\"-\"`"+`
+	} `+"`json:\"meta\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/account", "Account")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	var names []string
+	for _, field := range obj.Fields {
+		names = append(names, field.Name)
+		if field.Name == "Meta" {
+			for _, nested := range field.Type.Object.Fields {
+				names = append(names, "Meta."+nested.Name)
+			}
+		}
+	}
+	if expected := "ID,Meta,Meta.Source"; strings.Join(names, ",") != expected {
+		t.Errorf("Expected fields %s, got %s", expected, strings.Join(names, ","))
+	}
+}
+
+func TestGenericStructInstantiation(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/envelope", `package envelope
+type User struct {
+	ID string `+"`json:\"id\"`"+`
+}
+type Post struct {
+	Title string `+"`json:\"title\"`"+`
+}
+type Page[T any] struct {
+	Items []T `+"`json:\"items\"`"+`
+	Total int `+"`json:\"total\"`"+`
+}
+type Response[T any] struct {
+	Data  T       `+"`json:\"data\"`"+`
+	Error *string `+"`json:\"error\"`"+`
+}
+type Payload struct {
+	Users Response[Page[User]] `+"`json:\"users\"`"+`
+	Post  Response[Post]       `+"`json:\"post\"`"+`
+	Count Response[int]        `+"`json:\"count\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/envelope", "Payload")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if len(obj.Fields) != 3 {
+		t.Fatalf("Expected 3 fields, got %d", len(obj.Fields))
+	}
+
+	users := obj.Fields[0].Type.Object
+	if users == nil || users.TypeName != "example.com/envelope.ResponsePageUser" {
+		t.Fatalf("Expected Users to be a ResponsePageUser object, got %+v", obj.Fields[0].Type)
+	}
+	page := users.Fields[0].Type.Object
+	if page == nil || page.TypeName != "example.com/envelope.PageUser" {
+		t.Fatalf("Expected Users.Data to be a PageUser object, got %+v", users.Fields[0].Type)
+	}
+	items := page.Fields[0].Type
+	if items.Primitive != FieldTypePrimitiveArray || items.Array.ItemType.Object == nil ||
+		items.Array.ItemType.Object.TypeName != "example.com/envelope.User" {
+		t.Fatalf("Expected Users.Data.Items to be an array of User, got %+v", items)
+	}
+	if name := items.Array.ItemType.Object.Fields[0].ExposedName(); name != "id" {
+		t.Errorf("Expected User field id, got %s", name)
+	}
+
+	post := obj.Fields[1].Type.Object
+	if post == nil || post.TypeName != "example.com/envelope.ResponsePost" {
+		t.Fatalf("Expected Post to be a ResponsePost object, got %+v", obj.Fields[1].Type)
+	}
+	if data := post.Fields[0].Type.Object; data == nil || data.Fields[0].ExposedName() != "title" {
+		t.Errorf("Expected Post.Data to be a Post object, got %+v", post.Fields[0].Type)
+	}
+
+	count := obj.Fields[2].Type.Object
+	if count == nil || count.TypeName != "example.com/envelope.ResponseInt" {
+		t.Fatalf("Expected Count to be a ResponseInt object, got %+v", obj.Fields[2].Type)
+	}
+	if primitive := count.Fields[0].Type.Primitive; primitive != FieldTypePrimitiveInt {
+		t.Errorf("Expected Count.Data to be an int, got %s", primitive)
+	}
+}
+
+func TestEmbeddedStructFlattening(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/models", `package models
+type Base struct {
+	ID        string `+"`json:\"id\"`"+`
+	CreatedAt int64  `+"`json:\"created_at\"`"+`
+	Name      string `+"`json:\"name\"`"+`
+}
+type audit struct {
+	UpdatedBy string `+"`json:\"updated_by\"`"+`
+}
+type Owner struct {
+	Email string `+"`json:\"email\"`"+`
+}
+type Extra struct {
+	Note string `+"`json:\"note\"`"+`
+}
+type Article struct {
+	Base
+	*audit
+	Extra `+"`json:\",inline\"`"+`
+	Owner `+"`json:\"owner\"`"+`
+	Name  string `+"`json:\"name\"`"+`
+	Meta  struct {
+		Base
+		Source string `+"`json:\"source\"`"+`
+	} `+"`json:\"meta\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/models", "Article")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	var names []string
+	for _, field := range obj.Fields {
+		names = append(names, field.ExposedName())
+	}
+	// Name is declared by Article and wins over Base.Name, the pointer to the unexported audit is ignored
+	// as with encoding/json, Owner has a json name and stays nested
+	if expected := "owner,name,meta,id,created_at,note"; strings.Join(names, ",") != expected {
+		t.Errorf("Expected fields %s, got %s", expected, strings.Join(names, ","))
+	}
+	if owner := obj.Fields[0].Type.Object; owner == nil || owner.Fields[0].ExposedName() != "email" {
+		t.Errorf("Expected owner to be a nested Owner object, got %+v", obj.Fields[0].Type)
+	}
+
+	var metaNames []string
+	for _, field := range obj.Fields[2].Type.Object.Fields {
+		metaNames = append(metaNames, field.ExposedName())
+	}
+	if expected := "source,id,created_at,name"; strings.Join(metaNames, ",") != expected {
+		t.Errorf("Expected meta fields %s, got %s", expected, strings.Join(metaNames, ","))
+	}
+}
+
+func TestEmbeddedFieldConflicts(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/accounts", `package accounts
+type Legacy struct {
+	ID   string `+"`json:\"id\"`"+`
+	Name int
+}
+type Current struct {
+	ID   string `+"`json:\"id\"`"+`
+	Name string `+"`json:\"Name\"`"+`
+	Plan string
+}
+type Billing struct {
+	Plan int
+}
+type Contact struct {
+	Email int `+"`json:\"email\"`"+`
+}
+type Wrapper struct {
+	Contact
+}
+type Profile struct {
+	Email string `+"`json:\"email\"`"+`
+}
+type Account struct {
+	Legacy
+	Current
+	Billing
+	Wrapper
+	Profile
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/accounts", "Account")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	fields := map[string]Field{}
+	var names []string
+	for _, field := range obj.Fields {
+		names = append(names, field.ExposedName())
+		fields[field.ExposedName()] = field
+	}
+	// id is tagged on both sides and Plan on neither: both are dropped. The tagged Name of Current wins and the
+	// email of Profile is less nested than the one of Contact.
+	if expected := "Name,email"; strings.Join(names, ",") != expected {
+		t.Fatalf("Expected fields %s, got %s", expected, strings.Join(names, ","))
+	}
+	if fields["Name"].Type.Primitive != FieldTypePrimitiveString {
+		t.Errorf("Expected the tagged Name to win, got %+v", fields["Name"].Type)
+	}
+	if fields["email"].Type.Primitive != FieldTypePrimitiveString {
+		t.Errorf("Expected the least nested email to win, got %+v", fields["email"].Type)
+	}
+
+	// Fields of a struct parsed on its own are not affected by its embedding
+	wrapper, err := ctx.ParseStructByName("example.com/accounts", "Wrapper")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if len(wrapper.Fields) != 1 || wrapper.Fields[0].depth != 1 {
+		t.Errorf("Expected the email of Wrapper to be promoted once, got %+v", wrapper.Fields)
+	}
+}
+
+func TestDocComments(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/docs", `package docs
+
+// Invoice is a bill sent to a customer.
+type Invoice struct {
+	// ID identifies the invoice.
+	ID string `+"`json:\"id\"`"+`
+	// Amount is in cents,
+	// taxes included.
+	Amount   int    `+"`json:\"amount\"`"+`
+	Currency string `+"`json:\"currency\"`"+` // ISO 4217 code
+	From, To string
+	Paid     bool   `+"`json:\"paid\"`"+`
+}
+
+type (
+	// Customer pays invoices.
+	Customer struct {
+		Invoices []Invoice `+"`json:\"invoices\"`"+`
+	}
+)`)
+
+	obj, err := ctx.ParseStructByName("example.com/docs", "Customer")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if obj.Doc != "Customer pays invoices." {
+		t.Errorf("Expected Customer doc, got %q", obj.Doc)
+	}
+
+	invoice := obj.Fields[0].Type.Array.ItemType.Object
+	if invoice.Doc != "Invoice is a bill sent to a customer." {
+		t.Errorf("Expected Invoice doc, got %q", invoice.Doc)
+	}
+
+	expected := map[string]string{
+		"ID":       "ID identifies the invoice.",
+		"Amount":   "Amount is in cents,\ntaxes included.",
+		"Currency": "ISO 4217 code",
+		"From":     "",
+		"To":       "",
+		"Paid":     "",
+	}
+	if len(invoice.Fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %d", len(expected), len(invoice.Fields))
+	}
+	for _, field := range invoice.Fields {
+		if field.Doc != expected[field.Name] {
+			t.Errorf("Field %s: expected doc %q, got %q", field.Name, expected[field.Name], field.Doc)
+		}
+	}
+}
+
+func TestMapKeyTypes(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/maps", `package maps
+
+type Foo struct {
+	Name string `+"`json:\"name\"`"+`
+}
+
+type Rank int
+
+type Maps struct {
+	Labels map[int]string  `+"`json:\"labels\"`"+`
+	Ranks  map[Rank]string `+"`json:\"ranks\"`"+`
+	Foos   map[string]Foo  `+"`json:\"foos\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/maps", "Maps")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if len(obj.Fields) != 3 {
+		t.Fatalf("Expected 3 fields, got %d", len(obj.Fields))
+	}
+
+	for _, field := range obj.Fields[:2] {
+		if field.Type.Map == nil {
+			t.Fatalf("Expected %s to be a map", field.Name)
+		}
+		if field.Type.Map.Key.Primitive != FieldTypePrimitiveInt {
+			t.Errorf("Expected %s key to be int, got %q", field.Name, field.Type.Map.Key.Primitive)
+		}
+		if field.Type.Map.Value.Primitive != FieldTypePrimitiveString {
+			t.Errorf("Expected %s value to be string, got %q", field.Name, field.Type.Map.Value.Primitive)
+		}
+	}
+
+	foos := obj.Fields[2].Type.Map
+	if foos == nil {
+		t.Fatalf("Expected Foos to be a map")
+	}
+	if foos.Key.Primitive != FieldTypePrimitiveString {
+		t.Errorf("Expected Foos key to be string, got %q", foos.Key.Primitive)
+	}
+	if foos.Value.Object == nil || foos.Value.Object.TypeName != "example.com/maps.Foo" {
+		t.Errorf("Expected Foos value to be the Foo struct, got %+v", foos.Value)
+	}
+}
+
+func TestRawMessageAndBytes(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "encoding/json", `package json
+
+type RawMessage []byte`)
+	loadSourcePackage(t, ctx, "example.com/payload", `package payload
+
+import "encoding/json"
+
+type Blob []byte
+
+type Payload struct {
+	Data     json.RawMessage   `+"`json:\"data\"`"+`
+	Extra    *json.RawMessage  `+"`json:\"extra\"`"+`
+	Content  []byte            `+"`json:\"content\"`"+`
+	Blob     Blob              `+"`json:\"blob\"`"+`
+	Chunks   [][]byte          `+"`json:\"chunks\"`"+`
+	Numbers  []int             `+"`json:\"numbers\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/payload", "Payload")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	expected := map[string]FieldTypePrimitive{
+		"Data":    FieldTypePrimitiveAny,
+		"Extra":   FieldTypePrimitiveAny,
+		"Content": FieldTypePrimitiveString,
+		"Blob":    FieldTypePrimitiveString,
+		"Chunks":  FieldTypePrimitiveArray,
+		"Numbers": FieldTypePrimitiveArray,
+	}
+	for _, field := range obj.Fields {
+		if field.Type.Primitive != expected[field.Name] {
+			t.Errorf("Expected %s to be %q, got %q", field.Name, expected[field.Name], field.Type.Primitive)
+		}
+	}
+	if chunks := obj.Fields[4].Type.Array; chunks == nil || chunks.ItemType.Primitive != FieldTypePrimitiveString {
+		t.Errorf("Expected Chunks items to be base64 strings, got %+v", obj.Fields[4].Type)
+	}
+}
+
+func TestJSONTagOptions(t *testing.T) {
+	ctx := newTestParseContext()
+
+	tests := []struct {
+		tag       string
+		quoted    bool
+		omitempty bool
+	}{
+		{tag: `json:"id,string"`, quoted: true},
+		{tag: `json:"id,omitempty,string"`, quoted: true, omitempty: true},
+		{tag: `json:"id,omitempty"`, omitempty: true},
+		{tag: `json:"id" query:"id,string"`},
+		{tag: `json:"string"`},
+		{tag: ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			field := Field{Name: "ID", Tags: ctx.parseFieldTags(tt.tag)}
+			if got := field.HasJSONStringOption(); got != tt.quoted {
+				t.Errorf("HasJSONStringOption: expected %v, got %v", tt.quoted, got)
+			}
+			if got := field.HasOmitempty(); got != tt.omitempty {
+				t.Errorf("HasOmitempty: expected %v, got %v", tt.omitempty, got)
+			}
+		})
+	}
+}
+
+func TestNullableFields(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/profile", `package profile
+
+type Profile struct {
+	Name     string  `+"`json:\"name\"`"+`
+	Nickname *string `+"`json:\"nickname,omitempty\"`"+`
+	Bio      *string `+"`json:\"bio\"`"+`
+	Avatar   *string `+"`json:\"avatar,omitzero\"`"+`
+	Page     *int    `+"`query:\"page\"`"+`
+	Website  *string
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/profile", "Profile")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	expected := map[string]struct{ optional, nullable bool }{
+		"Name":     {false, false},
+		"Nickname": {true, false},
+		"Bio":      {true, true},
+		"Avatar":   {true, false},
+		"Page":     {true, false},
+		"Website":  {true, true},
+	}
+	for _, field := range obj.Fields {
+		want := expected[field.Name]
+		if field.Optional != want.optional || field.Nullable != want.nullable {
+			t.Errorf("%s: expected optional=%v nullable=%v, got optional=%v nullable=%v",
+				field.Name, want.optional, want.nullable, field.Optional, field.Nullable)
+		}
+	}
+}
+
+func TestDefaultTag(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "example.com/search", `package search
+
+type SearchRequest struct {
+	Limit  int      `+"`query:\"limit\" default:\"30\"`"+`
+	Sort   string   `+"`query:\"sort\" default:\"\"`"+`
+	Tags   []string `+"`query:\"tags\" default:\"a,b\"`"+`
+	Cursor string   `+"`query:\"cursor\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/search", "SearchRequest")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	expected := map[string]*string{"Limit": ptr("30"), "Sort": ptr(""), "Tags": ptr("a,b"), "Cursor": nil}
+	for _, field := range obj.Fields {
+		if !reflect.DeepEqual(field.Default, expected[field.Name]) {
+			t.Errorf("%s: expected default %v, got %v", field.Name, expected[field.Name], field.Default)
+		}
+	}
+}
+
+func TestTimeFormatTag(t *testing.T) {
+	ctx := newTestParseContext()
+	loadSourcePackage(t, ctx, "time", "package time\n\ntype Time struct{}")
+	loadSourcePackage(t, ctx, "example.com/events", `package events
+
+import "time"
+
+type ListEventsRequest struct {
+	Since   time.Time `+"`query:\"since\"`"+`
+	From    time.Time `+"`query:\"from\" timeformat:\"unix\"`"+`
+	Until   time.Time `+"`query:\"until\" timeformat:\"unixmilli\"`"+`
+	Day     time.Time `+"`query:\"day\" timeformat:\"2006-01-02\"`"+`
+	Updated time.Time `+"`query:\"updated\" timeformat:\"2006-01-02T15:04:05Z07:00\"`"+`
+	Created time.Time `+"`json:\"created\" timeformat:\"unix\"`"+`
+	Both    time.Time `+"`json:\"both\" form:\"both\" timeformat:\"unixmilli\"`"+`
+}`)
+
+	obj, err := ctx.ParseStructByName("example.com/events", "ListEventsRequest")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+
+	expected := map[string]FieldTypePrimitive{
+		"Since":   FieldTypePrimitiveTime,
+		"From":    FieldTypePrimitiveUnixTime,
+		"Until":   FieldTypePrimitiveUnixMilliTime,
+		"Day":     FieldTypePrimitiveString,
+		"Updated": FieldTypePrimitiveTime,
+		// encoding/json ignores the tag, JSON fields stay RFC 3339 dates
+		"Created": FieldTypePrimitiveTime,
+		"Both":    FieldTypePrimitiveTime,
+	}
+	for _, field := range obj.Fields {
+		if field.Type.Primitive != expected[field.Name] {
+			t.Errorf("%s: expected primitive %s, got %s", field.Name, expected[field.Name], field.Type.Primitive)
+		}
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func TestValidateTag(t *testing.T) {
+	ctx := newTestParseContext()
+
+	tags := ctx.parseFieldTags(`validate:"omitempty,min=1,max=100"`)
+	field := Field{Name: "Count", Tags: tags, Optional: ctx.isFieldOptional(tags, false)}
+
+	if !reflect.DeepEqual(field.ValidateRules(), []string{"omitempty", "min=1", "max=100"}) {
+		t.Errorf("Expected all rules as options, got %v", field.ValidateRules())
+	}
+	if field.ExposedName() != "Count" || len(tags) != 2 || tags[0].Key != FieldKindJSON {
+		t.Errorf("Expected a validate tag alone to keep the default json tag, got %+v", tags)
+	}
+	if field.Optional {
+		t.Errorf("Expected validate:\"omitempty\" not to make the field optional")
+	}
+	if rules := (Field{Tags: ctx.parseFieldTags(`json:"count"`)}).ValidateRules(); rules != nil {
+		t.Errorf("Expected no rules without validate tag, got %v", rules)
+	}
+}
+
+func TestJSONIgnoreTags(t *testing.T) {
+	ctx := newTestParseContext()
+
+	tests := []struct {
+		name            string
+		tag             string
+		notSerializable bool
+		exposedName     string
+		optional        bool
+	}{
+		{name: "json dash", tag: `json:"-"`, notSerializable: true, exposedName: "Field"},
+		{name: "json dash with query", tag: `json:"-" query:"q"`, exposedName: "q"},
+		{name: "json dash with ctx", tag: `json:"-" ctx:"user"`, exposedName: "Field"},
+		{name: "literal dash name", tag: `json:"-,"`, exposedName: "-"},
+		{name: "literal dash name with omitempty", tag: `json:"-,omitempty"`, exposedName: "-", optional: true},
+		{name: "no tag", tag: ``, exposedName: "Field"},
+		{name: "unrelated tag only", tag: `gorm:"index"`, exposedName: "Field"},
+		{name: "empty json name", tag: `json:",omitempty"`, exposedName: "Field", optional: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldTags := ctx.parseFieldTags(tt.tag)
+			field := Field{Name: "Field", Tags: fieldTags, Optional: ctx.isFieldOptional(fieldTags, false)}
+
+			if got := field.IsNotSerializable(); got != tt.notSerializable {
+				t.Errorf("IsNotSerializable: expected %v, got %v", tt.notSerializable, got)
+			}
+			if got := field.ExposedName(); got != tt.exposedName {
+				t.Errorf("ExposedName: expected %q, got %q", tt.exposedName, got)
+			}
+			if field.Optional != tt.optional {
+				t.Errorf("Optional: expected %v, got %v", tt.optional, field.Optional)
+			}
+		})
+	}
+
+	t.Run("ignored json field is not a body", func(t *testing.T) {
+		obj := ObjectType{Fields: []Field{{Name: "Q", Tags: ctx.parseFieldTags(`json:"-" query:"q"`)}}}
+		if obj.HasJSONBody() || obj.HasBody() {
+			t.Errorf("Expected no body for a json:\"-\" field")
+		}
+		if !obj.HasSearchParams() {
+			t.Errorf("Expected search params")
+		}
+	})
+}
+
+func TestParserReusesParsedTypes(t *testing.T) {
+	parser := NewParser(".")
+	loadSourcePackage(t, parser.ParseContext, "example.com/shop", `package shop
+type Status string
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+type Item struct {
+	SKU string `+"`json:\"sku\"`"+`
+}
+type Order struct {
+	Status Status `+"`json:\"status\"`"+`
+	Items  []Item `+"`json:\"items\"`"+`
+}
+type Cart struct {
+	Items []Item `+"`json:\"items\"`"+`
+}`)
+
+	first, err := parser.ParseStruct("example.com/shop", "Order")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	second, err := parser.ParseStruct("example.com/shop", "Order")
+	if err != nil {
+		t.Fatalf("Failed to parse struct again: %v", err)
+	}
+	if first != second {
+		t.Errorf("Expected the parsed Order to be reused")
+	}
+
+	cart, err := parser.ParseStruct("example.com/shop", "Cart")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if cart.Fields[0].Type.Array.ItemType.Object != first.Fields[1].Type.Array.ItemType.Object {
+		t.Errorf("Expected Item to be parsed once and shared by Order and Cart")
+	}
+
+	// a fresh parser produces the same result
+	fresh := NewParser(".")
+	loadSourcePackage(t, fresh.ParseContext, "example.com/shop", `package shop
+type Status string
+const (
+	StatusOpen   Status = "open"
+	StatusClosed Status = "closed"
+)
+type Item struct {
+	SKU string `+"`json:\"sku\"`"+`
+}
+type Order struct {
+	Status Status `+"`json:\"status\"`"+`
+	Items  []Item `+"`json:\"items\"`"+`
+}`)
+	expected, err := fresh.ParseStruct("example.com/shop", "Order")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if !reflect.DeepEqual(expected, second) {
+		t.Errorf("Expected repeated parses to match a fresh parse:\n%+v\n%+v", expected, second)
+	}
+	if second.Fields[0].Type.Enum == nil || len(second.Fields[0].Type.Enum.KeyValuesString) != 2 {
+		t.Errorf("Expected Status to be an enum with 2 values, got %+v", second.Fields[0].Type)
+	}
+}
+
+func BenchmarkParseStruct(b *testing.B) {
+	const pkgPath = "github.com/alexisvisco/goframe/core/helpers/introspect"
+	if _, err := ParseStruct(".", pkgPath, "ObjectType"); err != nil {
+		b.Skipf("Failed to load package: %v", err)
+	}
+
+	b.Run("fresh context per call", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := ParseStruct(".", pkgPath, "ObjectType"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("shared parser", func(b *testing.B) {
+		parser := NewParser(".")
+		for b.Loop() {
+			if _, err := parser.ParseStruct(pkgPath, "ObjectType"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestDiscriminatedUnion(t *testing.T) {
+	ctx := newTestParseContext()
+	ctx.ModulePath = "example.com"
+	loadSourcePackage(t, ctx, "example.com/payment", `package payment
+
+type Payment interface{ isPayment() }
+
+type CardPayment struct {
+	Type  string `+"`json:\"type\" discriminator:\"card\"`"+`
+	Last4 string `+"`json:\"last4\"`"+`
+}
+
+func (CardPayment) isPayment() {}
+
+type BankPayment struct {
+	Type string `+"`json:\"type\" discriminator:\"bank\"`"+`
+	IBAN string `+"`json:\"iban\"`"+`
+}
+
+func (*BankPayment) isPayment() {}
+
+type GiftPayment struct {
+	Code string `+"`json:\"code\"`"+`
+}
+
+func (GiftPayment) isPayment() {}
+
+type Checkout struct {
+	Payment Payment   `+"`json:\"payment\"`"+`
+	History []Payment `+"`json:\"history\"`"+`
+	Extra   any       `+"`json:\"extra\"`"+`
+}`)
+
+	union, err := ctx.ParseStructByName("example.com/payment", "Payment")
+	if err != nil {
+		t.Fatalf("Failed to parse interface: %v", err)
+	}
+	if union.Union == nil || union.Union.Discriminator != "type" {
+		t.Fatalf("Expected a union discriminated by type, got %+v", union.Union)
+	}
+	var variants []string
+	for _, variant := range union.Union.Variants {
+		field, _ := variant.DiscriminatorField()
+		variants = append(variants, variant.TypeName+"="+field.Discriminator)
+	}
+	expected := []string{"example.com/payment.BankPayment=bank", "example.com/payment.CardPayment=card"}
+	if !reflect.DeepEqual(variants, expected) {
+		t.Errorf("Expected variants %v, got %v", expected, variants)
+	}
+
+	checkout, err := ctx.ParseStructByName("example.com/payment", "Checkout")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	if checkout.Fields[0].Type.Object != union {
+		t.Errorf("Expected the payment field to be the union, got %+v", checkout.Fields[0].Type)
+	}
+	if item := checkout.Fields[1].Type.Array.ItemType; item.Object != union {
+		t.Errorf("Expected history items to be the union, got %+v", item)
+	}
+	if checkout.Fields[2].Type.Primitive != FieldTypePrimitiveAny {
+		t.Errorf("Expected an empty interface to stay any, got %+v", checkout.Fields[2].Type)
+	}
+}
+
+func TestUnionOutsideModule(t *testing.T) {
+	ctx := newTestParseContext()
+	ctx.ModulePath = "example.com/app"
+	loadSourcePackage(t, ctx, "example.com/lib", `package lib
+
+type Shape interface{ isShape() }
+
+type Circle struct {
+	Kind string `+"`json:\"kind\" discriminator:\"circle\"`"+`
+}
+
+func (Circle) isShape() {}`)
+	loadSourcePackage(t, ctx, "example.com/app/drawing", `package drawing
+
+import "example.com/lib"
+
+type Drawing struct {
+	Shape lib.Shape `+"`json:\"shape\"`"+`
+	Err   error     `+"`json:\"err\"`"+`
+}`)
+
+	drawing, err := ctx.ParseStructByName("example.com/app/drawing", "Drawing")
+	if err != nil {
+		t.Fatalf("Failed to parse struct: %v", err)
+	}
+	for _, field := range drawing.Fields {
+		if field.Type.Primitive != FieldTypePrimitiveAny {
+			t.Errorf("Expected %s to stay any, got %+v", field.Name, field.Type)
+		}
+	}
+	if _, visited := ctx.Visited["example.com/lib.Shape"]; visited {
+		t.Errorf("Expected the interface of another module not to be parsed")
+	}
+}
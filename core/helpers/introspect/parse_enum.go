@@ -2,12 +2,18 @@ package introspect
 
 import (
 	"fmt"
+	"go/ast"
 	"go/constant"
+	"go/token"
 	"go/types"
+	"strconv"
 
 	"golang.org/x/tools/go/packages"
 )
 
+// ParseEnums registers as enums the named types of pkg having at least two exported constants.
+// Values are the ones computed by the type checker, so iota blocks, implicitly repeated specs and
+// expressions like 1 << iota are resolved. Untyped constants belong to no type and are ignored.
 func (ctx *ParseContext) ParseEnums(pkg *packages.Package) {
 	// Check if we already parsed enums for this package to avoid redundant work
 	if ctx.EnumsParsed[pkg.PkgPath] {
@@ -65,6 +71,9 @@ func (ctx *ParseContext) ParseEnums(pkg *packages.Package) {
 					enum.KeyValuesString[constObj.Name()] = constant.StringVal(constObj.Val())
 				}
 			}
+		} else if names := textEnumNames(pkg, firstConstant, constants); names != nil {
+			// Int constants serialized through their String() method travel as strings on the wire
+			enum.KeyValuesString = names
 		} else if firstConstant.Val().Kind() == constant.Int {
 			enum.KeyValuesInt = make(map[string]int)
 			for _, constObj := range constants {
@@ -119,3 +128,174 @@ func (ctx *ParseContext) detectEnum(pkg *packages.Package, named *types.Named) *
 
 	return nil
 }
+
+// textEnumNames returns the string representation of int constants whose type marshals itself
+// as text, or nil when the constants are serialized as plain integers.
+func textEnumNames(pkg *packages.Package, first *types.Const, constants []*types.Const) map[string]string {
+	if first.Val().Kind() != constant.Int {
+		return nil
+	}
+	named, ok := first.Type().(*types.Named)
+	if !ok || !marshalsAsText(named) {
+		return nil
+	}
+	return resolveStringNames(pkg, named, constants)
+}
+
+// marshalsAsText reports whether a named type implements json.Marshaler or encoding.TextMarshaler
+// (on the value or the pointer receiver), meaning its JSON representation is not its underlying value.
+func marshalsAsText(named *types.Named) bool {
+	methods := types.NewMethodSet(types.NewPointer(named))
+	for _, name := range []string{"MarshalJSON", "MarshalText"} {
+		if sel := methods.Lookup(named.Obj().Pkg(), name); sel != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveStringNames finds the string representation of each constant of an int enum by reading
+// the String() method of the type. Supported forms are:
+//   - a switch returning a string literal per constant
+//   - a lookup table (map or array) keyed by the constants, e.g. map[Status]string{StatusActive: "active"}
+//   - a positional string array or slice indexed by the value, e.g. [...]string{"active", "inactive"}[s]
+//
+// It returns nil when the representation of a constant can't be determined statically.
+func resolveStringNames(pkg *packages.Package, named *types.Named, constants []*types.Const) map[string]string {
+	stringMethod := findStringMethod(pkg, named)
+	if stringMethod == nil || stringMethod.Body == nil {
+		return nil
+	}
+
+	byName := make(map[string]string)
+	byValue := make(map[int64]string)
+	tables := make(map[string]bool) // identifiers referenced by String(), candidates for lookup tables
+
+	ast.Inspect(stringMethod.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.CaseClause:
+			lit := returnedStringLiteral(node.Body)
+			if lit == "" {
+				return true
+			}
+			for _, expr := range node.List {
+				if ident, ok := expr.(*ast.Ident); ok {
+					byName[ident.Name] = lit
+				}
+			}
+		case *ast.IndexExpr:
+			if ident, ok := node.X.(*ast.Ident); ok {
+				tables[ident.Name] = true
+			}
+		case *ast.CompositeLit:
+			collectTableEntries(node, byName, byValue)
+		}
+		return true
+	})
+
+	// Lookup tables declared at the package level
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range vs.Names {
+					if !tables[name.Name] || i >= len(vs.Values) {
+						continue
+					}
+					if lit, ok := vs.Values[i].(*ast.CompositeLit); ok {
+						collectTableEntries(lit, byName, byValue)
+					}
+				}
+			}
+		}
+	}
+
+	names := make(map[string]string, len(constants))
+	for _, constObj := range constants {
+		if value, ok := byName[constObj.Name()]; ok {
+			names[constObj.Name()] = value
+			continue
+		}
+		if v, ok := constant.Int64Val(constObj.Val()); ok {
+			if value, ok := byValue[v]; ok {
+				names[constObj.Name()] = value
+				continue
+			}
+		}
+		return nil
+	}
+	return names
+}
+
+// findStringMethod returns the declaration of the String() string method of named, if any.
+func findStringMethod(pkg *packages.Package, named *types.Named) *ast.FuncDecl {
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Name.Name != "String" || fd.Recv == nil || len(fd.Recv.List) == 0 {
+				continue
+			}
+			recv := fd.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok && ident.Name == named.Obj().Name() {
+				return fd
+			}
+		}
+	}
+	return nil
+}
+
+// collectTableEntries records the string values of a map or array composite literal.
+// Keyed entries are recorded by constant name, positional entries by index.
+func collectTableEntries(lit *ast.CompositeLit, byName map[string]string, byValue map[int64]string) {
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			value, ok := stringLiteral(kv.Value)
+			if !ok {
+				continue
+			}
+			if ident, ok := kv.Key.(*ast.Ident); ok {
+				byName[ident.Name] = value
+			}
+			continue
+		}
+		if value, ok := stringLiteral(elt); ok {
+			byValue[int64(i)] = value
+		}
+	}
+}
+
+// returnedStringLiteral returns the string literal returned by a list of statements, if any.
+func returnedStringLiteral(stmts []ast.Stmt) string {
+	for _, stmt := range stmts {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		if value, ok := stringLiteral(ret.Results[0]); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
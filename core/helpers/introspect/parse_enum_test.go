@@ -0,0 +1,263 @@
+package introspect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// loadSourcePackage type-checks an in-memory package and registers it in the context
+// so it can be used without going through packages.Load. Imports are resolved among
+// the packages already registered.
+func loadSourcePackage(t *testing.T, ctx *ParseContext, pkgPath, src string) *packages.Package {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "source.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse source: %v", err)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	config := &types.Config{Importer: sourceImporter(ctx.Packages)}
+	typesPkg, err := config.Check(pkgPath, fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatalf("Failed to type-check source: %v", err)
+	}
+
+	pkg := &packages.Package{
+		ID:        pkgPath,
+		PkgPath:   pkgPath,
+		Name:      typesPkg.Name(),
+		Fset:      fset,
+		Syntax:    []*ast.File{file},
+		Types:     typesPkg,
+		TypesInfo: info,
+	}
+	ctx.Packages[pkgPath] = pkg
+	return pkg
+}
+
+type sourceImporter map[string]*packages.Package
+
+func (imp sourceImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := imp[path]; ok {
+		return pkg.Types, nil
+	}
+	return nil, fmt.Errorf("package %s is not loaded", path)
+}
+
+func newTestParseContext() *ParseContext {
+	return &ParseContext{
+		Visited:     make(map[string]*ObjectType),
+		Enums:       make(map[string]*FieldTypeEnum),
+		Packages:    make(map[string]*packages.Package),
+		EnumsParsed: make(map[string]bool),
+		RootPath:    ".",
+	}
+}
+
+func TestIntEnumWireRepresentation(t *testing.T) {
+	tests := []struct {
+		name            string
+		src             string
+		expectedStrings map[string]string
+		expectedInts    map[string]int
+	}{
+		{
+			name: "plain int enum",
+			src: `package status
+type Status int
+const (
+	StatusActive Status = iota
+	StatusInactive
+)`,
+			expectedInts: map[string]int{"StatusActive": 0, "StatusInactive": 1},
+		},
+		{
+			name: "switch in String with MarshalText",
+			src: `package status
+type Status int
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+func (s Status) String() string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusInactive:
+		return "inactive"
+	}
+	return "unknown"
+}
+func (s Status) MarshalText() ([]byte, error) { return []byte(s.String()), nil }`,
+			expectedStrings: map[string]string{"StatusActive": "active", "StatusInactive": "inactive"},
+		},
+		{
+			name: "keyed map table with MarshalJSON on pointer",
+			src: `package status
+type Status int
+const (
+	StatusActive Status = iota + 1
+	StatusInactive
+)
+var statusNames = map[Status]string{
+	StatusActive:   "active",
+	StatusInactive: "inactive",
+}
+func (s Status) String() string { return statusNames[s] }
+func (s *Status) MarshalJSON() ([]byte, error) { return []byte("\"" + s.String() + "\""), nil }`,
+			expectedStrings: map[string]string{"StatusActive": "active", "StatusInactive": "inactive"},
+		},
+		{
+			name: "positional array table",
+			src: `package status
+type Status int
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+func (s Status) String() string { return [...]string{"active", "inactive"}[s] }
+func (s Status) MarshalText() ([]byte, error) { return []byte(s.String()), nil }`,
+			expectedStrings: map[string]string{"StatusActive": "active", "StatusInactive": "inactive"},
+		},
+		{
+			name: "unresolvable String keeps integer values",
+			src: `package status
+type Status int
+const (
+	StatusActive Status = iota
+	StatusInactive
+)
+func (s Status) String() string { return "status" + string(rune('0'+int(s))) }
+func (s Status) MarshalText() ([]byte, error) { return []byte(s.String()), nil }`,
+			expectedInts: map[string]int{"StatusActive": 0, "StatusInactive": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestParseContext()
+			pkg := loadSourcePackage(t, ctx, "example.com/status", tt.src)
+
+			ctx.ParseEnums(pkg)
+
+			enum, ok := ctx.Enums["example.com/status.Status"]
+			if !ok {
+				t.Fatalf("Expected Status enum to be detected, got %v", getEnumKeys(ctx.Enums))
+			}
+			if len(tt.expectedStrings) > 0 && !reflect.DeepEqual(enum.KeyValuesString, tt.expectedStrings) {
+				t.Errorf("Expected string values %v, got %v", tt.expectedStrings, enum.KeyValuesString)
+			}
+			if len(tt.expectedInts) > 0 {
+				if !reflect.DeepEqual(enum.KeyValuesInt, tt.expectedInts) {
+					t.Errorf("Expected int values %v, got %v", tt.expectedInts, enum.KeyValuesInt)
+				}
+				if len(enum.KeyValuesString) > 0 {
+					t.Errorf("Expected no string values, got %v", enum.KeyValuesString)
+				}
+			}
+		})
+	}
+}
+
+func TestIotaEnums(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected map[string]int
+	}{
+		{
+			name: "implicit repetition",
+			src: `package status
+type Status int
+const (
+	A Status = iota
+	B
+	C
+)`,
+			expected: map[string]int{"A": 0, "B": 1, "C": 2},
+		},
+		{
+			name: "blank identifier and offset",
+			src: `package status
+type Status uint8
+const (
+	_ Status = iota
+	A
+	B
+)`,
+			expected: map[string]int{"A": 1, "B": 2},
+		},
+		{
+			name: "conversion of untyped iota",
+			src: `package status
+type Status int
+const (
+	A = Status(iota * 10)
+	B
+	C
+)`,
+			expected: map[string]int{"A": 0, "B": 10, "C": 20},
+		},
+		{
+			name: "bit flags",
+			src: `package status
+type Status int
+const (
+	A Status = 1 << iota
+	B
+	C
+)`,
+			expected: map[string]int{"A": 1, "B": 2, "C": 4},
+		},
+		{
+			name: "untyped iota block is not attached to the type",
+			src: `package status
+type Status int
+const (
+	A Status = 100
+)
+const (
+	B = iota
+	C
+)`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := newTestParseContext()
+			pkg := loadSourcePackage(t, ctx, "example.com/status", tt.src)
+
+			ctx.ParseEnums(pkg)
+
+			enum, ok := ctx.Enums["example.com/status.Status"]
+			if tt.expected == nil {
+				if ok {
+					t.Errorf("Expected no Status enum, got %v", enum.KeyValuesInt)
+				}
+				return
+			}
+			if !ok {
+				t.Fatalf("Expected Status enum to be detected, got %v", getEnumKeys(ctx.Enums))
+			}
+			if !reflect.DeepEqual(enum.KeyValuesInt, tt.expected) {
+				t.Errorf("Expected int values %v, got %v", tt.expected, enum.KeyValuesInt)
+			}
+		})
+	}
+}
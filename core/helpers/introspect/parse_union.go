@@ -0,0 +1,103 @@
+package introspect
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+)
+
+// parseUnion parses a named interface as a discriminated union of the structs implementing it. Variants are
+// the named structs of the package declaring the interface, or pointers to them, implementing it and having
+// a field tagged discriminator with their value, e.g.:
+//
+//	type Payment interface{ isPayment() }
+//
+//	type CardPayment struct {
+//		Type string `json:"type" discriminator:"card"`
+//	}
+//
+//	func (CardPayment) isPayment() {}
+//
+// Only the interfaces of the module at RootPath are parsed, those of the standard library and dependencies such
+// as error or io.Reader not being loaded. It returns nil when no variant is found or the interface is outside
+// the module, the interface is then exchanged as any value.
+func (ctx *ParseContext) parseUnion(pkg *packages.Package, named *types.Named, iface *types.Interface) (*ObjectType, error) {
+	typeKey := namedTypeKey(named)
+	if obj, visited := ctx.Visited[typeKey]; visited {
+		return obj, nil
+	}
+	if iface.Empty() || !ctx.inModule(named.Obj().Pkg()) {
+		return nil, nil
+	}
+
+	actualPkg := pkg
+	if named.Obj().Pkg().Path() != pkg.PkgPath {
+		var err error
+		actualPkg, err = ctx.LoadPackage(named.Obj().Pkg().Path())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load package for type %s: %w", typeKey, err)
+		}
+	}
+
+	// Registered before parsing the variants, which may refer to the union
+	obj := &ObjectType{TypeName: typeKey, Fields: []Field{}, Union: &ObjectUnion{}}
+	ctx.Visited[typeKey] = obj
+
+	scope := actualPkg.Types.Scope()
+	for _, name := range scope.Names() {
+		typeName, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || typeName.IsAlias() {
+			continue
+		}
+		variant, ok := typeName.Type().(*types.Named)
+		if !ok || variant.TypeParams().Len() > 0 {
+			continue
+		}
+		structType, ok := variant.Underlying().(*types.Struct)
+		if !ok || !(types.Implements(variant, iface) || types.Implements(types.NewPointer(variant), iface)) {
+			continue
+		}
+
+		variantObj, err := ctx.parseStruct(actualPkg, structType, variant)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse variant %s of %s: %w", name, typeKey, err)
+		}
+		discriminator, ok := variantObj.DiscriminatorField()
+		if !ok {
+			continue
+		}
+		if obj.Union.Discriminator == "" {
+			obj.Union.Discriminator = discriminator.ExposedName()
+		} else if discriminator.ExposedName() != obj.Union.Discriminator {
+			return nil, fmt.Errorf("variant %s of %s is discriminated by %s instead of %s",
+				name, typeKey, discriminator.ExposedName(), obj.Union.Discriminator)
+		}
+		obj.Union.Variants = append(obj.Union.Variants, variantObj)
+	}
+
+	if len(obj.Union.Variants) == 0 {
+		delete(ctx.Visited, typeKey)
+		return nil, nil
+	}
+	return obj, nil
+}
+
+// inModule reports whether pkg belongs to the module at RootPath.
+func (ctx *ParseContext) inModule(pkg *types.Package) bool {
+	if ctx.ModulePath == "" {
+		data, err := os.ReadFile(filepath.Join(ctx.RootPath, "go.mod"))
+		if err != nil {
+			return false
+		}
+		ctx.ModulePath = modfile.ModulePath(data)
+		if ctx.ModulePath == "" {
+			return false
+		}
+	}
+	return pkg.Path() == ctx.ModulePath || strings.HasPrefix(pkg.Path(), ctx.ModulePath+"/")
+}
@@ -11,6 +11,8 @@ import (
 
 type Translations struct {
 	translations map[string]map[string]string
+	parameters   map[string]map[string][]string
+	messages     map[string]map[string]*Message
 }
 
 // Parameter represents a translation parameter
@@ -20,10 +22,7 @@ type Parameter struct {
 }
 
 func (t *Translations) Get(ctx context.Context, key string) string {
-	language, ok := ctx.Value("language").(string)
-	if !ok {
-		language = "en"
-	}
+	language := languageFromContext(ctx)
 	if translations, ok := t.translations[language]; ok {
 		if translation, ok := translations[key]; ok {
 			return translation
@@ -32,8 +31,67 @@ func (t *Translations) Get(ctx context.Context, key string) string {
 	return key
 }
 
+// Plural returns the translation of key for count, picking among its plural forms (zero, one, two, few, many
+// and other) with the plural rules of the context language and falling back to the other form. A zero form,
+// when present, is used for a count of 0 even in languages without that category.
+// Parameters of the picked form are looked up by name in args.
+func (t *Translations) Plural(ctx context.Context, key string, count int, args map[string]any) string {
+	language := languageFromContext(ctx)
+	translations, ok := t.translations[language]
+	if !ok {
+		return key
+	}
+
+	form := key + "." + PluralCategory(language, count)
+	if _, ok := translations[key+".zero"]; ok && count == 0 {
+		form = key + ".zero"
+	}
+	translation, ok := translations[form]
+	if !ok {
+		form = key + ".other"
+		if translation, ok = translations[form]; !ok {
+			return key
+		}
+	}
+
+	if message, ok := t.messages[language][form]; ok {
+		return message.Format(language, args)
+	}
+	names := t.parameters[language][form]
+	if len(names) == 0 {
+		return translation
+	}
+	values := make([]any, len(names))
+	for i, name := range names {
+		values[i] = args[name]
+	}
+	return fmt.Sprintf(translation, values...)
+}
+
+// Format returns the translation of key formatted with args, the translation being an ICU MessageFormat
+// message with select or plural arguments.
+func (t *Translations) Format(ctx context.Context, key string, args map[string]any) string {
+	language := languageFromContext(ctx)
+	if message, ok := t.messages[language][key]; ok {
+		return message.Format(language, args)
+	}
+	return t.Get(ctx, key)
+}
+
+func languageFromContext(ctx context.Context) string {
+	language, ok := ctx.Value("language").(string)
+	if !ok {
+		language = "en"
+	}
+	return language
+}
+
 func TranslationsFromFiles(files map[string][]byte) (*Translations, error) {
-	t := &Translations{translations: make(map[string]map[string]string)}
+	t := &Translations{
+		translations: make(map[string]map[string]string),
+		parameters:   make(map[string]map[string][]string),
+		messages:     make(map[string]map[string]*Message),
+	}
 	for language, content := range files {
 		rawMap := make(map[string]any)
 
@@ -42,15 +100,21 @@ func TranslationsFromFiles(files map[string][]byte) (*Translations, error) {
 			return nil, fmt.Errorf("error parsing translations %s file: %w", language, err)
 		}
 		flatMap := make(map[string]string)
-		flatten(rawMap, "", flatMap)
+		parameters := make(map[string][]string)
+		messages := make(map[string]*Message)
+		if err := flatten(rawMap, "", flatMap, parameters, messages); err != nil {
+			return nil, fmt.Errorf("error parsing translations %s file: %w", language, err)
+		}
 
 		t.translations[language] = flatMap
+		t.parameters[language] = parameters
+		t.messages[language] = messages
 	}
 
 	return t, nil
 }
 
-func flatten(m map[string]interface{}, prefix string, translations map[string]string) {
+func flatten(m map[string]interface{}, prefix string, translations map[string]string, parameters map[string][]string, messages map[string]*Message) error {
 	for k, v := range m {
 		key := k
 		if prefix != "" {
@@ -58,12 +122,28 @@ func flatten(m map[string]interface{}, prefix string, translations map[string]st
 		}
 		switch v := v.(type) {
 		case map[string]interface{}:
-			flatten(v, key, translations)
+			if err := flatten(v, key, translations, parameters, messages); err != nil {
+				return err
+			}
 		default:
-			fmtStr, _ := parseParameters(fmt.Sprint(v))
+			value := fmt.Sprint(v)
+			if IsMessageFormat(value) {
+				message, err := ParseMessage(value)
+				if err != nil {
+					return fmt.Errorf("key %s: %w", key, err)
+				}
+				translations[key] = value
+				messages[key] = message
+				continue
+			}
+			fmtStr, params := parseParameters(value)
 			translations[key] = fmtStr
+			for _, param := range params {
+				parameters[key] = append(parameters[key], param.Name)
+			}
 		}
 	}
+	return nil
 }
 
 // Parse parameters from a translation string - modified to clean format strings
@@ -0,0 +1,144 @@
+package i18n
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPlural(t *testing.T) {
+	translations, err := TranslationsFromFiles(map[string][]byte{
+		"en": []byte(`
+notifications:
+  zero: "No notifications"
+  one: "One notification"
+  other: "{count:int} notifications for {user}"
+files:
+  one: "One file"
+  other: "{count:int} files"
+`),
+		"fr": []byte(`
+files:
+  one: "{count:int} fichier"
+  other: "{count:int} fichiers"
+`),
+		"ru": []byte(`
+files:
+  one: "{count:int} файл"
+  few: "{count:int} файла"
+  many: "{count:int} файлов"
+`),
+	})
+	if err != nil {
+		t.Fatalf("Expected translations to be parsed: %v", err)
+	}
+
+	tests := []struct {
+		language string
+		key      string
+		count    int
+		expected string
+	}{
+		{"en", "notifications", 0, "No notifications"},
+		{"en", "notifications", 1, "One notification"},
+		{"en", "notifications", 5, "5 notifications for Alice"},
+		{"en", "files", 0, "0 files"},
+		{"en", "files", 1, "One file"},
+		{"en", "files", 2, "2 files"},
+		{"fr", "files", 0, "0 fichier"},
+		{"fr", "files", 1, "1 fichier"},
+		{"fr", "files", 2, "2 fichiers"},
+		{"ru", "files", 21, "21 файл"},
+		{"ru", "files", 3, "3 файла"},
+		{"ru", "files", 11, "11 файлов"},
+		{"en", "missing", 2, "missing"},
+	}
+	for _, test := range tests {
+		ctx := context.WithValue(context.Background(), "language", test.language)
+		got := translations.Plural(ctx, test.key, test.count, map[string]any{"count": test.count, "user": "Alice"})
+		if got != test.expected {
+			t.Errorf("Expected %q for %s %s(%d), got %q", test.expected, test.language, test.key, test.count, got)
+		}
+	}
+}
+
+func TestPluralCategory(t *testing.T) {
+	tests := []struct {
+		language string
+		count    int
+		expected string
+	}{
+		{"en", 0, PluralOther},
+		{"en", 1, PluralOne},
+		{"en-US", 7, PluralOther},
+		{"pt_BR", 0, PluralOne},
+		{"ja", 1, PluralOther},
+		{"pl", 22, PluralFew},
+		{"pl", 25, PluralMany},
+		{"cs", 4, PluralFew},
+		{"ar", 2, PluralTwo},
+		{"ar", 11, PluralMany},
+		{"ar", 100, PluralOther},
+	}
+	for _, test := range tests {
+		if got := PluralCategory(test.language, test.count); got != test.expected {
+			t.Errorf("Expected %s for %d in %s, got %s", test.expected, test.count, test.language, got)
+		}
+	}
+}
+
+func TestFormatMessage(t *testing.T) {
+	translations, err := TranslationsFromFiles(map[string][]byte{
+		"en": []byte(`
+invite: "{gender, select, male{He} female{She} other{They}} invited {count, plural, =0{nobody} one{# guest} other{# guests}} to {event}"
+`),
+		"fr": []byte(`
+invite: "{gender, select, male{Il} female{Elle} other{Iel}} a invité {count, plural, =0{personne} one{# invité} other{# invités}} à {event}"
+`),
+	})
+	if err != nil {
+		t.Fatalf("Expected translations to be parsed: %v", err)
+	}
+
+	tests := []struct {
+		language string
+		gender   string
+		count    int
+		expected string
+	}{
+		{"en", "male", 0, "He invited nobody to the party"},
+		{"en", "female", 1, "She invited 1 guest to the party"},
+		{"en", "unknown", 3, "They invited 3 guests to the party"},
+		{"fr", "female", 0, "Elle a invité personne à the party"},
+		{"fr", "male", 1, "Il a invité 1 invité à the party"},
+	}
+	for _, test := range tests {
+		ctx := context.WithValue(context.Background(), "language", test.language)
+		got := translations.Format(ctx, "invite", map[string]any{"gender": test.gender, "count": test.count, "event": "the party"})
+		if got != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, got)
+		}
+	}
+}
+
+func TestParseMessage(t *testing.T) {
+	message, err := ParseMessage("{role, select, admin{{name} manages {teams:[]string}} other{{name}}} has {count, plural, one{# item} other{# items}}")
+	if err != nil {
+		t.Fatalf("Expected the message to be parsed: %v", err)
+	}
+	expected := []Parameter{{"role", "string"}, {"name", "string"}, {"teams", "[]string"}, {"count", "int"}}
+	if got := message.Parameters(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected parameters %v, got %v", expected, got)
+	}
+
+	for _, invalid := range []string{
+		"{gender, select, male{He}}",
+		"{gender, select, male{He} other{They}",
+		"{gender, choice, other{They}}",
+		"{count, plural, one # other{#}}",
+	} {
+		if _, err := ParseMessage(invalid); err == nil {
+			t.Errorf("Expected an error for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// messageFormatRegex matches the select and plural arguments of ICU MessageFormat messages.
+var messageFormatRegex = regexp.MustCompile(`\{\s*[\w.]+\s*,\s*(select|plural)\s*,`)
+
+const (
+	argumentSelect = "select"
+	argumentPlural = "plural"
+)
+
+// Message is a parsed ICU MessageFormat message. Besides the {name} and {name:type} placeholders, it supports
+// select and plural arguments whose cases are messages themselves, e.g.:
+//
+//	{gender, select, male{He} female{She} other{They}} invited {count, plural, =0{nobody} one{# guest} other{# guests}}
+//
+// In plural cases # is replaced by the count. Plural cases are exact values (=0) or plural categories.
+type Message struct {
+	parts []messagePart
+}
+
+type messagePart struct {
+	text string
+
+	// argument is the name of the argument, the part is a text when it is empty
+	argument     string
+	argumentType string
+	kind         string
+	cases        map[string]*Message
+	pound        bool
+}
+
+// IsMessageFormat reports whether value contains select or plural arguments and has to be parsed with
+// ParseMessage rather than substituted as a format string.
+func IsMessageFormat(value string) bool {
+	return messageFormatRegex.MatchString(value)
+}
+
+// ParseMessage parses an ICU MessageFormat message.
+func ParseMessage(value string) (*Message, error) {
+	p := &messageParser{value: value}
+	message, err := p.parseMessage(0, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid message %q: %w", value, err)
+	}
+	return message, nil
+}
+
+// Parameters returns the arguments of the message in their order of appearance, the cases of an argument
+// being walked by key with other last. Select arguments are strings and plural arguments are ints.
+func (m *Message) Parameters() []Parameter {
+	var params []Parameter
+	seen := map[string]bool{}
+	var collect func(*Message)
+	collect = func(m *Message) {
+		for _, part := range m.parts {
+			if part.argument == "" || part.pound {
+				continue
+			}
+			if !seen[part.argument] {
+				seen[part.argument] = true
+				params = append(params, Parameter{Name: part.argument, Type: part.argumentType})
+			}
+			for _, key := range sortedCaseKeys(part.cases) {
+				collect(part.cases[key])
+			}
+		}
+	}
+	collect(m)
+	return params
+}
+
+// Format formats the message with args, plural cases being picked with the rules of language.
+func (m *Message) Format(language string, args map[string]any) string {
+	var sb strings.Builder
+	m.format(&sb, language, args)
+	return sb.String()
+}
+
+func (m *Message) format(sb *strings.Builder, language string, args map[string]any) {
+	for _, part := range m.parts {
+		switch {
+		case part.argument == "":
+			sb.WriteString(part.text)
+		case part.pound:
+			sb.WriteString(fmt.Sprint(args[part.argument]))
+		case part.kind == argumentSelect:
+			selected, ok := part.cases[fmt.Sprint(args[part.argument])]
+			if !ok {
+				selected = part.cases[PluralOther]
+			}
+			selected.format(sb, language, args)
+		case part.kind == argumentPlural:
+			count := toInt(args[part.argument])
+			selected, ok := part.cases["="+strconv.Itoa(count)]
+			if !ok {
+				if selected, ok = part.cases[PluralCategory(language, count)]; !ok {
+					selected = part.cases[PluralOther]
+				}
+			}
+			selected.format(sb, language, args)
+		default:
+			sb.WriteString(fmt.Sprint(args[part.argument]))
+		}
+	}
+}
+
+type messageParser struct {
+	value string
+	pos   int
+}
+
+// parseMessage parses a message until the end of the value, or the closing brace of the case being parsed
+// when depth is positive. plural is the name of the enclosing plural argument, replacing #.
+func (p *messageParser) parseMessage(depth int, plural string) (*Message, error) {
+	message := &Message{}
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			message.parts = append(message.parts, messagePart{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.value) {
+		c := p.value[p.pos]
+		switch {
+		case c == '{':
+			flush()
+			p.pos++
+			part, err := p.parseArgument(depth, plural)
+			if err != nil {
+				return nil, err
+			}
+			message.parts = append(message.parts, part)
+		case c == '}':
+			if depth == 0 {
+				return nil, fmt.Errorf("unexpected } at %d", p.pos)
+			}
+			flush()
+			return message, nil
+		case c == '#' && plural != "":
+			flush()
+			p.pos++
+			message.parts = append(message.parts, messagePart{argument: plural, pound: true})
+		default:
+			text.WriteByte(c)
+			p.pos++
+		}
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("missing } at the end of the message")
+	}
+	flush()
+	return message, nil
+}
+
+// parseArgument parses an argument, the opening brace being consumed.
+func (p *messageParser) parseArgument(depth int, plural string) (messagePart, error) {
+	end := strings.IndexAny(p.value[p.pos:], ",}")
+	if end < 0 {
+		return messagePart{}, fmt.Errorf("unterminated argument at %d", p.pos)
+	}
+	header := p.value[p.pos : p.pos+end]
+	p.pos += end
+
+	if p.value[p.pos] == '}' {
+		p.pos++
+		name, argumentType, found := strings.Cut(header, ":")
+		if !found {
+			argumentType = "string"
+		}
+		return messagePart{argument: strings.TrimSpace(name), argumentType: strings.TrimSpace(argumentType)}, nil
+	}
+
+	part := messagePart{argument: strings.TrimSpace(header), cases: map[string]*Message{}}
+	p.pos++
+	end = strings.IndexByte(p.value[p.pos:], ',')
+	if end < 0 {
+		return messagePart{}, fmt.Errorf("missing cases for argument %s", part.argument)
+	}
+	part.kind = strings.TrimSpace(p.value[p.pos : p.pos+end])
+	p.pos += end + 1
+	switch part.kind {
+	case argumentSelect:
+		part.argumentType = "string"
+	case argumentPlural:
+		part.argumentType = "int"
+		plural = part.argument
+	default:
+		return messagePart{}, fmt.Errorf("unsupported argument type %s for %s", part.kind, part.argument)
+	}
+
+	for {
+		p.skipSpaces()
+		if p.pos >= len(p.value) {
+			return messagePart{}, fmt.Errorf("unterminated %s argument %s", part.kind, part.argument)
+		}
+		if p.value[p.pos] == '}' {
+			p.pos++
+			break
+		}
+
+		end = strings.IndexAny(p.value[p.pos:], "{} \t\n")
+		if end <= 0 {
+			return messagePart{}, fmt.Errorf("missing case key in %s argument %s", part.kind, part.argument)
+		}
+		key := p.value[p.pos : p.pos+end]
+		p.pos += end
+		p.skipSpaces()
+		if p.pos >= len(p.value) || p.value[p.pos] != '{' {
+			return messagePart{}, fmt.Errorf("missing message for case %s of %s", key, part.argument)
+		}
+		p.pos++
+		message, err := p.parseMessage(depth+1, plural)
+		if err != nil {
+			return messagePart{}, err
+		}
+		p.pos++
+		part.cases[key] = message
+	}
+
+	if _, ok := part.cases[PluralOther]; !ok {
+		return messagePart{}, fmt.Errorf("missing other case in %s argument %s", part.kind, part.argument)
+	}
+	return part, nil
+}
+
+func (p *messageParser) skipSpaces() {
+	for p.pos < len(p.value) && strings.IndexByte(" \t\n", p.value[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// sortedCaseKeys returns the sorted keys of cases with other last.
+func sortedCaseKeys(cases map[string]*Message) []string {
+	if len(cases) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(cases))
+	for key := range cases {
+		if key != PluralOther {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return append(keys, PluralOther)
+}
+
+func toInt(value any) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint:
+		return int(v)
+	case uint8:
+		return int(v)
+	case uint16:
+		return int(v)
+	case uint32:
+		return int(v)
+	case uint64:
+		return int(v)
+	default:
+		n, _ := strconv.Atoi(fmt.Sprint(value))
+		return n
+	}
+}
@@ -0,0 +1,91 @@
+package i18n
+
+import "strings"
+
+// Plural categories as defined by the Unicode CLDR.
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+// PluralCategories lists the plural categories in the order they are usually written.
+var PluralCategories = []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// PluralCategory returns the CLDR plural category of count in language, e.g. "en", "fr" or "pt-BR".
+// Only integer rules are implemented; languages without a specific rule use the english one.
+func PluralCategory(language string, count int) string {
+	n := count
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+
+	switch baseLanguage(language) {
+	case "ja", "zh", "ko", "vi", "th", "id", "ms", "tr":
+		return PluralOther
+	case "fr", "pt":
+		if n == 0 || n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	case "ru", "uk", "be":
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "pl":
+		switch {
+		case n == 1:
+			return PluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return PluralFew
+		default:
+			return PluralMany
+		}
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return PluralOne
+		case n >= 2 && n <= 4:
+			return PluralFew
+		default:
+			return PluralOther
+		}
+	case "ar":
+		switch {
+		case n == 0:
+			return PluralZero
+		case n == 1:
+			return PluralOne
+		case n == 2:
+			return PluralTwo
+		case mod100 >= 3 && mod100 <= 10:
+			return PluralFew
+		case mod100 >= 11:
+			return PluralMany
+		default:
+			return PluralOther
+		}
+	default:
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	}
+}
+
+// baseLanguage returns the language subtag of a locale, e.g. "pt" for "pt-BR" or "pt_BR".
+func baseLanguage(locale string) string {
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	return strings.ToLower(locale)
+}
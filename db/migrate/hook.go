@@ -0,0 +1,63 @@
+package migrate
+
+import "time"
+
+// Direction is the direction a migration is run in.
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// Phase is the step of a migration a MigrationEvent is emitted for.
+type Phase string
+
+const (
+	// PhaseStart is emitted before the migration runs.
+	PhaseStart Phase = "start"
+	// PhaseSuccess is emitted once the migration ran and was recorded in the migrations table.
+	// With GlobalTransactionOption, the transaction may still fail to commit afterwards.
+	PhaseSuccess Phase = "success"
+	// PhaseError is emitted when the migration or its record in the migrations table failed.
+	PhaseError Phase = "error"
+)
+
+// MigrationEvent is passed to the hook configured with HookOption around each migration.
+type MigrationEvent struct {
+	Version   string
+	Name      string
+	Direction Direction
+	Phase     Phase
+	// Elapsed is the time spent running the migration, zero for PhaseStart.
+	Elapsed time.Duration
+	// Err is the error of the migration, only set for PhaseError.
+	Err error
+}
+
+// startEvent emits the PhaseStart event of migration and returns a function emitting its outcome.
+func (c *options) startEvent(migration Migration, direction Direction) func(err error) {
+	if c.hook == nil {
+		return func(error) {}
+	}
+
+	name, at := migration.Version()
+	event := MigrationEvent{
+		Version:   formatVersion(name, at),
+		Name:      name,
+		Direction: direction,
+		Phase:     PhaseStart,
+	}
+	c.hook(event)
+
+	start := time.Now()
+	return func(err error) {
+		event.Elapsed = time.Since(start)
+		event.Phase = PhaseSuccess
+		if err != nil {
+			event.Phase = PhaseError
+			event.Err = err
+		}
+		c.hook(event)
+	}
+}
@@ -0,0 +1,148 @@
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintSeverity tells whether a lint issue must block a migration or is only a suspicious pattern.
+type LintSeverity string
+
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Lint rules reported by LintSQL and LintDir.
+const (
+	LintRuleInvalidFile         = "invalid-file"
+	LintRuleMissingDown         = "missing-down"
+	LintRuleUnguardedDropTable  = "unguarded-drop-table"
+	LintRuleNonIdempotentCreate = "non-idempotent-create-table"
+	LintRuleTimestampCollision  = "timestamp-collision"
+)
+
+// LintIssue describes a mistake found in a SQL migration file.
+type LintIssue struct {
+	File     string
+	Rule     string
+	Severity LintSeverity
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s: %s [%s] %s", i.File, i.Severity, i.Rule, i.Message)
+}
+
+var (
+	dropTablePattern        = regexp.MustCompile(`(?is)\bDROP\s+TABLE\s+(IF\s+EXISTS\s+)?([^\s;(]+)`)
+	createTablePattern      = regexp.MustCompile(`(?is)\bCREATE\s+(?:(?:GLOBAL\s+|LOCAL\s+)?(?:TEMP|TEMPORARY|UNLOGGED)\s+)?TABLE\s+(IF\s+NOT\s+EXISTS\s+)?([^\s;(]+)`)
+	sqlLineCommentPattern   = regexp.MustCompile(`--[^\n]*`)
+	sqlBlockCommentPattern  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	sqlStringLiteralPattern = regexp.MustCompile(`'(?:[^']|'')*'`)
+)
+
+// LintSQL checks a single SQL migration file for common mistakes:
+//   - file names that don't follow the {timestamp}_{name}.sql format and unparsable content
+//   - missing or empty -- migrate:down sections
+//   - DROP TABLE statements without IF EXISTS in the up section
+//   - CREATE TABLE statements without IF NOT EXISTS in the up section
+func LintSQL(filename, content string) []LintIssue {
+	var issues []LintIssue
+	add := func(rule string, severity LintSeverity, format string, args ...any) {
+		issues = append(issues, LintIssue{
+			File:     filename,
+			Rule:     rule,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if _, _, err := parseSQLFileName(filename); err != nil {
+		add(LintRuleInvalidFile, LintSeverityError, "invalid file name: %s", err)
+	}
+
+	upSQL, downSQL, _, _, err := parseSQLContent(content)
+	if err != nil {
+		add(LintRuleInvalidFile, LintSeverityError, "%s", err)
+		return issues
+	}
+
+	if downSQL == "" {
+		add(LintRuleMissingDown, LintSeverityWarning, "missing or empty -- migrate:down section, the migration cannot be rolled back")
+	}
+
+	up := sanitizeSQL(upSQL)
+	for _, match := range dropTablePattern.FindAllStringSubmatch(up, -1) {
+		if match[1] == "" {
+			add(LintRuleUnguardedDropTable, LintSeverityWarning, "DROP TABLE %s without IF EXISTS in the up section", match[2])
+		}
+	}
+	for _, match := range createTablePattern.FindAllStringSubmatch(up, -1) {
+		if match[1] == "" {
+			add(LintRuleNonIdempotentCreate, LintSeverityWarning, "CREATE TABLE %s without IF NOT EXISTS", match[2])
+		}
+	}
+
+	return issues
+}
+
+// LintDir lints every SQL migration of dir and reports timestamps shared by several files.
+// Issues are sorted by file name.
+func LintDir(fsys fs.FS, dir string) ([]LintIssue, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations directory %s: %w", dir, err)
+	}
+
+	var issues []LintIssue
+	filesByTimestamp := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		filename := path.Join(dir, entry.Name())
+		content, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
+		}
+		issues = append(issues, LintSQL(filename, string(content))...)
+
+		if _, at, err := parseSQLFileName(filename); err == nil {
+			timestamp := at.Format("20060102150405")
+			filesByTimestamp[timestamp] = append(filesByTimestamp[timestamp], filename)
+		}
+	}
+
+	for timestamp, files := range filesByTimestamp {
+		if len(files) < 2 {
+			continue
+		}
+		for _, file := range files {
+			issues = append(issues, LintIssue{
+				File:     file,
+				Rule:     LintRuleTimestampCollision,
+				Severity: LintSeverityError,
+				Message:  fmt.Sprintf("timestamp %s is shared by %s", timestamp, strings.Join(files, ", ")),
+			})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].File < issues[j].File
+	})
+
+	return issues, nil
+}
+
+// sanitizeSQL removes comments and string literals so keywords inside them are not linted.
+func sanitizeSQL(sql string) string {
+	sql = sqlBlockCommentPattern.ReplaceAllString(sql, " ")
+	sql = sqlLineCommentPattern.ReplaceAllString(sql, " ")
+	return sqlStringLiteralPattern.ReplaceAllString(sql, "''")
+}
@@ -0,0 +1,100 @@
+package migrate
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lintRules(issues []LintIssue) []string {
+	rules := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		rules = append(rules, issue.Rule)
+	}
+	return rules
+}
+
+func TestLintSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "clean migration",
+			filename: "20240101120000_create_users.sql",
+			content: `-- migrate:up
+CREATE TABLE IF NOT EXISTS users (id INTEGER PRIMARY KEY);
+
+-- migrate:down
+DROP TABLE users;`,
+			expected: []string{},
+		},
+		{
+			name:     "missing down section",
+			filename: "20240101120000_create_users.sql",
+			content: `-- migrate:up
+CREATE TABLE IF NOT EXISTS users (id INTEGER PRIMARY KEY);`,
+			expected: []string{LintRuleMissingDown},
+		},
+		{
+			name:     "unguarded drop and non idempotent create",
+			filename: "20240101120000_replace_users.sql",
+			content: `-- migrate:up
+DROP TABLE users;
+CREATE TABLE accounts (id INTEGER PRIMARY KEY);
+
+-- migrate:down
+DROP TABLE accounts;`,
+			expected: []string{LintRuleUnguardedDropTable, LintRuleNonIdempotentCreate},
+		},
+		{
+			name:     "keywords in comments and strings are ignored",
+			filename: "20240101120000_seed.sql",
+			content: `-- migrate:up
+-- DROP TABLE users;
+/* CREATE TABLE users (id INTEGER) */
+INSERT INTO notes (body) VALUES ('DROP TABLE users');
+
+-- migrate:down
+DELETE FROM notes;`,
+			expected: []string{},
+		},
+		{
+			name:     "invalid file name and missing up section",
+			filename: "create_users.sql",
+			content:  `SELECT 1;`,
+			expected: []string{LintRuleInvalidFile, LintRuleInvalidFile},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := LintSQL(tt.filename, tt.content)
+			assert.Equal(t, tt.expected, lintRules(issues))
+		})
+	}
+}
+
+func TestLintDirTimestampCollision(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20240101120000_create_users.sql": {Data: []byte("-- migrate:up\nCREATE TABLE IF NOT EXISTS users (id INTEGER);\n-- migrate:down\nDROP TABLE users;")},
+		"migrations/20240101120000_create_posts.sql": {Data: []byte("-- migrate:up\nCREATE TABLE IF NOT EXISTS posts (id INTEGER);\n-- migrate:down\nDROP TABLE posts;")},
+		"migrations/20240102120000_create_tags.sql":  {Data: []byte("-- migrate:up\nCREATE TABLE IF NOT EXISTS tags (id INTEGER);\n-- migrate:down\nDROP TABLE tags;")},
+		"migrations/README.md":                       {Data: []byte("not a migration")},
+	}
+
+	issues, err := LintDir(fsys, "migrations")
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.Equal(t, "migrations/20240101120000_create_posts.sql", issues[0].File)
+	assert.Equal(t, "migrations/20240101120000_create_users.sql", issues[1].File)
+	for _, issue := range issues {
+		assert.Equal(t, LintRuleTimestampCollision, issue.Rule)
+		assert.Equal(t, LintSeverityError, issue.Severity)
+	}
+}
@@ -0,0 +1,134 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrLockTimeout is returned when the migration lock is still held by another migrator after the lock timeout.
+var ErrLockTimeout = errors.New("timed out waiting for the migration lock")
+
+// migrationLockKey identifies the migration lock, it is the key of pg_advisory_lock.
+const migrationLockKey int64 = 7_150_388_219_486_331_121
+
+// migrationLockName is the name of the MySQL GET_LOCK lock.
+const migrationLockName = "goframe_schema_migrations"
+
+// lockPollInterval is the delay between two attempts to insert the SQLite lock row.
+const lockPollInterval = 50 * time.Millisecond
+
+// acquireLock serializes migrators running against the same database. It returns a function releasing the lock.
+//
+// Postgres uses pg_advisory_lock and MySQL GET_LOCK, both held by a dedicated connection of the pool.
+// SQLite has no advisory locks: a row is inserted into migration_lock and deleted on release, a migrator
+// that crashed while holding it leaves the row behind and it must be deleted by hand.
+// Other databases are not locked.
+func (m *Migrator) acquireLock(ctx context.Context, timeout time.Duration) (func() error, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var release func() error
+	var err error
+	switch m.db.Dialector.Name() {
+	case "postgres":
+		release, err = m.acquireSessionLock(lockCtx, "SELECT pg_advisory_lock($1)", "SELECT pg_advisory_unlock($1)", migrationLockKey)
+	case "mysql":
+		release, err = m.acquireMySQLLock(lockCtx, timeout)
+	case "sqlite":
+		release, err = m.acquireRowLock(lockCtx)
+	default:
+		return func() error { return nil }, nil
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, fmt.Errorf("%w after %s", ErrLockTimeout, timeout)
+	}
+	return release, err
+}
+
+// acquireSessionLock takes a lock held by a connection, it is released on the same connection.
+func (m *Migrator) acquireSessionLock(ctx context.Context, lockQuery, unlockQuery string, key any) (func() error, error) {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := conn.ExecContext(ctx, lockQuery, key); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), unlockQuery, key)
+		return errors.Join(err, conn.Close())
+	}, nil
+}
+
+// acquireMySQLLock takes the GET_LOCK lock, which returns 0 instead of failing when the timeout is reached.
+func (m *Migrator) acquireMySQLLock(ctx context.Context, timeout time.Duration) (func() error, error) {
+	sqlDB, err := m.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", migrationLockName, int(timeout.Seconds())).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if acquired.Int64 != 1 {
+		conn.Close()
+		return nil, context.DeadlineExceeded
+	}
+
+	return func() error {
+		_, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", migrationLockName)
+		return errors.Join(err, conn.Close())
+	}, nil
+}
+
+// acquireRowLock inserts the lock row of migration_lock, retrying while another migrator holds it.
+func (m *Migrator) acquireRowLock(ctx context.Context) (func() error, error) {
+	db := m.db.WithContext(ctx)
+	err := db.Exec(`CREATE TABLE IF NOT EXISTS migration_lock (
+		id INTEGER NOT NULL PRIMARY KEY,
+		locked_at TIMESTAMP NOT NULL
+	)`).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+
+	for {
+		result := db.Exec("INSERT INTO migration_lock (id, locked_at) VALUES (1, $1) ON CONFLICT (id) DO NOTHING", time.Now().UTC())
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	return func() error {
+		return m.db.WithContext(context.Background()).Exec("DELETE FROM migration_lock WHERE id = 1").Error
+	}, nil
+}
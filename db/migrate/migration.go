@@ -43,6 +43,13 @@
 //	err = migrator.Down(ctx, migrations, 2) // rollback 2 steps
 //	err = migrator.Down(ctx, migrations, 0) // rollback all (same as DownAll)
 //
+//	// Migrate up to a version, or roll back the migrations applied after it
+//	err = migrator.UpTo(ctx, migrations, "20240101120000_create_users_table")
+//	err = migrator.DownTo(ctx, migrations, "20240101120000")
+//
+//	// Roll back and apply again the last applied migration
+//	err = migrator.Redo(ctx, migrations)
+//
 // # SQL File Migrations
 //
 // Create migrations from SQL files using the expected format:
@@ -62,15 +69,25 @@
 //	migrations := []migrate.Migration{migration}
 //	err := migrator.Up(ctx, migrations)
 //
+//	// Or load every SQL migration of a directory
+//	migrations, err := migrate.MigrationsFromDir(fsys, "migrations")
+//
+// The statements of a section are executed one at a time, as some drivers only accept one statement per Exec.
+// Semicolons inside string literals, comments and dollar-quoted function bodies don't end a statement.
+// A file containing a "-- migrate:no-split" line executes each of its sections in a single Exec instead.
+//
 // The rollback steps functionality allows precise control over how many migrations
 // to rollback, making it safer to undo recent changes without affecting older migrations.
 package migrate
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
+	"path"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -98,6 +115,22 @@ type MigrationWithTx interface {
 	UseTx(kind string) bool
 }
 
+// MigrationWithTimeout is an optional interface for migrations that need a different timeout than the one
+// given with TimeoutOption, like a long data backfill.
+type MigrationWithTimeout interface {
+	// Timeout returns the maximum duration of the migration, for both up and down.
+	Timeout() time.Duration
+}
+
+// ErrUnknownVersion is returned by UpTo and DownTo when the target version is not one of the migrations.
+var ErrUnknownVersion = errors.New("unknown migration version")
+
+// ErrNoAppliedMigrations is returned by Redo when there is no migration to redo.
+var ErrNoAppliedMigrations = errors.New("no applied migrations")
+
+// ErrInvalidTableName is returned when the name given to TableNameOption is not a plain SQL identifier.
+var ErrInvalidTableName = errors.New("invalid migrations table name")
+
 // Option configures migration execution.
 type Option func(*options)
 
@@ -106,6 +139,29 @@ type options struct {
 	globalTransaction bool
 	timeout           time.Duration
 	logger            *slog.Logger
+	lock              bool
+	lockTimeout       time.Duration
+	tableName         string
+	dryRun            bool
+	hook              func(MigrationEvent)
+}
+
+// defaultTableName is the table recording the applied migrations, see TableNameOption.
+const defaultTableName = "schema_migrations"
+
+// tableNamePattern matches the table names accepted by TableNameOption, optionally qualified by a schema.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// newOptions applies opts over the defaults shared by every operation.
+func newOptions(opts []Option) (*options, error) {
+	cfg := &options{tableName: defaultTableName}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if !tableNamePattern.MatchString(cfg.tableName) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTableName, cfg.tableName)
+	}
+	return cfg, nil
 }
 
 // GlobalTransactionOption configures whether all migrations run in a single transaction.
@@ -130,6 +186,48 @@ func LoggerOption(logger *slog.Logger) Option {
 	}
 }
 
+// LockOption configures whether the migration lock is taken, it is enabled by default.
+// The lock prevents several instances started at the same time from running the same migrations.
+func LockOption(enabled bool) Option {
+	return func(c *options) {
+		c.lock = enabled
+	}
+}
+
+// LockTimeoutOption configures how long to wait for the migration lock held by another migrator.
+// ErrLockTimeout is returned when it is exceeded.
+func LockTimeoutOption(timeout time.Duration) Option {
+	return func(c *options) {
+		c.lockTimeout = timeout
+	}
+}
+
+// TableNameOption configures the table recording the applied migrations, schema_migrations by default.
+// It lets several applications share a database, e.g. billing_schema_migrations. The name may be qualified by
+// a schema and must only contain letters, digits and underscores.
+func TableNameOption(name string) Option {
+	return func(c *options) {
+		c.tableName = name
+	}
+}
+
+// DryRunOption configures whether migrations are only logged instead of being executed.
+// The SQL of SQL file migrations is logged, other migrations can't be introspected and only their version is.
+// Nothing is written to the database, including the migrations table.
+func DryRunOption(enabled bool) Option {
+	return func(c *options) {
+		c.dryRun = enabled
+	}
+}
+
+// HookOption configures a function called before and after each migration, e.g. to export metrics.
+// It is called synchronously, a slow hook delays the migrations.
+func HookOption(hook func(MigrationEvent)) Option {
+	return func(c *options) {
+		c.hook = hook
+	}
+}
+
 // Migrator handles database migrations.
 type Migrator struct {
 	db *gorm.DB
@@ -142,29 +240,40 @@ func New(db *gorm.DB) *Migrator {
 
 // Up executes pending migrations in chronological order.
 func (m *Migrator) Up(ctx context.Context, migrations []Migration, opts ...Option) error {
-	cfg := &options{
-		timeout: 15 * time.Second,                            // default timeout
-		logger:  slog.Default().With("component", "migrate"), // default logger
-	}
-	for _, opt := range opts {
-		opt(cfg)
+	cfg, err := newOptions(append([]Option{
+		TimeoutOption(15 * time.Second),                           // default timeout
+		LoggerOption(slog.Default().With("component", "migrate")), // default logger
+		LockOption(true),
+		LockTimeoutOption(15 * time.Second),
+	}, opts...))
+	if err != nil {
+		return err
 	}
 
 	if cfg.logger != nil {
 		cfg.logger.InfoContext(ctx, "starting migration up process")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	start := time.Now()
+	setupCtx, cancel := runContext(ctx, start, nil, cfg)
 	defer cancel()
 
-	if err := m.ensureTable(ctx); err != nil {
-		if cfg.logger != nil {
-			cfg.logger.ErrorContext(ctx, "failed to ensure migrations table", "error", err)
-		}
-		return fmt.Errorf("failed to ensure migrations table: %w", err)
+	release, err := m.prepare(setupCtx, cfg)
+	if err != nil {
+		return err
 	}
+	defer release()
 
-	applied, err := m.getAppliedMigrations(ctx)
+	return m.up(ctx, start, migrations, cfg)
+}
+
+// up executes pending migrations, the migrations table and the lock being handled by the caller, see prepare.
+// The run started at start, see runContext.
+func (m *Migrator) up(ctx context.Context, start time.Time, migrations []Migration, cfg *options) error {
+	setupCtx, cancel := runContext(ctx, start, nil, cfg)
+	defer cancel()
+
+	applied, err := m.getAppliedMigrations(setupCtx, cfg.tableName)
 	if err != nil {
 		if cfg.logger != nil {
 			cfg.logger.ErrorContext(ctx, "failed to get applied migrations", "error", err)
@@ -181,11 +290,22 @@ func (m *Migrator) Up(ctx context.Context, migrations []Migration, opts ...Optio
 		return nil
 	}
 
+	ctx, cancelRun := runContext(ctx, start, pending, cfg)
+	defer cancelRun()
+
+	if cfg.dryRun {
+		m.logDryRun(ctx, pending, true, cfg)
+		return nil
+	}
+
 	if cfg.globalTransaction {
 		return m.runInGlobalTransaction(ctx, pending, true, cfg)
 	}
 
 	for _, migration := range pending {
+		if err := checkContext(ctx, migration, cfg); err != nil {
+			return err
+		}
 		if err := m.runUp(ctx, migration, cfg); err != nil {
 			name, at := migration.Version()
 			if cfg.logger != nil {
@@ -210,12 +330,14 @@ func (m *Migrator) Up(ctx context.Context, migrations []Migration, opts ...Optio
 // Down executes applied migrations in reverse chronological order.
 // If steps is 0, all applied migrations are rolled back.
 func (m *Migrator) Down(ctx context.Context, migrations []Migration, steps int, opts ...Option) error {
-	cfg := &options{
-		timeout: 15 * time.Second, // default timeout
-		logger:  slog.Default(),   // default logger
-	}
-	for _, opt := range opts {
-		opt(cfg)
+	cfg, err := newOptions(append([]Option{
+		TimeoutOption(15 * time.Second), // default timeout
+		LoggerOption(slog.Default()),    // default logger
+		LockOption(true),
+		LockTimeoutOption(15 * time.Second),
+	}, opts...))
+	if err != nil {
+		return err
 	}
 
 	if cfg.logger != nil {
@@ -229,18 +351,26 @@ func (m *Migrator) Down(ctx context.Context, migrations []Migration, steps int,
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	start := time.Now()
+	setupCtx, cancel := runContext(ctx, start, nil, cfg)
 	defer cancel()
 
-	if err := m.ensureTable(ctx); err != nil {
-		if cfg.logger != nil {
-			cfg.logger.ErrorContext(ctx, "failed to ensure migrations table",
-				"error", err)
-		}
-		return fmt.Errorf("failed to ensure migrations table: %w", err)
+	release, err := m.prepare(setupCtx, cfg)
+	if err != nil {
+		return err
 	}
+	defer release()
 
-	applied, err := m.getAppliedMigrations(ctx)
+	return m.down(ctx, start, migrations, steps, cfg)
+}
+
+// down rolls back applied migrations, the migrations table and the lock being handled by the caller, see prepare.
+// The run started at start, see runContext.
+func (m *Migrator) down(ctx context.Context, start time.Time, migrations []Migration, steps int, cfg *options) error {
+	setupCtx, cancel := runContext(ctx, start, nil, cfg)
+	defer cancel()
+
+	applied, err := m.getAppliedMigrations(setupCtx, cfg.tableName)
 	if err != nil {
 		if cfg.logger != nil {
 			cfg.logger.ErrorContext(ctx, "failed to get applied migrations",
@@ -283,11 +413,22 @@ func (m *Migrator) Down(ctx context.Context, migrations []Migration, steps int,
 		}
 	}
 
+	ctx, cancelRun := runContext(ctx, start, sorted, cfg)
+	defer cancelRun()
+
+	if cfg.dryRun {
+		m.logDryRun(ctx, sorted, false, cfg)
+		return nil
+	}
+
 	if cfg.globalTransaction {
 		return m.runInGlobalTransaction(ctx, sorted, false, cfg)
 	}
 
 	for _, migration := range sorted {
+		if err := checkContext(ctx, migration, cfg); err != nil {
+			return err
+		}
 		if err := m.runDown(ctx, migration, cfg); err != nil {
 			name, at := migration.Version()
 			if cfg.logger != nil {
@@ -328,6 +469,144 @@ func (m *Migrator) DownSteps(ctx context.Context, migrations []Migration, steps
 	return m.Down(ctx, migrations, steps, opts...)
 }
 
+// Redo rolls back the last applied migration and applies it again, e.g. to try changes to it during development.
+// The lock is held across both, so another migrator can't run in between.
+// An error wrapping ErrNoAppliedMigrations is returned when none of the migrations is applied.
+func (m *Migrator) Redo(ctx context.Context, migrations []Migration, opts ...Option) error {
+	cfg, err := newOptions(append([]Option{
+		TimeoutOption(15 * time.Second),                           // default timeout
+		LoggerOption(slog.Default().With("component", "migrate")), // default logger
+		LockOption(true),
+		LockTimeoutOption(15 * time.Second),
+	}, opts...))
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	setupCtx, cancel := runContext(ctx, start, nil, cfg)
+	defer cancel()
+
+	release, err := m.prepare(setupCtx, cfg)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	last, err := m.lastApplied(setupCtx, migrations, cfg)
+	if err != nil {
+		return err
+	}
+	name, at := last.Version()
+	version := formatVersion(name, at)
+
+	// the migration stays applied in dry run, the up would find nothing pending
+	if cfg.dryRun {
+		m.logDryRun(ctx, []Migration{last}, false, cfg)
+		m.logDryRun(ctx, []Migration{last}, true, cfg)
+		return nil
+	}
+
+	if cfg.logger != nil {
+		cfg.logger.InfoContext(ctx, "redo: rolling back migration", "migration_version", version)
+	}
+	if err := m.down(ctx, start, []Migration{last}, 1, cfg); err != nil {
+		return fmt.Errorf("failed to redo migration %s: %w", version, err)
+	}
+
+	if cfg.logger != nil {
+		cfg.logger.InfoContext(ctx, "redo: applying migration again", "migration_version", version)
+	}
+	// the up gets a timeout of its own, as when running Down then Up
+	if err := m.up(ctx, time.Now(), []Migration{last}, cfg); err != nil {
+		return fmt.Errorf("failed to redo migration %s: %w", version, err)
+	}
+
+	return nil
+}
+
+// lastApplied returns the most recent of the applied migrations.
+func (m *Migrator) lastApplied(ctx context.Context, migrations []Migration, cfg *options) (Migration, error) {
+	statuses, err := m.Status(ctx, migrations, TableNameOption(cfg.tableName))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(statuses) - 1; i >= 0; i-- {
+		if !statuses[i].Applied {
+			continue
+		}
+		for _, migration := range migrations {
+			if name, at := migration.Version(); formatVersion(name, at) == statuses[i].Version {
+				return migration, nil
+			}
+		}
+	}
+
+	return nil, ErrNoAppliedMigrations
+}
+
+// UpTo executes pending migrations in chronological order up to and including the target version.
+// The target is a version as recorded in schema_migrations (20240101120000_create_users_table) or its timestamp
+// (20240101120000), an error wrapping ErrUnknownVersion is returned when no migration matches it.
+func (m *Migrator) UpTo(ctx context.Context, migrations []Migration, targetVersion string, opts ...Option) error {
+	target, err := findVersion(migrations, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	var selected []Migration
+	for _, migration := range migrations {
+		if _, at := migration.Version(); !at.After(target) {
+			selected = append(selected, migration)
+		}
+	}
+
+	return m.Up(ctx, selected, opts...)
+}
+
+// DownTo rolls back applied migrations in reverse chronological order until the target version, which stays applied.
+// The target is matched like in UpTo.
+func (m *Migrator) DownTo(ctx context.Context, migrations []Migration, targetVersion string, opts ...Option) error {
+	target, err := findVersion(migrations, targetVersion)
+	if err != nil {
+		return err
+	}
+
+	var selected []Migration
+	for _, migration := range migrations {
+		if _, at := migration.Version(); at.After(target) {
+			selected = append(selected, migration)
+		}
+	}
+
+	if len(selected) == 0 {
+		return nil
+	}
+	return m.Down(ctx, selected, 0, opts...)
+}
+
+// findVersion returns the timestamp of the migration matching version, see UpTo.
+func findVersion(migrations []Migration, version string) (time.Time, error) {
+	var matches []Migration
+	for _, migration := range migrations {
+		name, at := migration.Version()
+		if formatVersion(name, at) == version || at.UTC().Format("20060102150405") == version {
+			matches = append(matches, migration)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return time.Time{}, fmt.Errorf("%w: %s", ErrUnknownVersion, version)
+	case 1:
+		_, at := matches[0].Version()
+		return at, nil
+	default:
+		return time.Time{}, fmt.Errorf("%w: %s matches %d migrations, use the full version", ErrUnknownVersion, version, len(matches))
+	}
+}
+
 // runInGlobalTransaction executes all migrations in a single transaction.
 func (m *Migrator) runInGlobalTransaction(ctx context.Context, migrations []Migration, isUp bool, cfg *options) error {
 	if cfg.logger != nil {
@@ -352,6 +631,10 @@ func (m *Migrator) runInGlobalTransaction(ctx context.Context, migrations []Migr
 	txCtx := dbutil.WithDB(ctx, tx)
 
 	for _, migration := range migrations {
+		if err := checkContext(ctx, migration, cfg); err != nil {
+			return err
+		}
+
 		name, at := migration.Version()
 		version := formatVersion(name, at)
 
@@ -365,14 +648,23 @@ func (m *Migrator) runInGlobalTransaction(ctx context.Context, migrations []Migr
 				"migration_name", name)
 		}
 
+		direction := DirectionUp
+		if !isUp {
+			direction = DirectionDown
+		}
+		finish := cfg.startEvent(migration, direction)
+
+		migrationCtx, cancelMigration := withMigrationTimeout(txCtx, migration)
 		var execErr error
 		if isUp {
-			execErr = migration.Up(txCtx)
+			execErr = migration.Up(migrationCtx)
 		} else {
-			execErr = migration.Down(txCtx)
+			execErr = migration.Down(migrationCtx)
 		}
+		cancelMigration()
 
 		if execErr != nil {
+			finish(execErr)
 			action := "run"
 			if !isUp {
 				action = "rollback"
@@ -390,11 +682,12 @@ func (m *Migrator) runInGlobalTransaction(ctx context.Context, migrations []Migr
 		// Update migration table
 		var err error
 		if isUp {
-			err = tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version).Error
+			err = tx.Exec("INSERT INTO "+cfg.tableName+" (version, applied_at) VALUES ($1, $2)", version, time.Now().UTC()).Error
 		} else {
-			err = tx.Exec("DELETE FROM schema_migrations WHERE version = $1", version).Error
+			err = tx.Exec("DELETE FROM "+cfg.tableName+" WHERE version = $1", version).Error
 		}
 
+		finish(err)
 		if err != nil {
 			if cfg.logger != nil {
 				cfg.logger.ErrorContext(ctx, "failed to update migration table in global transaction",
@@ -414,6 +707,14 @@ func (m *Migrator) runInGlobalTransaction(ctx context.Context, migrations []Migr
 		}
 	}
 
+	// Do not commit work done after the context was cancelled, the deferred rollback discards it
+	if err := ctx.Err(); err != nil {
+		if cfg.logger != nil {
+			cfg.logger.ErrorContext(ctx, "global transaction aborted before commit", "error", err)
+		}
+		return fmt.Errorf("global transaction aborted: %w", err)
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		if cfg.logger != nil {
 			cfg.logger.ErrorContext(ctx, "failed to commit global transaction", "error", err)
@@ -434,18 +735,114 @@ func (m *Migrator) runInGlobalTransaction(ctx context.Context, migrations []Migr
 	return nil
 }
 
-// ensureTable creates the schema_migrations table if it doesn't exist.
-func (m *Migrator) ensureTable(ctx context.Context) error {
-	query := `CREATE TABLE IF NOT EXISTS schema_migrations (
-		version VARCHAR NOT NULL PRIMARY KEY
+// checkContext returns an error when ctx is cancelled or timed out, so remaining migrations are not attempted.
+func checkContext(ctx context.Context, migration Migration, cfg *options) error {
+	err := ctx.Err()
+	if err == nil {
+		return nil
+	}
+
+	name, at := migration.Version()
+	if cfg.logger != nil {
+		cfg.logger.ErrorContext(ctx, "migration process aborted",
+			"migration_version", formatVersion(name, at),
+			"migration_name", name,
+			"error", err)
+	}
+	return fmt.Errorf("aborted before migration %s_%s: %w",
+		at.UTC().Format("20060102150405"), name, err)
+}
+
+// prepare ensures the migrations table exists and acquires the migration lock when enabled, before running
+// migrations. Nothing is written in dry run, not even the migrations table. The returned function releases the lock.
+func (m *Migrator) prepare(ctx context.Context, cfg *options) (func(), error) {
+	if cfg.dryRun {
+		return func() {}, nil
+	}
+
+	if err := m.ensureTable(ctx, cfg.tableName); err != nil {
+		if cfg.logger != nil {
+			cfg.logger.ErrorContext(ctx, "failed to ensure migrations table", "error", err)
+		}
+		return nil, fmt.Errorf("failed to ensure migrations table: %w", err)
+	}
+
+	if !cfg.lock {
+		return func() {}, nil
+	}
+	return m.lock(ctx, cfg)
+}
+
+// lock acquires the migration lock, see acquireLock. The returned function releases it and logs a failure.
+func (m *Migrator) lock(ctx context.Context, cfg *options) (func(), error) {
+	release, err := m.acquireLock(ctx, cfg.lockTimeout)
+	if err != nil {
+		if cfg.logger != nil {
+			cfg.logger.ErrorContext(ctx, "failed to acquire migration lock", "error", err)
+		}
+		return nil, fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+
+	return func() {
+		if err := release(); err != nil && cfg.logger != nil {
+			cfg.logger.ErrorContext(ctx, "failed to release migration lock", "error", err)
+		}
+	}, nil
+}
+
+// logDryRun logs the migrations that would be applied or rolled back, see DryRunOption.
+func (m *Migrator) logDryRun(ctx context.Context, migrations []Migration, isUp bool, cfg *options) {
+	if cfg.logger == nil {
+		return
+	}
+
+	for _, migration := range migrations {
+		name, at := migration.Version()
+		attrs := []any{"migration_version", formatVersion(name, at), "migration_name", name}
+
+		if sqlMig, ok := migration.(*sqlMigration); ok {
+			sql := sqlMig.downSQL
+			if isUp {
+				sql = sqlMig.upSQL
+			}
+			attrs = append(attrs, "sql", sql)
+		}
+
+		if isUp {
+			cfg.logger.InfoContext(ctx, "dry run: migration would be applied", attrs...)
+		} else {
+			cfg.logger.InfoContext(ctx, "dry run: migration would be rolled back", attrs...)
+		}
+	}
+}
+
+// ensureTable creates the migrations table if it doesn't exist.
+// Tables created before applied_at was recorded get the column added, their rows keep a NULL applied_at.
+func (m *Migrator) ensureTable(ctx context.Context, table string) error {
+	query := `CREATE TABLE IF NOT EXISTS ` + table + ` (
+		version VARCHAR NOT NULL PRIMARY KEY,
+		applied_at TIMESTAMP NULL
 	)`
 
-	return m.db.WithContext(ctx).Exec(query).Error
+	db := m.db.WithContext(ctx)
+	if err := db.Exec(query).Error; err != nil {
+		return err
+	}
+
+	if !db.Migrator().HasColumn(table, "applied_at") {
+		return db.Exec("ALTER TABLE " + table + " ADD COLUMN applied_at TIMESTAMP NULL").Error
+	}
+
+	return nil
 }
 
-// getAppliedMigrations returns a set of applied migration versions.
-func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[string]bool, error) {
-	rows, err := m.db.WithContext(ctx).Raw("SELECT version FROM schema_migrations").Rows()
+// getAppliedMigrations returns a set of applied migration versions, empty when the migrations table doesn't exist.
+func (m *Migrator) getAppliedMigrations(ctx context.Context, table string) (map[string]bool, error) {
+	if !m.db.WithContext(ctx).Migrator().HasTable(table) {
+		return make(map[string]bool), nil
+	}
+
+	rows, err := m.db.WithContext(ctx).Raw("SELECT version FROM " + table).Rows()
 	if err != nil {
 		return nil, err
 	}
@@ -463,23 +860,133 @@ func (m *Migrator) getAppliedMigrations(ctx context.Context) (map[string]bool, e
 	return applied, rows.Err()
 }
 
-// Applied returns all applied migration versions sorted chronologically.
-func (m *Migrator) Applied(ctx context.Context) ([]string, error) {
-	rows, err := m.db.WithContext(ctx).Raw("SELECT version FROM schema_migrations ORDER BY version ASC").Rows()
+// AppliedMigration is a migration recorded in the migrations table.
+type AppliedMigration struct {
+	Version string // Version as recorded in schema_migrations (e.g. 20240101120000_create_users_table)
+	// AppliedAt is when the migration was applied, nil for migrations applied before applied_at was recorded.
+	AppliedAt *time.Time
+}
+
+// Applied returns all applied migrations sorted chronologically.
+// Only TableNameOption is used among opts.
+func (m *Migrator) Applied(ctx context.Context, opts ...Option) ([]AppliedMigration, error) {
+	cfg, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.WithContext(ctx).Raw("SELECT version FROM " + cfg.tableName + " ORDER BY version ASC").Rows()
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var list []string
+	var versions []string
 	for rows.Next() {
 		var version string
 		if err := rows.Scan(&version); err != nil {
 			return nil, err
 		}
-		list = append(list, version)
+		versions = append(versions, version)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	appliedAt, err := m.getAppliedAt(ctx, cfg.tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migrations applied at: %w", err)
+	}
+
+	list := make([]AppliedMigration, 0, len(versions))
+	for _, version := range versions {
+		list = append(list, AppliedMigration{Version: version, AppliedAt: appliedAt[version]})
+	}
+	return list, nil
+}
+
+// MigrationStatus describes whether a migration has been applied to the database.
+type MigrationStatus struct {
+	Version string    // Version as recorded in schema_migrations (e.g. 20240101120000_create_users_table)
+	Name    string    // Name of the migration
+	At      time.Time // Timestamp of the migration
+	Applied bool
+	// AppliedAt is when the migration was applied, nil for migrations applied before applied_at was recorded.
+	AppliedAt *time.Time
+}
+
+// Status returns the status of each migration sorted chronologically, telling which are applied and which are pending.
+// The migrations table is not created when it doesn't exist yet, all migrations are then pending.
+// Only TableNameOption is used among opts.
+func (m *Migrator) Status(ctx context.Context, migrations []Migration, opts ...Option) ([]MigrationStatus, error) {
+	cfg, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := m.getAppliedMigrations(ctx, cfg.tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	appliedAt, err := m.getAppliedAt(ctx, cfg.tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migrations applied at: %w", err)
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		name, at := migration.Version()
+		version := formatVersion(name, at)
+		if applied[version] {
+			statuses = append(statuses, MigrationStatus{
+				Version:   version,
+				Name:      name,
+				At:        at,
+				Applied:   true,
+				AppliedAt: appliedAt[version],
+			})
+		}
+	}
+
+	for _, migration := range m.filterPending(migrations, applied) {
+		name, at := migration.Version()
+		statuses = append(statuses, MigrationStatus{Version: formatVersion(name, at), Name: name, At: at})
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].At.Before(statuses[j].At)
+	})
+
+	return statuses, nil
+}
+
+// getAppliedAt returns when each migration was applied.
+// It is empty when the migrations table has not been upgraded with the applied_at column yet, see ensureTable.
+func (m *Migrator) getAppliedAt(ctx context.Context, table string) (map[string]*time.Time, error) {
+	appliedAt := make(map[string]*time.Time)
+	if !m.db.WithContext(ctx).Migrator().HasColumn(table, "applied_at") {
+		return appliedAt, nil
+	}
+
+	rows, err := m.db.WithContext(ctx).Raw("SELECT version, applied_at FROM " + table).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version string
+		var at sql.NullTime
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		if at.Valid {
+			appliedAt[version] = &at.Time
+		}
 	}
-	return list, rows.Err()
+
+	return appliedAt, rows.Err()
 }
 
 // filterPending returns migrations that haven't been applied yet, sorted by timestamp.
@@ -506,7 +1013,7 @@ func (m *Migrator) filterPending(migrations []Migration, applied map[string]bool
 }
 
 // runUp executes a migration and records it as applied.
-func (m *Migrator) runUp(ctx context.Context, migration Migration, cfg *options) error {
+func (m *Migrator) runUp(ctx context.Context, migration Migration, cfg *options) (err error) {
 	name, at := migration.Version()
 	version := formatVersion(name, at)
 
@@ -516,6 +1023,12 @@ func (m *Migrator) runUp(ctx context.Context, migration Migration, cfg *options)
 			"migration_name", name)
 	}
 
+	finish := cfg.startEvent(migration, DirectionUp)
+	defer func() { finish(err) }()
+
+	ctx, cancel := withMigrationTimeout(ctx, migration)
+	defer cancel()
+
 	if useTx(migration, "up") {
 		return m.runInTransaction(ctx, migration, true, cfg)
 	}
@@ -531,7 +1044,7 @@ func (m *Migrator) runUp(ctx context.Context, migration Migration, cfg *options)
 		return err
 	}
 
-	err := m.db.WithContext(ctx).Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version).Error
+	err = m.db.WithContext(ctx).Exec("INSERT INTO "+cfg.tableName+" (version, applied_at) VALUES ($1, $2)", version, time.Now().UTC()).Error
 	if err != nil {
 		if cfg.logger != nil {
 			cfg.logger.ErrorContext(ctx, "failed to record migration as applied",
@@ -551,7 +1064,7 @@ func (m *Migrator) runUp(ctx context.Context, migration Migration, cfg *options)
 }
 
 // runDown executes a migration rollback and removes it from applied migrations.
-func (m *Migrator) runDown(ctx context.Context, migration Migration, cfg *options) error {
+func (m *Migrator) runDown(ctx context.Context, migration Migration, cfg *options) (err error) {
 	name, at := migration.Version()
 	version := formatVersion(name, at)
 
@@ -561,6 +1074,12 @@ func (m *Migrator) runDown(ctx context.Context, migration Migration, cfg *option
 			"migration_name", name)
 	}
 
+	finish := cfg.startEvent(migration, DirectionDown)
+	defer func() { finish(err) }()
+
+	ctx, cancel := withMigrationTimeout(ctx, migration)
+	defer cancel()
+
 	if useTx(migration, "down") {
 		return m.runInTransaction(ctx, migration, false, cfg)
 	}
@@ -576,7 +1095,7 @@ func (m *Migrator) runDown(ctx context.Context, migration Migration, cfg *option
 		return err
 	}
 
-	err := m.db.WithContext(ctx).Exec("DELETE FROM schema_migrations WHERE version = $1", version).Error
+	err = m.db.WithContext(ctx).Exec("DELETE FROM "+cfg.tableName+" WHERE version = $1", version).Error
 	if err != nil {
 		if cfg.logger != nil {
 			cfg.logger.ErrorContext(ctx, "failed to remove migration from applied list",
@@ -613,9 +1132,9 @@ func (m *Migrator) runInTransaction(ctx context.Context, migration Migration, is
 
 		var err error
 		if isUp {
-			err = dbutil.DB(txCtx, nil).Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version).Error
+			err = dbutil.DB(txCtx, nil).Exec("INSERT INTO "+cfg.tableName+" (version, applied_at) VALUES ($1, $2)", version, time.Now().UTC()).Error
 		} else {
-			err = dbutil.DB(txCtx, nil).Exec("DELETE FROM schema_migrations WHERE version = $1", version).Error
+			err = dbutil.DB(txCtx, nil).Exec("DELETE FROM "+cfg.tableName+" WHERE version = $1", version).Error
 		}
 		return err
 	})
@@ -636,23 +1155,57 @@ func useTx(migration Migration, kind string) bool {
 	return true // default to using transactions
 }
 
+// withMigrationTimeout derives the context of a migration implementing MigrationWithTimeout.
+// Other migrations keep the context of the run, bounded by the TimeoutOption.
+func withMigrationTimeout(ctx context.Context, migration Migration) (context.Context, context.CancelFunc) {
+	if timeoutMigration, ok := migration.(MigrationWithTimeout); ok {
+		return context.WithTimeout(ctx, timeoutMigration.Timeout())
+	}
+	return context.WithCancel(ctx)
+}
+
+// runContext bounds a run started at start to the TimeoutOption, extended by the timeout of each of the
+// migrations it runs implementing MigrationWithTimeout so that a migration declaring a longer timeout is not cut
+// by the global one. Without migrations, it bounds the preparation of the run, e.g. acquiring the lock.
+func runContext(ctx context.Context, start time.Time, migrations []Migration, cfg *options) (context.Context, context.CancelFunc) {
+	timeout := cfg.timeout
+	for _, migration := range migrations {
+		if timeoutMigration, ok := migration.(MigrationWithTimeout); ok {
+			timeout += timeoutMigration.Timeout()
+		}
+	}
+	return context.WithDeadline(ctx, start.Add(timeout))
+}
+
 // MigrationFromSQL creates a Migration from a SQL file using the provided filesystem.
 // Expected file format: single file with -- migrate:up and -- migrate:down separators.
 // Optional transaction control: -- migrate:up transaction=false, -- migrate:down transaction=true
+// Statements are executed one by one, -- migrate:no-split executes each section in a single Exec instead.
+// It panics when the file cannot be read or parsed, see MigrationFromSQLErr.
 func MigrationFromSQL(fsys fs.FS, filename string) Migration {
+	migration, err := MigrationFromSQLErr(fsys, filename)
+	if err != nil {
+		panic(err)
+	}
+	return migration
+}
+
+// MigrationFromSQLErr is like MigrationFromSQL but returns an error when the file cannot be read or parsed,
+// e.g. to skip or report the invalid files of a directory.
+func MigrationFromSQLErr(fsys fs.FS, filename string) (Migration, error) {
 	content, err := fs.ReadFile(fsys, filename)
 	if err != nil {
-		panic(fmt.Errorf("failed to read file %s: %w", filename, err))
+		return nil, fmt.Errorf("failed to read file %s: %w", filename, err)
 	}
 
 	name, timestamp, err := parseSQLFileName(filename)
 	if err != nil {
-		panic(fmt.Errorf("invalid file name %s: %w", filename, err))
+		return nil, fmt.Errorf("invalid file name %s: %w", filename, err)
 	}
 
 	upSQL, downSQL, upUseTx, downUseTx, err := parseSQLContent(string(content))
 	if err != nil {
-		panic(fmt.Errorf("failed to parse SQL content in %s: %w", filename, err))
+		return nil, fmt.Errorf("failed to parse SQL content in %s: %w", filename, err)
 	}
 
 	return &sqlMigration{
@@ -662,7 +1215,42 @@ func MigrationFromSQL(fsys fs.FS, filename string) Migration {
 		downSQL:   downSQL,
 		upUseTx:   upUseTx,
 		downUseTx: downUseTx,
+		noSplit:   noSplitPattern.MatchString(string(content)),
+	}, nil
+}
+
+// MigrationsFromDir creates the migrations of every .sql file of dir, sorted chronologically.
+// Files not named {timestamp}_{name}.sql are skipped with a warning, files that cannot be read or parsed
+// return an error.
+func MigrationsFromDir(fsys fs.FS, dir string) ([]Migration, error) {
+	filenames, err := fs.Glob(fsys, path.Join(dir, "*.sql"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations of %s: %w", dir, err)
 	}
+
+	migrations := make([]Migration, 0, len(filenames))
+	for _, filename := range filenames {
+		if _, _, err := parseSQLFileName(filename); err != nil {
+			slog.Default().With("component", "migrate").Warn("skipping SQL file with an invalid migration name",
+				"file", filename,
+				"error", err)
+			continue
+		}
+
+		migration, err := MigrationFromSQLErr(fsys, filename)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migration)
+	}
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		_, atI := migrations[i].Version()
+		_, atJ := migrations[j].Version()
+		return atI.Before(atJ)
+	})
+
+	return migrations, nil
 }
 
 // parseSQLFileName extracts migration information from a SQL file name.
@@ -757,6 +1345,7 @@ type sqlMigration struct {
 	downSQL   string
 	upUseTx   bool
 	downUseTx bool
+	noSplit   bool // see noSplitPattern
 }
 
 // Up executes the SQL migration.
@@ -764,7 +1353,7 @@ func (s *sqlMigration) Up(ctx context.Context) error {
 	if s.upSQL == "" {
 		return fmt.Errorf("no up SQL found for migration %s", s.name)
 	}
-	return dbutil.DB(ctx, nil).Exec(s.upSQL).Error
+	return s.exec(ctx, s.upSQL)
 }
 
 // Down executes the SQL migration rollback.
@@ -772,7 +1361,22 @@ func (s *sqlMigration) Down(ctx context.Context) error {
 	if s.downSQL == "" {
 		return nil
 	}
-	return dbutil.DB(ctx, nil).Exec(s.downSQL).Error
+	return s.exec(ctx, s.downSQL)
+}
+
+// exec runs each statement of sql, or the whole sql at once with the -- migrate:no-split directive.
+func (s *sqlMigration) exec(ctx context.Context, sql string) error {
+	db := dbutil.DB(ctx, nil)
+	if s.noSplit {
+		return db.Exec(sql).Error
+	}
+
+	for _, statement := range splitSQLStatements(sql, db.Dialector.Name() == "mysql") {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Version returns the migration name and timestamp.
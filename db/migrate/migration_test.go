@@ -1,8 +1,17 @@
 package migrate
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/stretchr/testify/assert"
@@ -68,7 +77,7 @@ func TestMigratorDownWithSteps(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify all migrations were applied
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 5)
 
@@ -92,7 +101,7 @@ func TestMigratorDownWithSteps(t *testing.T) {
 		assert.True(t, migrations[4].(*testMigration).downCalled)  // fifth_migration
 
 		// Verify only 3 migrations remain applied
-		applied, err := migrator.getAppliedMigrations(ctx)
+		applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 		require.NoError(t, err)
 		assert.Len(t, applied, 3)
 	})
@@ -113,7 +122,7 @@ func TestMigratorDownAll(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify all migrations were rolled back
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 0)
 }
@@ -133,7 +142,7 @@ func TestMigratorDownSteps(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify only 2 migrations remain applied
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 2)
 }
@@ -154,7 +163,7 @@ func TestMigratorDownWithStepsExceedsAvailable(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify all migrations were rolled back
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 0)
 }
@@ -174,7 +183,7 @@ func TestMigratorDownWithZeroSteps(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify all migrations were rolled back
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 0)
 }
@@ -229,7 +238,7 @@ func TestMigratorDownNoAppliedMigrations(t *testing.T) {
 	require.NoError(t, err)
 
 	// Should complete without error
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 0)
 }
@@ -249,7 +258,762 @@ func TestMigratorDownWithGlobalTransaction(t *testing.T) {
 	require.NoError(t, err)
 
 	// Verify correct number of migrations remain
-	applied, err := migrator.getAppliedMigrations(ctx)
+	applied, err := migrator.getAppliedMigrations(ctx, defaultTableName)
 	require.NoError(t, err)
 	assert.Len(t, applied, 3)
 }
+
+func TestMigratorUpAbortsOnCancelledContext(t *testing.T) {
+	for _, global := range []bool{false, true} {
+		t.Run(fmt.Sprintf("global transaction %v", global), func(t *testing.T) {
+			db := setupTestDB(t)
+			migrator := New(db)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Cancel the context once the first migration is recorded, simulating a timeout mid-run
+			require.NoError(t, db.Callback().Raw().After("gorm:raw").Register("test:cancel", func(tx *gorm.DB) {
+				if strings.HasPrefix(tx.Statement.SQL.String(), "INSERT INTO schema_migrations") {
+					cancel()
+				}
+			}))
+
+			migrations := createTestMigrations()
+			err := migrator.Up(ctx, migrations, GlobalTransactionOption(global))
+			require.ErrorIs(t, err, context.Canceled)
+			assert.True(t, migrations[0].(*testMigration).upCalled)
+			assert.False(t, migrations[1].(*testMigration).upCalled, "migrations after the cancellation must not run")
+
+			applied, err := migrator.getAppliedMigrations(context.Background(), defaultTableName)
+			require.NoError(t, err)
+			if global {
+				assert.Empty(t, applied, "the global transaction must be rolled back")
+			} else {
+				assert.LessOrEqual(t, len(applied), 1, "only the first migration may be recorded")
+			}
+		})
+	}
+}
+
+func TestMigratorStatus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all pending", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+
+		statuses, err := migrator.Status(ctx, migrations)
+		require.NoError(t, err)
+		require.Len(t, statuses, len(migrations))
+		for _, status := range statuses {
+			assert.False(t, status.Applied, status.Name)
+			assert.Nil(t, status.AppliedAt)
+		}
+		assert.Equal(t, "20240101000000_first_migration", statuses[0].Version)
+	})
+
+	t.Run("all applied", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		statuses, err := migrator.Status(ctx, migrations)
+		require.NoError(t, err)
+		require.Len(t, statuses, len(migrations))
+		for _, status := range statuses {
+			assert.True(t, status.Applied, status.Name)
+		}
+	})
+
+	t.Run("mixed", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+		require.NoError(t, migrator.Up(ctx, migrations[:2], LoggerOption(nil)))
+
+		// migrations given out of order are still reported chronologically
+		reversed := []Migration{migrations[4], migrations[3], migrations[2], migrations[1], migrations[0]}
+		statuses, err := migrator.Status(ctx, reversed)
+		require.NoError(t, err)
+
+		var names []string
+		var applied []bool
+		for _, status := range statuses {
+			names = append(names, status.Name)
+			applied = append(applied, status.Applied)
+		}
+		assert.Equal(t, []string{"first_migration", "second_migration", "third_migration", "fourth_migration", "fifth_migration"}, names)
+		assert.Equal(t, []bool{true, true, false, false, false}, applied)
+	})
+
+	t.Run("applied at", func(t *testing.T) {
+		db := setupTestDB(t)
+		require.NoError(t, db.Exec("CREATE TABLE schema_migrations (version VARCHAR NOT NULL PRIMARY KEY, applied_at DATETIME)").Error)
+		appliedAt := time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)
+		require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)", "20240101000000_first_migration", appliedAt).Error)
+
+		statuses, err := New(db).Status(ctx, createTestMigrations())
+		require.NoError(t, err)
+		require.True(t, statuses[0].Applied)
+		require.NotNil(t, statuses[0].AppliedAt)
+		assert.True(t, appliedAt.Equal(*statuses[0].AppliedAt))
+		assert.Nil(t, statuses[1].AppliedAt)
+	})
+}
+
+// appliedVersions returns the versions of applied migrations.
+func appliedVersions(applied []AppliedMigration) []string {
+	versions := make([]string, 0, len(applied))
+	for _, migration := range applied {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+func TestMigratorRecordsAppliedAt(t *testing.T) {
+	ctx := context.Background()
+
+	for _, global := range []bool{false, true} {
+		t.Run(fmt.Sprintf("global transaction %v", global), func(t *testing.T) {
+			migrator := New(setupTestDB(t))
+			migrations := createTestMigrations()
+
+			before := time.Now().UTC().Add(-time.Second)
+			require.NoError(t, migrator.Up(ctx, migrations, GlobalTransactionOption(global), LoggerOption(nil)))
+			after := time.Now().UTC().Add(time.Second)
+
+			statuses, err := migrator.Status(ctx, migrations)
+			require.NoError(t, err)
+			for _, status := range statuses {
+				require.NotNil(t, status.AppliedAt, status.Name)
+				assert.True(t, status.AppliedAt.After(before) && status.AppliedAt.Before(after),
+					"%s applied at %s", status.Name, status.AppliedAt)
+			}
+
+			applied, err := migrator.Applied(ctx)
+			require.NoError(t, err)
+			require.Len(t, applied, len(migrations))
+			for i, migration := range applied {
+				assert.Equal(t, statuses[i].Version, migration.Version)
+				require.NotNil(t, migration.AppliedAt, migration.Version)
+				assert.True(t, migration.AppliedAt.Equal(*statuses[i].AppliedAt),
+					"%s applied at %s", migration.Version, migration.AppliedAt)
+			}
+		})
+	}
+}
+
+func TestMigratorUpgradesTableWithoutAppliedAt(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	require.NoError(t, db.Exec("CREATE TABLE schema_migrations (version VARCHAR NOT NULL PRIMARY KEY)").Error)
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations (version) VALUES (?)", "20240101000000_first_migration").Error)
+
+	migrator := New(db)
+	migrations := createTestMigrations()
+	require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+	assert.True(t, db.Migrator().HasColumn("schema_migrations", "applied_at"))
+	assert.False(t, migrations[0].(*testMigration).upCalled, "the already applied migration must be kept")
+
+	statuses, err := migrator.Status(ctx, migrations)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(migrations))
+	assert.True(t, statuses[0].Applied)
+	assert.Nil(t, statuses[0].AppliedAt, "migrations applied before the upgrade have no applied_at")
+	for _, status := range statuses[1:] {
+		assert.True(t, status.Applied, status.Name)
+		assert.NotNil(t, status.AppliedAt, status.Name)
+	}
+
+	applied, err := migrator.Applied(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, len(migrations))
+	assert.Equal(t, "20240101000000_first_migration", applied[0].Version)
+	assert.Nil(t, applied[0].AppliedAt, "migrations applied before the upgrade have no applied_at")
+	assert.NotNil(t, applied[1].AppliedAt)
+}
+
+// concurrencyMigration records how many migrations run at the same time and how many times it ran.
+type concurrencyMigration struct {
+	testMigration
+	running, maxRunning, runs *atomic.Int32
+}
+
+func (c *concurrencyMigration) Up(ctx context.Context) error {
+	n := c.running.Add(1)
+	defer c.running.Add(-1)
+	for {
+		current := c.maxRunning.Load()
+		if n <= current || c.maxRunning.CompareAndSwap(current, n) {
+			break
+		}
+	}
+	c.runs.Add(1)
+	time.Sleep(20 * time.Millisecond)
+	return nil
+}
+
+func TestMigratorLockSerializesConcurrentRuns(t *testing.T) {
+	dsn := filepath.Join(t.TempDir(), "migrations.db")
+	var running, maxRunning, runs atomic.Int32
+	migrationsFor := func() []Migration {
+		var migrations []Migration
+		for _, m := range createTestMigrations() {
+			migrations = append(migrations, &concurrencyMigration{
+				testMigration: *m.(*testMigration),
+				running:       &running,
+				maxRunning:    &maxRunning,
+				runs:          &runs,
+			})
+		}
+		return migrations
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = New(db).Up(context.Background(), migrationsFor(), LoggerOption(nil))
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), maxRunning.Load(), "migrations must not run concurrently")
+	assert.Equal(t, int32(5), runs.Load(), "each migration must run once")
+}
+
+func TestMigratorLockTimeout(t *testing.T) {
+	db := setupTestDB(t)
+	migrator := New(db)
+
+	release, err := migrator.acquireLock(context.Background(), time.Second)
+	require.NoError(t, err)
+
+	err = migrator.Up(context.Background(), createTestMigrations(), LockTimeoutOption(100*time.Millisecond), LoggerOption(nil))
+	require.ErrorIs(t, err, ErrLockTimeout)
+
+	require.NoError(t, release())
+	require.NoError(t, migrator.Up(context.Background(), createTestMigrations(), LoggerOption(nil)))
+
+	t.Run("disabled", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		release, err := migrator.acquireLock(context.Background(), time.Second)
+		require.NoError(t, err)
+		defer release()
+
+		require.NoError(t, migrator.Up(context.Background(), createTestMigrations(), LockOption(false), LoggerOption(nil)))
+	})
+}
+
+func TestMigratorUpTo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("full version", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+
+		require.NoError(t, migrator.UpTo(ctx, migrations, "20240101020000_third_migration", LoggerOption(nil)))
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"20240101000000_first_migration",
+			"20240101010000_second_migration",
+			"20240101020000_third_migration",
+		}, appliedVersions(applied))
+		assert.False(t, migrations[3].(*testMigration).upCalled)
+	})
+
+	t.Run("timestamp", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		require.NoError(t, migrator.UpTo(ctx, createTestMigrations(), "20240101000000", LoggerOption(nil)))
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"20240101000000_first_migration"}, appliedVersions(applied))
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		err := migrator.UpTo(ctx, createTestMigrations(), "20990101000000_missing", LoggerOption(nil))
+		require.ErrorIs(t, err, ErrUnknownVersion)
+		assert.Contains(t, err.Error(), "20990101000000_missing")
+	})
+}
+
+func TestMigratorDownTo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps the target applied", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		require.NoError(t, migrator.DownTo(ctx, migrations, "20240101010000_second_migration", LoggerOption(nil)))
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"20240101000000_first_migration", "20240101010000_second_migration"}, appliedVersions(applied))
+		assert.False(t, migrations[1].(*testMigration).downCalled)
+		assert.True(t, migrations[2].(*testMigration).downCalled)
+		assert.True(t, migrations[4].(*testMigration).downCalled)
+	})
+
+	t.Run("latest version is a no-op", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		require.NoError(t, migrator.DownTo(ctx, migrations, "20240101040000_fifth_migration", LoggerOption(nil)))
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, 5)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		migrations := createTestMigrations()
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		err := migrator.DownTo(ctx, migrations, "nope", LoggerOption(nil))
+		require.ErrorIs(t, err, ErrUnknownVersion)
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, 5, "nothing must be rolled back")
+	})
+}
+
+func TestMigratorRedo(t *testing.T) {
+	ctx := context.Background()
+
+	fsys := fstest.MapFS{
+		"20240101000000_create_settings.sql": {Data: []byte(`-- migrate:up
+CREATE TABLE settings (name VARCHAR NOT NULL PRIMARY KEY, value VARCHAR NOT NULL);
+
+-- migrate:down
+DROP TABLE settings;
+`)},
+		"20240102000000_seed_settings.sql": {Data: []byte(`-- migrate:up
+INSERT INTO settings (name, value) VALUES ('theme', 'light');
+
+-- migrate:down
+DELETE FROM settings WHERE name = 'theme';
+`)},
+	}
+	migrations := []Migration{
+		MigrationFromSQL(fsys, "20240101000000_create_settings.sql"),
+		MigrationFromSQL(fsys, "20240102000000_seed_settings.sql"),
+	}
+
+	for _, global := range []bool{false, true} {
+		t.Run(fmt.Sprintf("global transaction %v", global), func(t *testing.T) {
+			db := setupTestDB(t)
+			migrator := New(db)
+			require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+			require.NoError(t, db.Exec("UPDATE settings SET value = 'dark' WHERE name = 'theme'").Error)
+
+			require.NoError(t, migrator.Redo(ctx, migrations, GlobalTransactionOption(global), LoggerOption(nil)))
+
+			var value string
+			require.NoError(t, db.Raw("SELECT value FROM settings WHERE name = 'theme'").Scan(&value).Error)
+			assert.Equal(t, "light", value, "the last migration must be applied again")
+
+			applied, err := migrator.Applied(ctx)
+			require.NoError(t, err)
+			assert.Len(t, applied, 2)
+		})
+	}
+
+	t.Run("no applied migrations", func(t *testing.T) {
+		migrator := New(setupTestDB(t))
+		err := migrator.Redo(ctx, migrations, LoggerOption(nil))
+		require.ErrorIs(t, err, ErrNoAppliedMigrations)
+	})
+
+	t.Run("holds the lock across down and up", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		// a lock released between the down and the up would let another migrator run in between
+		require.NoError(t, db.Exec("CREATE TABLE lock_count (n INTEGER)").Error)
+		require.NoError(t, db.Exec(`CREATE TRIGGER count_locks AFTER INSERT ON migration_lock
+			BEGIN INSERT INTO lock_count (n) VALUES (1); END`).Error)
+
+		require.NoError(t, migrator.Redo(ctx, migrations, LoggerOption(nil)))
+
+		var count int64
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM lock_count").Scan(&count).Error)
+		assert.Equal(t, int64(1), count, "the lock must be acquired once")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		require.NoError(t, migrator.Redo(ctx, migrations, DryRunOption(true), LoggerOption(logger)))
+
+		assert.Contains(t, logs.String(), "dry run: migration would be rolled back")
+		assert.Contains(t, logs.String(), "DELETE FROM settings WHERE name = 'theme';")
+		assert.Contains(t, logs.String(), "dry run: migration would be applied")
+		assert.Contains(t, logs.String(), "INSERT INTO settings (name, value) VALUES ('theme', 'light');")
+
+		var count int64
+		require.NoError(t, db.Raw("SELECT COUNT(*) FROM settings").Scan(&count).Error)
+		assert.Equal(t, int64(1), count, "nothing must be rolled back")
+	})
+}
+
+func TestMigrationFromSQLErr(t *testing.T) {
+	fsys := fstest.MapFS{
+		"20240101000000_valid.sql":   {Data: []byte("-- migrate:up\nCREATE TABLE a (id INT);\n-- migrate:down\nDROP TABLE a;\n")},
+		"not_a_timestamp_name.sql":   {Data: []byte("-- migrate:up\nCREATE TABLE a (id INT);\n")},
+		"20240101000000_no_up.sql":   {Data: []byte("CREATE TABLE a (id INT);\n-- migrate:down\nDROP TABLE a;\n")},
+		"20240101000000_invalid.txt": {Data: []byte("-- migrate:up\nSELECT 1;\n")},
+	}
+
+	migration, err := MigrationFromSQLErr(fsys, "20240101000000_valid.sql")
+	require.NoError(t, err)
+	name, at := migration.Version()
+	assert.Equal(t, "valid", name)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), at)
+
+	errorCases := map[string]string{
+		"20240101000000_missing.sql": "failed to read file",
+		"not_a_timestamp_name.sql":   "invalid file name",
+		"20240101000000_invalid.txt": "invalid file name",
+		"20240101000000_no_up.sql":   "no -- migrate:up section found",
+	}
+	for filename, message := range errorCases {
+		t.Run(filename, func(t *testing.T) {
+			var migration Migration
+			var err error
+			require.NotPanics(t, func() {
+				migration, err = MigrationFromSQLErr(fsys, filename)
+			})
+			require.Error(t, err)
+			assert.Nil(t, migration)
+			assert.Contains(t, err.Error(), message)
+		})
+	}
+
+	t.Run("MigrationFromSQL panics", func(t *testing.T) {
+		assert.Panics(t, func() {
+			MigrationFromSQL(fsys, "20240101000000_missing.sql")
+		})
+	})
+}
+
+func TestMigrationsFromDir(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20240103000000_add_index.sql":    {Data: []byte("-- migrate:up\nCREATE INDEX idx ON users (name);\n")},
+		"migrations/20240101000000_create_users.sql": {Data: []byte("-- migrate:up\nCREATE TABLE users (name VARCHAR);\n")},
+		"migrations/20240102000000_add_email.sql":    {Data: []byte("-- migrate:up\nALTER TABLE users ADD COLUMN email VARCHAR;\n")},
+		"migrations/notes.sql":                       {Data: []byte("-- migrate:up\nSELECT 1;\n")},
+		"migrations/README.md":                       {Data: []byte("# Migrations\n")},
+		"migrations/nested/20240104000000_other.sql": {Data: []byte("-- migrate:up\nSELECT 1;\n")},
+	}
+
+	migrations, err := MigrationsFromDir(fsys, "migrations")
+	require.NoError(t, err)
+
+	var names []string
+	for _, migration := range migrations {
+		name, _ := migration.Version()
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"create_users", "add_email", "add_index"}, names)
+
+	t.Run("invalid content", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/20240101000000_create_users.sql": {Data: []byte("CREATE TABLE users (name VARCHAR);\n")},
+		}
+		_, err := MigrationsFromDir(fsys, "migrations")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "20240101000000_create_users.sql")
+	})
+}
+
+func TestMigratorTableName(t *testing.T) {
+	ctx := context.Background()
+	db := setupTestDB(t)
+	migrator := New(db)
+	migrations := createTestMigrations()
+	tableName := TableNameOption("billing_schema_migrations")
+
+	require.NoError(t, migrator.Up(ctx, migrations, tableName, LoggerOption(nil)))
+	assert.True(t, db.Migrator().HasTable("billing_schema_migrations"))
+	assert.False(t, db.Migrator().HasTable("schema_migrations"), "the default table must not be created")
+
+	applied, err := migrator.Applied(ctx, tableName)
+	require.NoError(t, err)
+	assert.Len(t, applied, 5)
+
+	statuses, err := migrator.Status(ctx, migrations)
+	require.NoError(t, err)
+	assert.False(t, statuses[0].Applied, "the default table has no applied migrations")
+
+	require.NoError(t, migrator.DownSteps(ctx, migrations, 2, tableName, LoggerOption(nil)))
+	statuses, err = migrator.Status(ctx, migrations, tableName)
+	require.NoError(t, err)
+	var appliedCount int
+	for _, status := range statuses {
+		if status.Applied {
+			appliedCount++
+		}
+	}
+	assert.Equal(t, 3, appliedCount)
+
+	require.NoError(t, migrator.Up(ctx, migrations, tableName, GlobalTransactionOption(true), LoggerOption(nil)))
+	applied, err = migrator.Applied(ctx, tableName)
+	require.NoError(t, err)
+	assert.Len(t, applied, 5)
+
+	for _, name := range []string{"", "users; DROP TABLE users", "1table", "schema.table.extra", `"quoted"`} {
+		t.Run(fmt.Sprintf("invalid %q", name), func(t *testing.T) {
+			err := migrator.Up(ctx, migrations, TableNameOption(name), LoggerOption(nil))
+			require.ErrorIs(t, err, ErrInvalidTableName)
+			_, err = migrator.Applied(ctx, TableNameOption(name))
+			require.ErrorIs(t, err, ErrInvalidTableName)
+		})
+	}
+}
+
+func TestMigratorDryRun(t *testing.T) {
+	ctx := context.Background()
+	fsys := fstest.MapFS{
+		"20240101000000_create_items.sql": {Data: []byte("-- migrate:up\nCREATE TABLE items (name VARCHAR);\n\n-- migrate:down\nDROP TABLE items;\n")},
+	}
+	sqlMig := MigrationFromSQL(fsys, "20240101000000_create_items.sql")
+	codeMig := &testMigration{name: "code_migration", timestamp: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}
+	migrations := []Migration{sqlMig, codeMig}
+
+	t.Run("up", func(t *testing.T) {
+		db := setupTestDB(t)
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+		require.NoError(t, New(db).Up(ctx, migrations, DryRunOption(true), LoggerOption(logger)))
+
+		assert.False(t, db.Migrator().HasTable("schema_migrations"), "the migrations table must not be created")
+		assert.False(t, db.Migrator().HasTable("items"), "the SQL must not be executed")
+		assert.False(t, codeMig.upCalled, "code migrations must not be executed")
+		assert.Contains(t, logs.String(), "CREATE TABLE items (name VARCHAR);")
+		assert.Contains(t, logs.String(), "20240102000000_code_migration")
+	})
+
+	t.Run("down", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		require.NoError(t, migrator.Up(ctx, migrations, LoggerOption(nil)))
+
+		var logs bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&logs, nil))
+		require.NoError(t, migrator.DownAll(ctx, migrations, DryRunOption(true), LoggerOption(logger)))
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, 2, "no row must be removed from schema_migrations")
+		assert.True(t, db.Migrator().HasTable("items"))
+		assert.False(t, codeMig.downCalled)
+		assert.Contains(t, logs.String(), "DROP TABLE items;")
+	})
+}
+
+// slowMigration waits for delay, or until its context is done, and declares its own timeout.
+type slowMigration struct {
+	testMigration
+	delay, timeout time.Duration
+}
+
+func (s *slowMigration) Up(ctx context.Context) error {
+	select {
+	case <-time.After(s.delay):
+		s.upCalled = true
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *slowMigration) Timeout() time.Duration {
+	return s.timeout
+}
+
+// runBoundMigration waits like slowMigration without declaring a timeout, only the run bounds it.
+type runBoundMigration struct {
+	testMigration
+	delay time.Duration
+}
+
+func (r *runBoundMigration) Up(ctx context.Context) error {
+	select {
+	case <-time.After(r.delay):
+		r.upCalled = true
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestMigratorMigrationTimeout(t *testing.T) {
+	ctx := context.Background()
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("longer than the global timeout", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		slow := &slowMigration{
+			testMigration: testMigration{name: "slow_migration", timestamp: baseTime},
+			delay:         150 * time.Millisecond,
+			timeout:       time.Second,
+		}
+		next := &testMigration{name: "next_migration", timestamp: baseTime.Add(time.Hour)}
+
+		err := migrator.Up(ctx, []Migration{slow, next}, TimeoutOption(50*time.Millisecond), LoggerOption(nil))
+		require.NoError(t, err)
+		assert.True(t, slow.upCalled)
+		assert.True(t, next.upCalled)
+
+		applied, err := migrator.Applied(ctx)
+		require.NoError(t, err)
+		assert.Len(t, applied, 2)
+	})
+
+	t.Run("exceeded", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		slow := &slowMigration{
+			testMigration: testMigration{name: "slow_migration", timestamp: baseTime},
+			delay:         time.Second,
+			timeout:       50 * time.Millisecond,
+		}
+
+		err := migrator.Up(ctx, []Migration{slow}, TimeoutOption(5*time.Second), LoggerOption(nil))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, slow.upCalled)
+	})
+
+	t.Run("applied migrations do not extend the run", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		long := &slowMigration{
+			testMigration: testMigration{name: "long_migration", timestamp: baseTime},
+			timeout:       time.Hour,
+		}
+		require.NoError(t, migrator.Up(ctx, []Migration{long}, LoggerOption(nil)))
+
+		slow := &runBoundMigration{
+			testMigration: testMigration{name: "slow_migration", timestamp: baseTime.Add(time.Hour)},
+			delay:         time.Second,
+		}
+		err := migrator.Up(ctx, []Migration{long, slow}, TimeoutOption(50*time.Millisecond), LoggerOption(nil))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.False(t, slow.upCalled)
+	})
+
+	t.Run("global transaction", func(t *testing.T) {
+		db := setupTestDB(t)
+		migrator := New(db)
+		slow := &slowMigration{
+			testMigration: testMigration{name: "slow_migration", timestamp: baseTime},
+			delay:         150 * time.Millisecond,
+			timeout:       time.Second,
+		}
+
+		err := migrator.Up(ctx, []Migration{slow}, TimeoutOption(50*time.Millisecond), GlobalTransactionOption(true), LoggerOption(nil))
+		require.NoError(t, err)
+		assert.True(t, slow.upCalled)
+	})
+}
+
+// failingMigration fails to apply with err.
+type failingMigration struct {
+	testMigration
+	err error
+}
+
+func (f *failingMigration) Up(ctx context.Context) error {
+	return f.err
+}
+
+func TestMigratorHook(t *testing.T) {
+	ctx := context.Background()
+	baseTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type step struct {
+		version   string
+		direction Direction
+		phase     Phase
+	}
+	record := func(events *[]MigrationEvent) Option {
+		return HookOption(func(evt MigrationEvent) { *events = append(*events, evt) })
+	}
+	steps := func(events []MigrationEvent) []step {
+		var got []step
+		for _, evt := range events {
+			got = append(got, step{evt.Version, evt.Direction, evt.Phase})
+		}
+		return got
+	}
+
+	for _, global := range []bool{false, true} {
+		t.Run(fmt.Sprintf("success global=%t", global), func(t *testing.T) {
+			migrator := New(setupTestDB(t))
+			migrations := createTestMigrations()[:2]
+
+			var events []MigrationEvent
+			require.NoError(t, migrator.Up(ctx, migrations, GlobalTransactionOption(global), record(&events), LoggerOption(nil)))
+			require.NoError(t, migrator.DownSteps(ctx, migrations, 1, GlobalTransactionOption(global), record(&events), LoggerOption(nil)))
+
+			assert.Equal(t, []step{
+				{"20240101000000_first_migration", DirectionUp, PhaseStart},
+				{"20240101000000_first_migration", DirectionUp, PhaseSuccess},
+				{"20240101010000_second_migration", DirectionUp, PhaseStart},
+				{"20240101010000_second_migration", DirectionUp, PhaseSuccess},
+				{"20240101010000_second_migration", DirectionDown, PhaseStart},
+				{"20240101010000_second_migration", DirectionDown, PhaseSuccess},
+			}, steps(events))
+			for _, evt := range events {
+				assert.Equal(t, strings.SplitN(evt.Version, "_", 2)[1], evt.Name)
+				assert.NoError(t, evt.Err)
+			}
+		})
+
+		t.Run(fmt.Sprintf("failure global=%t", global), func(t *testing.T) {
+			migrator := New(setupTestDB(t))
+			errBroken := errors.New("broken migration")
+			migrations := []Migration{
+				&testMigration{name: "first_migration", timestamp: baseTime},
+				&failingMigration{testMigration: testMigration{name: "broken_migration", timestamp: baseTime.Add(time.Hour)}, err: errBroken},
+				&testMigration{name: "third_migration", timestamp: baseTime.Add(2 * time.Hour)},
+			}
+
+			var events []MigrationEvent
+			err := migrator.Up(ctx, migrations, GlobalTransactionOption(global), record(&events), LoggerOption(nil))
+			require.ErrorIs(t, err, errBroken)
+
+			assert.Equal(t, []step{
+				{"20240101000000_first_migration", DirectionUp, PhaseStart},
+				{"20240101000000_first_migration", DirectionUp, PhaseSuccess},
+				{"20240101010000_broken_migration", DirectionUp, PhaseStart},
+				{"20240101010000_broken_migration", DirectionUp, PhaseError},
+			}, steps(events))
+			assert.ErrorIs(t, events[3].Err, errBroken)
+			assert.Zero(t, events[2].Elapsed)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package migrate
+
+import (
+	"regexp"
+	"strings"
+)
+
+// noSplitPattern matches the -- migrate:no-split directive, executing each section of a SQL file in a single Exec.
+var noSplitPattern = regexp.MustCompile(`(?im)^\s*--\s*migrate:no-split\s*$`)
+
+// dollarQuotePattern matches the opening of a Postgres dollar-quoted string: $$ or $tag$.
+var dollarQuotePattern = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// splitSQLStatements splits sql on the ; terminating its statements, so drivers accepting a single statement
+// per Exec can run a migration section. Terminators inside string literals, quoted identifiers, comments and
+// dollar-quoted bodies ($$ ... $$, $fn$ ... $fn$) are kept. Statements made only of comments are dropped.
+// A backslash escapes the next character in Postgres E'...' strings, and in every string with backslashEscapes
+// as in MySQL ('it\'s').
+func splitSQLStatements(sql string, backslashEscapes bool) []string {
+	var statements []string
+	var current strings.Builder
+	hasCode := false
+
+	flush := func() {
+		if hasCode {
+			statements = append(statements, strings.TrimSpace(current.String()))
+		}
+		current.Reset()
+		hasCode = false
+	}
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		switch {
+		case c == ';':
+			flush()
+			continue
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end == -1 {
+				end = len(sql) - i
+			}
+			current.WriteString(sql[i : i+end])
+			i += end - 1
+			continue
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end == -1 {
+				end = len(sql) - i
+			} else {
+				end += 4
+			}
+			current.WriteString(sql[i : i+end])
+			i += end - 1
+			continue
+		case c == '\'' || c == '"' || c == '`':
+			// '' inside a string literal closes and reopens it, which keeps the whole literal together
+			escapes := c != '`' && backslashEscapes || c == '\'' && isEscapeStringPrefix(sql, i)
+			end := quoteEnd(sql, i, escapes)
+			current.WriteString(sql[i:end])
+			i = end - 1
+		case c == '$':
+			tag := dollarQuotePattern.FindString(sql[i:])
+			if tag == "" {
+				current.WriteByte(c)
+				break
+			}
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end == -1 {
+				end = len(sql) - i
+			} else {
+				end += 2 * len(tag)
+			}
+			current.WriteString(sql[i : i+end])
+			i += end - 1
+		default:
+			current.WriteByte(c)
+			if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+				continue
+			}
+		}
+		hasCode = true
+	}
+	flush()
+
+	return statements
+}
+
+// quoteEnd returns the index following the quote closing the text quoted at start, or len(sql) when it is not
+// closed. With escapes, a quote preceded by a backslash doesn't close it.
+func quoteEnd(sql string, start int, escapes bool) int {
+	quote := sql[start]
+	for i := start + 1; i < len(sql); i++ {
+		switch sql[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+	}
+	return len(sql)
+}
+
+// isEscapeStringPrefix reports whether the quote at i opens a Postgres escape string, E'...'.
+func isEscapeStringPrefix(sql string, i int) bool {
+	if i == 0 || (sql[i-1] != 'E' && sql[i-1] != 'e') {
+		return false
+	}
+	return i == 1 || !isIdentifierByte(sql[i-2])
+}
+
+func isIdentifierByte(c byte) bool {
+	return c == '_' || c == '$' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,143 @@
+package migrate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSplitSQLStatements(t *testing.T) {
+	tests := []struct {
+		name             string
+		sql              string
+		backslashEscapes bool
+		expected         []string
+	}{
+		{
+			name: "multiple statements",
+			sql: `CREATE TABLE users (id INTEGER PRIMARY KEY);
+CREATE INDEX idx_users_id ON users (id);
+
+INSERT INTO users (id) VALUES (1)`,
+			expected: []string{
+				"CREATE TABLE users (id INTEGER PRIMARY KEY)",
+				"CREATE INDEX idx_users_id ON users (id)",
+				"INSERT INTO users (id) VALUES (1)",
+			},
+		},
+		{
+			name: "string literals",
+			sql:  `INSERT INTO notes (body) VALUES ('a; b'), ('it''s; fine'); SELECT "odd;column" FROM notes;`,
+			expected: []string{
+				`INSERT INTO notes (body) VALUES ('a; b'), ('it''s; fine')`,
+				`SELECT "odd;column" FROM notes`,
+			},
+		},
+		{
+			name: "comments",
+			sql: `-- first; statement
+CREATE TABLE a (id INT); /* not; a terminator */
+-- trailing comment only;`,
+			expected: []string{
+				"-- first; statement\nCREATE TABLE a (id INT)",
+			},
+		},
+		{
+			name: "postgres function body",
+			sql: `CREATE FUNCTION touch() RETURNS trigger AS $$
+BEGIN
+	NEW.updated_at = now();
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+CREATE FUNCTION noop() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql;
+SELECT $1;`,
+			expected: []string{
+				"CREATE FUNCTION touch() RETURNS trigger AS $$\nBEGIN\n\tNEW.updated_at = now();\n\tRETURN NEW;\nEND;\n$$ LANGUAGE plpgsql",
+				"CREATE FUNCTION noop() RETURNS void AS $body$ BEGIN PERFORM 1; END; $body$ LANGUAGE plpgsql",
+				"SELECT $1",
+			},
+		},
+		{
+			name:             "mysql backslash escapes",
+			sql:              `INSERT INTO notes (body) VALUES ('it\'s; ok'), ("say \"hi\"; bye"), ('c:\\'); SELECT 1;`,
+			backslashEscapes: true,
+			expected: []string{
+				`INSERT INTO notes (body) VALUES ('it\'s; ok'), ("say \"hi\"; bye"), ('c:\\')`,
+				"SELECT 1",
+			},
+		},
+		{
+			name: "postgres escape strings",
+			sql:  `INSERT INTO notes (body) VALUES (E'it\'s; ok'), (e'tab\t; \\'); SELECT 1;`,
+			expected: []string{
+				`INSERT INTO notes (body) VALUES (E'it\'s; ok'), (e'tab\t; \\')`,
+				"SELECT 1",
+			},
+		},
+		{
+			name: "postgres standard strings",
+			sql:  `INSERT INTO paths (dir) VALUES ('c:\'); SELECT 'a; b';`,
+			expected: []string{
+				`INSERT INTO paths (dir) VALUES ('c:\')`,
+				`SELECT 'a; b'`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, splitSQLStatements(tt.sql, tt.backslashEscapes))
+		})
+	}
+}
+
+func TestSQLMigrationSplitsStatements(t *testing.T) {
+	ctx := context.Background()
+	content := `-- migrate:up
+CREATE TABLE items (name VARCHAR NOT NULL);
+INSERT INTO items (name) VALUES ('a;b');
+INSERT INTO items (name) VALUES ('c');
+
+-- migrate:down
+DROP TABLE items;
+`
+
+	countInserts := func(t *testing.T, db *gorm.DB) *int {
+		count := new(int)
+		require.NoError(t, db.Callback().Raw().Before("gorm:raw").Register("test:count", func(tx *gorm.DB) {
+			if strings.Contains(tx.Statement.SQL.String(), "INSERT INTO items") {
+				*count++
+			}
+		}))
+		return count
+	}
+
+	t.Run("split", func(t *testing.T) {
+		db := setupTestDB(t)
+		execs := countInserts(t, db)
+		migration := MigrationFromSQL(fstest.MapFS{"20240101000000_items.sql": {Data: []byte(content)}}, "20240101000000_items.sql")
+
+		require.NoError(t, New(db).Up(ctx, []Migration{migration}, LoggerOption(nil)))
+
+		var names []string
+		require.NoError(t, db.Raw("SELECT name FROM items ORDER BY name").Scan(&names).Error)
+		assert.Equal(t, []string{"a;b", "c"}, names)
+		assert.Equal(t, 2, *execs, "each insert must be executed on its own")
+	})
+
+	t.Run("no-split", func(t *testing.T) {
+		db := setupTestDB(t)
+		execs := countInserts(t, db)
+		noSplit := "-- migrate:no-split\n" + content
+		migration := MigrationFromSQL(fstest.MapFS{"20240101000000_items.sql": {Data: []byte(noSplit)}}, "20240101000000_items.sql")
+
+		require.NoError(t, New(db).Up(ctx, []Migration{migration}, LoggerOption(nil)))
+		assert.Equal(t, 1, *execs, "the up section must be executed in a single Exec")
+	})
+}
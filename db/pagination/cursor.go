@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"database/sql/driver"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 // CursorParams represents cursor-based pagination parameters.
@@ -18,6 +20,7 @@ type CursorParams struct {
 	Cursor    string `json:"cursor" query:"cursor"`       // Base64 encoded cursor for pagination position
 	PageSize  int    `json:"page_size" query:"page_size"` // Number of items per page
 	Direction string `json:"direction" query:"direction"` // "next" or "prev" for navigation direction
+	Order     string `json:"order" query:"order"`         // "asc" or "desc" for the sort order of the order field
 }
 
 // CursorPagination represents pagination metadata for cursor-based pagination.
@@ -28,30 +31,182 @@ type CursorPagination struct {
 	HasNext    bool   `json:"has_next"`              // Whether there is a next page
 	HasPrev    bool   `json:"has_prev"`              // Whether there is a previous page
 	PageSize   int    `json:"page_size"`             // Items per page
+	Total      int64  `json:"total,omitempty"`       // Total number of items, only counted with TotalOption
+}
+
+// LinkHeader returns the value of an RFC 5988 Link header pointing at the first, previous and next pages.
+// The cursor, direction and page_size query parameters are set on baseURL, its other query parameters
+// (filters, order, ...) are kept. The prev and next links are omitted when there is no such page.
+// An empty string is returned when baseURL can't be parsed.
+//
+// Example:
+//
+//	w.Header().Set("Link", result.LinkHeader("https://api.example.com/users?order=desc"))
+func (p *CursorPagination) LinkHeader(baseURL string) string {
+	pageSize := strconv.Itoa(p.PageSize)
+
+	links := []paginationLink{{rel: "first", params: map[string]string{"cursor": "", "direction": "", "page_size": pageSize}}}
+	if p.HasPrev && p.PrevCursor != "" {
+		links = append(links, paginationLink{rel: "prev", params: map[string]string{"cursor": p.PrevCursor, "direction": "prev", "page_size": pageSize}})
+	}
+	if p.HasNext && p.NextCursor != "" {
+		links = append(links, paginationLink{rel: "next", params: map[string]string{"cursor": p.NextCursor, "direction": "next", "page_size": pageSize}})
+	}
+
+	return linkHeader(baseURL, links)
+}
+
+// CursorOption configures cursor-based pagination.
+type CursorOption func(*cursorOptions)
+
+// cursorOptions holds cursor pagination configuration.
+type cursorOptions struct {
+	total bool
+	nulls NullsPlacement
+}
+
+// newCursorOptions applies opts over the defaults.
+func newCursorOptions(opts []CursorOption) *cursorOptions {
+	cfg := &cursorOptions{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// NullsPlacement is where the NULL values of the order field are placed, see NullsOption.
+type NullsPlacement string
+
+const (
+	NullsFirst NullsPlacement = "first"
+	NullsLast  NullsPlacement = "last"
+)
+
+// NullsOption enables the NULL handling of PaginateCursor for a nullable order field: NULLs are placed
+// first or last of the sort order on every database, and cursors pointing at a NULL value keep paginating.
+// Without it, NULLs are placed where the database puts them and the comparison with the cursor skips them.
+//
+// Rows sharing an order field value, NULL included, are ordered by the primary key of the model, which the
+// cursors hold too: a page ending in the middle of them continues with the next one. The model must have
+// a primary key.
+func NullsOption(placement NullsPlacement) CursorOption {
+	return func(c *cursorOptions) {
+		c.nulls = placement
+	}
+}
+
+// TotalOption configures whether the items matching the query are counted to fill CursorPagination.Total.
+// It is disabled by default: counting runs an extra COUNT(*) query scanning every matching row, which
+// cursor pagination is meant to avoid on large datasets.
+func TotalOption(enabled bool) CursorOption {
+	return func(c *cursorOptions) {
+		c.total = enabled
+	}
+}
+
+// countTotal counts the rows matched by db, before cursor filtering and limiting, when TotalOption is enabled.
+func countTotal[T any](db *gorm.DB, cfg *cursorOptions) (int64, error) {
+	if !cfg.total {
+		return 0, nil
+	}
+
+	var total int64
+	if err := db.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error; err != nil {
+		return 0, fmt.Errorf("failed to count records: %w", err)
+	}
+	return total, nil
 }
 
 // CursorData represents the internal structure of a cursor.
 // This is encoded as base64 JSON for transmission.
 type CursorData struct {
-	ID    interface{} `json:"id"`    // The value of the ordering field
-	Field string      `json:"field"` // The field name used for ordering
+	ID    interface{} `json:"id"`             // The value of the ordering field
+	Field string      `json:"field"`          // The field name used for ordering
+	Null  bool        `json:"null,omitempty"` // Whether the value of the ordering field is NULL
+	Key   interface{} `json:"key,omitempty"`  // The primary key of the item, set with NullsOption
+}
+
+// MultiCursorData represents the internal structure of a cursor produced by PaginateCursorMulti.
+// It holds the value of every ordering column of the item the cursor points at.
+type MultiCursorData struct {
+	Columns []CursorColumn `json:"columns"` // The ordering columns, in ordering priority
+}
+
+// CursorColumn is an ordering column of a MultiCursorData and its value.
+type CursorColumn struct {
+	Field string      `json:"field"` // The column name
+	Value interface{} `json:"value"` // The value of the column
+}
+
+// CursorConfig holds the page size limits applied to cursor pagination parameters.
+type CursorConfig struct {
+	DefaultPageSize int // Page size used when none or an invalid one is given, 20 when zero
+	MaxPageSize     int // Largest page size accepted, 100 when zero
+}
+
+// DefaultCursorConfig is the configuration used by NewCursorParams and ParseCursorParams.
+var DefaultCursorConfig = CursorConfig{DefaultPageSize: 20, MaxPageSize: 100}
+
+// withDefaults returns the config with its zero fields set to the ones of DefaultCursorConfig.
+func (c CursorConfig) withDefaults() CursorConfig {
+	if c.DefaultPageSize <= 0 {
+		c.DefaultPageSize = DefaultCursorConfig.DefaultPageSize
+	}
+	if c.MaxPageSize <= 0 {
+		c.MaxPageSize = DefaultCursorConfig.MaxPageSize
+	}
+	return c
 }
 
 // NewCursorParams creates new cursor pagination parameters with validation and defaults.
-// Invalid directions default to "next", and invalid page sizes default to 20.
+// Invalid directions default to "next", invalid orders default to "asc", and invalid page sizes default to 20.
 // Page sizes are limited to a maximum of 100 to prevent excessive memory usage.
-func NewCursorParams(cursor string, pageSize int, direction string) CursorParams {
-	if pageSize <= 0 || pageSize > 100 {
-		pageSize = 20
+func NewCursorParams(cursor string, pageSize int, direction, order string) CursorParams {
+	return NewCursorParamsWithConfig(cursor, pageSize, direction, order, DefaultCursorConfig)
+}
+
+// NewCursorParamsWithConfig is like NewCursorParams with the page size limits of config:
+// page sizes that are not positive or above config.MaxPageSize default to config.DefaultPageSize.
+func NewCursorParamsWithConfig(cursor string, pageSize int, direction, order string, config CursorConfig) CursorParams {
+	config = config.withDefaults()
+	if pageSize <= 0 || pageSize > config.MaxPageSize {
+		pageSize = config.DefaultPageSize
 	}
 	if direction != "next" && direction != "prev" {
 		direction = "next"
 	}
+	if order != "asc" && order != "desc" {
+		order = "asc"
+	}
 	return CursorParams{
 		Cursor:    cursor,
 		PageSize:  pageSize,
 		Direction: direction,
+		Order:     order,
+	}
+}
+
+// queryOrder returns the comparison operator and the ORDER BY direction of the query fetching a page.
+// Going to the previous page walks the sort order backwards, the results are then reversed.
+func (p CursorParams) queryOrder() (operator, order string) {
+	descending := p.Order == "desc"
+	if p.Direction == "prev" {
+		descending = !descending
+	}
+	if descending {
+		return "<", "DESC"
+	}
+	return ">", "ASC"
+}
+
+// pageFlags returns whether there are pages after and before the fetched one. The extra record fetched
+// tells whether rows remain in the walked direction: after the page going forward, before it going backwards.
+// The cursor tells whether rows exist on the other side.
+func (p CursorParams) pageFlags(overflow, hasCursor bool) (hasNext, hasPrev bool) {
+	if p.Direction == "prev" {
+		return hasCursor, overflow
 	}
+	return overflow, hasCursor
 }
 
 // PaginateCursor performs cursor-based pagination on a GORM query.
@@ -63,31 +218,40 @@ func NewCursorParams(cursor string, pageSize int, direction string) CursorParams
 // Example:
 //
 //	// First page
-//	params := pagination.NewCursorParams("", 20, "next")
+//	params := pagination.NewCursorParams("", 20, "next", "asc")
 //	var users []User
 //	pagination, users, err := pagination.PaginateCursor(db, params, &users, "id")
 //
 //	// Navigate to next page
 //	if pagination.HasNext {
-//		nextParams := pagination.NewCursorParams(pagination.NextCursor, 20, "next")
+//		nextParams := pagination.NewCursorParams(pagination.NextCursor, 20, "next", "asc")
 //		nextPagination, nextUsers, err := pagination.PaginateCursor(db, nextParams, &users, "id")
 //	}
 //
 //	// Navigate backwards
 //	if pagination.HasPrev {
-//		prevParams := pagination.NewCursorParams(pagination.PrevCursor, 20, "prev")
+//		prevParams := pagination.NewCursorParams(pagination.PrevCursor, 20, "prev", "asc")
 //		prevPagination, prevUsers, err := pagination.PaginateCursor(db, prevParams, &users, "id")
 //	}
 //
-// The function supports ordering by any comparable field. Ensure the orderField
-// is properly indexed in your database for optimal performance.
-func PaginateCursor[T any](db *gorm.DB, params CursorParams, dest *[]T, orderField string) (*CursorPagination, []T, error) {
+// Pass TotalOption(true) to also count the items matching the query, filters included.
+// Pass NullsOption when orderField is nullable.
+//
+// The function supports ordering by any comparable field, in ascending or descending order
+// (params.Order), e.g. "desc" on "created_at" for a newest-first feed. "next" always follows the sort order.
+// Ensure the orderField is properly indexed in your database for optimal performance.
+func PaginateCursor[T any](db *gorm.DB, params CursorParams, dest *[]T, orderField string, opts ...CursorOption) (*CursorPagination, []T, error) {
+	cfg := newCursorOptions(opts)
+	total, err := countTotal[T](db, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	query := db
 
 	// Parse cursor if provided
 	var cursorData *CursorData
 	if params.Cursor != "" {
-		var err error
 		cursorData, err = decodeCursor(params.Cursor)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to decode cursor: %w", err)
@@ -95,20 +259,17 @@ func PaginateCursor[T any](db *gorm.DB, params CursorParams, dest *[]T, orderFie
 	}
 
 	// Apply cursor filtering
-	if cursorData != nil {
-		operator := ">"
-		order := "ASC"
-		if params.Direction == "prev" {
-			operator = "<"
-			order = "DESC"
+	var key *schema.Field
+	if cfg.nulls != "" {
+		key, err = primaryKey[T](db)
+		if err != nil {
+			return nil, nil, err
 		}
-		query = query.Where(fmt.Sprintf("%s %s ?", orderField, operator), cursorData.ID)
-		query = query.Order(fmt.Sprintf("%s %s", orderField, order))
+		query = applyNullsCursor(query, params, cursorData, orderField, key.DBName, cfg.nulls)
 	} else {
-		// Default ordering for first page
-		order := "ASC"
-		if params.Direction == "prev" {
-			order = "DESC"
+		operator, order := params.queryOrder()
+		if cursorData != nil {
+			query = query.Where(fmt.Sprintf("%s %s ?", orderField, operator), cursorData.ID)
 		}
 		query = query.Order(fmt.Sprintf("%s %s", orderField, order))
 	}
@@ -120,12 +281,12 @@ func PaginateCursor[T any](db *gorm.DB, params CursorParams, dest *[]T, orderFie
 		return nil, nil, fmt.Errorf("failed to fetch cursor paginated data: %w", err)
 	}
 
-	hasNext := len(*dest) > params.PageSize
-	hasPrev := cursorData != nil
+	overflow := len(*dest) > params.PageSize
+	hasNext, hasPrev := params.pageFlags(overflow, cursorData != nil)
 
 	// Remove the extra record if present
 	var data []T
-	if hasNext {
+	if overflow {
 		data = (*dest)[:params.PageSize]
 	} else {
 		data = *dest
@@ -140,18 +301,274 @@ func PaginateCursor[T any](db *gorm.DB, params CursorParams, dest *[]T, orderFie
 		HasNext:  hasNext,
 		HasPrev:  hasPrev,
 		PageSize: params.PageSize,
+		Total:    total,
 	}
 
-	// Generate cursors
+	// Generate cursors, only for the pages that exist so clients don't navigate past the first or last page
 	if len(data) > 0 {
-		if hasNext || params.Direction == "prev" {
-			nextCursor, err := encodeCursor(getFieldValue(data[len(data)-1], orderField), orderField)
+		if hasNext {
+			nextCursor, err := encodeItemCursor(data[len(data)-1], orderField, key)
 			if err == nil {
 				pagination.NextCursor = nextCursor
 			}
 		}
 
-		if hasPrev || params.Direction == "next" {
+		if hasPrev {
+			prevCursor, err := encodeItemCursor(data[0], orderField, key)
+			if err == nil {
+				pagination.PrevCursor = prevCursor
+			}
+		}
+	}
+
+	return pagination, data, nil
+}
+
+// primaryKey returns the primary key field of the model T, used to order the rows sharing an order field value.
+func primaryKey[T any](db *gorm.DB) (*schema.Field, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, fmt.Errorf("failed to parse model: %w", err)
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("%s has no primary key to order NULL values by", stmt.Schema.Name)
+	}
+	return stmt.Schema.PrioritizedPrimaryField, nil
+}
+
+// applyNullsCursor applies the cursor filtering and the ordering of a nullable order field, see NullsOption.
+// NULLs are sorted with an "IS NULL" expression rather than NULLS FIRST/LAST, which MySQL doesn't support.
+// Rows with the same order field value are ordered by keyColumn, compared with the key of the cursor.
+func applyNullsCursor(query *gorm.DB, params CursorParams, cursorData *CursorData, orderField, keyColumn string, nulls NullsPlacement) *gorm.DB {
+	operator, order := params.queryOrder()
+
+	// NULLs are at the end of the rows walked by the query when they are placed last and the query
+	// walks the sort order forward, or when they are placed first and the query walks it backwards
+	nullsAtEnd := (nulls == NullsLast) != (params.Direction == "prev")
+
+	if cursorData != nil {
+		// a cursor without a key compares as NULL, skipping the rows sharing its value
+		keyAfter := fmt.Sprintf("%s %s ?", keyColumn, operator)
+		switch {
+		case cursorData.Null && nullsAtEnd:
+			query = query.Where(fmt.Sprintf("(%s IS NULL AND %s)", orderField, keyAfter), cursorData.Key)
+		case cursorData.Null:
+			query = query.Where(fmt.Sprintf("(%s IS NOT NULL OR %s)", orderField, keyAfter), cursorData.Key)
+		case nullsAtEnd:
+			query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s) OR %s IS NULL)",
+				orderField, operator, orderField, keyAfter, orderField), cursorData.ID, cursorData.ID, cursorData.Key)
+		default:
+			query = query.Where(fmt.Sprintf("(%s %s ? OR (%s = ? AND %s))",
+				orderField, operator, orderField, keyAfter), cursorData.ID, cursorData.ID, cursorData.Key)
+		}
+	}
+
+	nullsOrder := "DESC"
+	if nullsAtEnd {
+		nullsOrder = "ASC"
+	}
+	query = query.Order(fmt.Sprintf("%s IS NULL %s", orderField, nullsOrder))
+	query = query.Order(fmt.Sprintf("%s %s", orderField, order))
+	return query.Order(fmt.Sprintf("%s %s", keyColumn, order))
+}
+
+// PaginateCursorT performs cursor-based pagination like PaginateCursor, allocating and returning the
+// typed slice instead of filling a destination.
+//
+// Example:
+//
+//	pagination, users, err := pagination.PaginateCursorT[User](db, params, "id")
+func PaginateCursorT[T any](db *gorm.DB, params CursorParams, orderField string, opts ...CursorOption) (*CursorPagination, []T, error) {
+	var dest []T
+	return PaginateCursor(db, params, &dest, orderField, opts...)
+}
+
+// PaginateCursorMulti performs keyset pagination ordered by several columns.
+// Unlike PaginateCursor, pages stay stable when the first column has duplicate values: the cursor
+// holds the value of every column and rows are compared as a tuple, e.g. (score, id) > (?, ?).
+// The last column should be unique, typically the primary key.
+//
+// Example:
+//
+//	params := pagination.NewCursorParams("", 20, "next", "asc")
+//	var products []Product
+//	result, products, err := pagination.PaginateCursorMulti(db, params, &products, []string{"score", "id"})
+//
+// All columns are sorted in params.Order, "prev" walks them backwards. A cursor must be reused with
+// the columns it was created with, otherwise an error is returned. The database must support row value
+// comparisons (Postgres, MySQL and SQLite 3.15+ do). TotalOption is supported as with PaginateCursor.
+func PaginateCursorMulti[T any](db *gorm.DB, params CursorParams, dest *[]T, columns []string, opts ...CursorOption) (*CursorPagination, []T, error) {
+	if len(columns) == 0 {
+		return nil, nil, fmt.Errorf("at least one ordering column is required")
+	}
+
+	total, err := countTotal[T](db, newCursorOptions(opts))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	query := db
+
+	// Parse cursor if provided
+	var cursorData *MultiCursorData
+	if params.Cursor != "" {
+		cursorData, err = decodeMultiCursor(params.Cursor, columns)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decode cursor: %w", err)
+		}
+	}
+
+	// Apply cursor filtering with a composite keyset predicate
+	operator, order := params.queryOrder()
+	if cursorData != nil {
+		placeholders := make([]string, len(columns))
+		values := make([]interface{}, len(columns))
+		for i, column := range cursorData.Columns {
+			placeholders[i] = "?"
+			values[i] = column.Value
+		}
+		query = query.Where(fmt.Sprintf("(%s) %s (%s)",
+			strings.Join(columns, ", "), operator, strings.Join(placeholders, ", ")), values...)
+	}
+	for _, column := range columns {
+		query = query.Order(fmt.Sprintf("%s %s", column, order))
+	}
+
+	// Fetch one extra record to check if there are more pages
+	query = query.Limit(params.PageSize + 1)
+
+	if err := query.Find(dest).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch cursor paginated data: %w", err)
+	}
+
+	overflow := len(*dest) > params.PageSize
+	hasNext, hasPrev := params.pageFlags(overflow, cursorData != nil)
+
+	// Remove the extra record if present
+	var data []T
+	if overflow {
+		data = (*dest)[:params.PageSize]
+	} else {
+		data = *dest
+	}
+
+	// If we're going backwards, reverse the results
+	if params.Direction == "prev" {
+		reverseSlice(&data)
+	}
+
+	pagination := &CursorPagination{
+		HasNext:  hasNext,
+		HasPrev:  hasPrev,
+		PageSize: params.PageSize,
+		Total:    total,
+	}
+
+	// Generate cursors, only for the pages that exist so clients don't navigate past the first or last page
+	if len(data) > 0 {
+		if hasNext {
+			nextCursor, err := encodeMultiCursor(data[len(data)-1], columns)
+			if err == nil {
+				pagination.NextCursor = nextCursor
+			}
+		}
+
+		if hasPrev {
+			prevCursor, err := encodeMultiCursor(data[0], columns)
+			if err == nil {
+				pagination.PrevCursor = prevCursor
+			}
+		}
+	}
+
+	return pagination, data, nil
+}
+
+// PaginateAround fetches the items surrounding a cursor in a single call.
+// It returns up to before items preceding the cursor, the item at the cursor itself,
+// and up to after items following it, all in ascending orderField order.
+//
+// This powers "jump to item" style views (e.g. opening a conversation on a given message)
+// without having to issue two PaginateCursor calls and merge the results client-side.
+//
+// Example:
+//
+//	var messages []Message
+//	result, messages, err := pagination.PaginateAround(db, cursor, 10, 10, &messages, "created_at")
+//
+// The returned CursorPagination has HasPrev/HasNext set when more items exist on either side,
+// with PrevCursor and NextCursor pointing at the first and last returned items so that regular
+// PaginateCursor calls can continue from there.
+func PaginateAround[T any](db *gorm.DB, cursor string, before, after int, dest *[]T, orderField string) (*CursorPagination, []T, error) {
+	if cursor == "" {
+		return nil, nil, fmt.Errorf("a cursor is required to paginate around an item")
+	}
+	if before < 0 {
+		before = 0
+	}
+	if after < 0 {
+		after = 0
+	}
+
+	cursorData, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+
+	// Items strictly before the cursor, closest first, one extra to detect a previous page
+	var beforeItems []T
+	if before > 0 {
+		err := db.Session(&gorm.Session{}).
+			Where(fmt.Sprintf("%s < ?", orderField), cursorData.ID).
+			Order(fmt.Sprintf("%s DESC", orderField)).
+			Limit(before + 1).
+			Find(&beforeItems).Error
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch items before cursor: %w", err)
+		}
+	}
+
+	// The item at the cursor followed by the items after it, one extra to detect a next page
+	var afterItems []T
+	err = db.Session(&gorm.Session{}).
+		Where(fmt.Sprintf("%s >= ?", orderField), cursorData.ID).
+		Order(fmt.Sprintf("%s ASC", orderField)).
+		Limit(after + 2).
+		Find(&afterItems).Error
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch items after cursor: %w", err)
+	}
+
+	hasPrev := len(beforeItems) > before
+	if hasPrev {
+		beforeItems = beforeItems[:before]
+	}
+	reverseSlice(&beforeItems)
+
+	hasNext := len(afterItems) > after+1
+	if hasNext {
+		afterItems = afterItems[:after+1]
+	}
+
+	data := make([]T, 0, len(beforeItems)+len(afterItems))
+	data = append(data, beforeItems...)
+	data = append(data, afterItems...)
+	*dest = data
+
+	pagination := &CursorPagination{
+		HasNext:  hasNext,
+		HasPrev:  hasPrev,
+		PageSize: before + after + 1,
+	}
+
+	if len(data) > 0 {
+		if hasNext {
+			nextCursor, err := encodeCursor(getFieldValue(data[len(data)-1], orderField), orderField)
+			if err == nil {
+				pagination.NextCursor = nextCursor
+			}
+		}
+		if hasPrev {
 			prevCursor, err := encodeCursor(getFieldValue(data[0], orderField), orderField)
 			if err == nil {
 				pagination.PrevCursor = prevCursor
@@ -172,27 +589,49 @@ func PaginateCursor[T any](db *gorm.DB, params CursorParams, dest *[]T, orderFie
 //		r.URL.Query().Get("cursor"),
 //		r.URL.Query().Get("page_size"),
 //		r.URL.Query().Get("direction"),
+//		r.URL.Query().Get("order"),
 //	)
 //
 // Invalid or missing parameters will use sensible defaults.
-func ParseCursorParams(cursor, pageSize, direction string) CursorParams {
-	size := 20
+func ParseCursorParams(cursor, pageSize, direction, order string) CursorParams {
+	return ParseCursorParamsWithConfig(cursor, pageSize, direction, order, DefaultCursorConfig)
+}
+
+// ParseCursorParamsWithConfig is like ParseCursorParams with the page size limits of config.
+func ParseCursorParamsWithConfig(cursor, pageSize, direction, order string, config CursorConfig) CursorParams {
+	size := 0 // invalid sizes fall back to config.DefaultPageSize
 	if pageSize != "" {
-		if parsed, err := strconv.Atoi(pageSize); err == nil && parsed > 0 && parsed <= 100 {
+		if parsed, err := strconv.Atoi(pageSize); err == nil {
 			size = parsed
 		}
 	}
 
-	return NewCursorParams(cursor, size, direction)
+	return NewCursorParamsWithConfig(cursor, size, direction, order, config)
 }
 
 // encodeCursor creates a base64 encoded cursor from an ID and field name.
 // The cursor contains both the field value and the field name to ensure
-// consistency across different queries.
+// consistency across different queries. It is signed when SetCursorSigningKey was called.
 func encodeCursor(id interface{}, field string) (string, error) {
+	return encodeKeyedCursor(id, field, nil)
+}
+
+// encodeItemCursor creates the cursor of item for PaginateCursor, holding its primary key when key is set.
+func encodeItemCursor(item interface{}, orderField string, key *schema.Field) (string, error) {
+	if key == nil {
+		return encodeCursor(getFieldValue(item, orderField), orderField)
+	}
+	return encodeKeyedCursor(getFieldValue(item, orderField), orderField, getFieldValue(item, key.Name))
+}
+
+// encodeKeyedCursor is like encodeCursor, also storing the primary key of the item the cursor points at.
+func encodeKeyedCursor(id interface{}, field string, key interface{}) (string, error) {
+	value, null := cursorValue(id)
 	cursorData := CursorData{
-		ID:    id,
+		ID:    value,
 		Field: field,
+		Null:  null,
+		Key:   key,
 	}
 
 	jsonData, err := json.Marshal(cursorData)
@@ -200,13 +639,18 @@ func encodeCursor(id interface{}, field string) (string, error) {
 		return "", err
 	}
 
-	return base64.URLEncoding.EncodeToString(jsonData), nil
+	return signCursor(base64.URLEncoding.EncodeToString(jsonData)), nil
 }
 
 // decodeCursor decodes a base64 cursor back to CursorData.
-// Returns an error if the cursor is malformed or cannot be decoded.
+// Returns an error if the cursor is malformed, cannot be decoded or its signature is invalid.
 func decodeCursor(cursor string) (*CursorData, error) {
-	jsonData, err := base64.URLEncoding.DecodeString(cursor)
+	payload, err := verifyCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := base64.URLEncoding.DecodeString(payload)
 	if err != nil {
 		return nil, err
 	}
@@ -219,6 +663,51 @@ func decodeCursor(cursor string) (*CursorData, error) {
 	return &cursorData, nil
 }
 
+// encodeMultiCursor creates a base64 encoded cursor holding the value of each column of item.
+func encodeMultiCursor(item interface{}, columns []string) (string, error) {
+	cursorData := MultiCursorData{Columns: make([]CursorColumn, len(columns))}
+	for i, column := range columns {
+		cursorData.Columns[i] = CursorColumn{Field: column, Value: getFieldValue(item, column)}
+	}
+
+	jsonData, err := json.Marshal(cursorData)
+	if err != nil {
+		return "", err
+	}
+
+	return signCursor(base64.URLEncoding.EncodeToString(jsonData)), nil
+}
+
+// decodeMultiCursor decodes a base64 cursor back to MultiCursorData.
+// Returns an error if the cursor is malformed or was not created for columns.
+func decodeMultiCursor(cursor string, columns []string) (*MultiCursorData, error) {
+	payload, err := verifyCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonData, err := base64.URLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursorData MultiCursorData
+	if err := json.Unmarshal(jsonData, &cursorData); err != nil {
+		return nil, err
+	}
+
+	if len(cursorData.Columns) != len(columns) {
+		return nil, fmt.Errorf("cursor has %d columns, expected %d", len(cursorData.Columns), len(columns))
+	}
+	for i, column := range cursorData.Columns {
+		if column.Field != columns[i] {
+			return nil, fmt.Errorf("cursor column %d is %q, expected %q", i, column.Field, columns[i])
+		}
+	}
+
+	return &cursorData, nil
+}
+
 // getFieldValue extracts the value of a specific field from a struct using reflection.
 // It supports various field name formats including exact matches, case-insensitive matches,
 // JSON tag names, and GORM column names.
@@ -260,6 +749,34 @@ func getFieldValue(item interface{}, fieldName string) interface{} {
 	return nil
 }
 
+// cursorValue returns the value to store in a cursor for a field value and whether it is NULL.
+// Pointers are dereferenced and driver.Valuer types like sql.NullFloat64 are converted to their SQL value.
+func cursorValue(value interface{}) (interface{}, bool) {
+	if valuer, ok := value.(driver.Valuer); ok {
+		v := reflect.ValueOf(valuer)
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil, true
+		}
+		sqlValue, err := valuer.Value()
+		if err != nil {
+			return value, false
+		}
+		value = sqlValue
+	}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, true
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, true
+	}
+	return v.Interface(), false
+}
+
 // reverseSlice reverses a slice in place.
 // This is used when paginating backwards to maintain correct order.
 func reverseSlice[T any](slice *[]T) {
@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/nrednav/cuid2"
@@ -84,6 +85,7 @@ func TestNewCursorParams(t *testing.T) {
 		cursor    string
 		pageSize  int
 		direction string
+		order     string
 		expected  CursorParams
 	}{
 		{
@@ -91,62 +93,78 @@ func TestNewCursorParams(t *testing.T) {
 			cursor:    "abc123",
 			pageSize:  10,
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "invalid direction defaults to next",
 			cursor:    "abc123",
 			pageSize:  10,
 			direction: "invalid",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "empty direction defaults to next",
 			cursor:    "abc123",
 			pageSize:  10,
 			direction: "",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "prev direction",
 			cursor:    "abc123",
 			pageSize:  10,
 			direction: "prev",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "prev"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "prev", Order: "asc"},
 		},
 		{
 			name:      "invalid pageSize defaults to 20",
 			cursor:    "abc123",
 			pageSize:  0,
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "negative pageSize defaults to 20",
 			cursor:    "abc123",
 			pageSize:  -5,
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "pageSize too large defaults to 20",
 			cursor:    "abc123",
 			pageSize:  101,
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "pageSize at limit is allowed",
 			cursor:    "abc123",
 			pageSize:  100,
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 100, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 100, Direction: "next", Order: "asc"},
+		},
+		{
+			name:      "desc order",
+			cursor:    "abc123",
+			pageSize:  10,
+			direction: "next",
+			order:     "desc",
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next", Order: "desc"},
+		},
+		{
+			name:      "invalid order defaults to asc",
+			cursor:    "abc123",
+			pageSize:  10,
+			direction: "next",
+			order:     "sideways",
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next", Order: "asc"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := NewCursorParams(tt.cursor, tt.pageSize, tt.direction)
+			result := NewCursorParams(tt.cursor, tt.pageSize, tt.direction, tt.order)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -156,7 +174,7 @@ func TestPaginateCursorFirstPage(t *testing.T) {
 	db := setupCursorTestDB(t)
 	seedUsersWithCUID(db, 10)
 
-	params := NewCursorParams("", 3, "next")
+	params := NewCursorParams("", 3, "next", "asc")
 	var users []UserWithCUID
 	pagination, results, err := PaginateCursor(db, params, &users, "created_at")
 	require.NoError(t, err)
@@ -165,7 +183,7 @@ func TestPaginateCursorFirstPage(t *testing.T) {
 	assert.True(t, pagination.HasNext)
 	assert.False(t, pagination.HasPrev)
 	assert.NotEmpty(t, pagination.NextCursor)
-	assert.NotEmpty(t, pagination.PrevCursor) // PrevCursor is generated when direction is "next"
+	assert.Empty(t, pagination.PrevCursor) // there is no page before the first one
 	assert.Equal(t, 3, pagination.PageSize)
 
 	// Verify ordering by created_at
@@ -174,12 +192,37 @@ func TestPaginateCursorFirstPage(t *testing.T) {
 	}
 }
 
+func TestPaginateCursorBoundaryCursors(t *testing.T) {
+	db := setupCursorTestDB(t)
+	seedProductsWithScore(db, 4)
+
+	var products []ProductWithScore
+	first, _, err := PaginateCursor(db, NewCursorParams("", 2, "next", "asc"), &products, "price")
+	require.NoError(t, err)
+	assert.False(t, first.HasPrev)
+	assert.Equal(t, "", first.PrevCursor)
+	assert.NotEmpty(t, first.NextCursor)
+
+	var lastProducts []ProductWithScore
+	last, _, err := PaginateCursor(db, NewCursorParams(first.NextCursor, 2, "next", "asc"), &lastProducts, "price")
+	require.NoError(t, err)
+	assert.False(t, last.HasNext)
+	assert.Equal(t, "", last.NextCursor)
+	assert.NotEmpty(t, last.PrevCursor)
+
+	var allProducts []ProductWithScore
+	single, _, err := PaginateCursor(db, NewCursorParams("", 10, "next", "asc"), &allProducts, "price")
+	require.NoError(t, err)
+	assert.Equal(t, "", single.PrevCursor)
+	assert.Equal(t, "", single.NextCursor)
+}
+
 func TestPaginateCursorWithCUID(t *testing.T) {
 	db := setupCursorTestDB(t)
 	seedUsersWithCUID(db, 10)
 
 	t.Run("first page ordered by CUID", func(t *testing.T) {
-		params := NewCursorParams("", 4, "next")
+		params := NewCursorParams("", 4, "next", "asc")
 		var users []UserWithCUID
 		pagination, results, err := PaginateCursor(db, params, &users, "id")
 		require.NoError(t, err)
@@ -198,7 +241,7 @@ func TestPaginateCursorWithCUID(t *testing.T) {
 
 	t.Run("navigate with CUID cursor", func(t *testing.T) {
 		// Get first page
-		params := NewCursorParams("", 3, "next")
+		params := NewCursorParams("", 3, "next", "asc")
 		var users []UserWithCUID
 		firstPagination, firstResults, err := PaginateCursor(db, params, &users, "id")
 		require.NoError(t, err)
@@ -206,7 +249,7 @@ func TestPaginateCursorWithCUID(t *testing.T) {
 
 		// Use cursor for next page
 		var nextUsers []UserWithCUID
-		nextParams := NewCursorParams(firstPagination.NextCursor, 3, "next")
+		nextParams := NewCursorParams(firstPagination.NextCursor, 3, "next", "asc")
 		nextPagination, nextResults, err := PaginateCursor(db, nextParams, &nextUsers, "id")
 		require.NoError(t, err)
 
@@ -230,7 +273,7 @@ func TestPaginateCursorWithNumericOrdering(t *testing.T) {
 	seedProductsWithScore(db, 8)
 
 	t.Run("order by integer price", func(t *testing.T) {
-		params := NewCursorParams("", 3, "next")
+		params := NewCursorParams("", 3, "next", "asc")
 		var products []ProductWithScore
 		pagination, results, err := PaginateCursor(db, params, &products, "price")
 		require.NoError(t, err)
@@ -245,7 +288,7 @@ func TestPaginateCursorWithNumericOrdering(t *testing.T) {
 	})
 
 	t.Run("order by float score", func(t *testing.T) {
-		params := NewCursorParams("", 3, "next")
+		params := NewCursorParams("", 3, "next", "asc")
 		var products []ProductWithScore
 		pagination, results, err := PaginateCursor(db, params, &products, "score")
 		require.NoError(t, err)
@@ -261,14 +304,14 @@ func TestPaginateCursorWithNumericOrdering(t *testing.T) {
 
 	t.Run("navigate with numeric cursor", func(t *testing.T) {
 		// Get first page ordered by price
-		params := NewCursorParams("", 2, "next")
+		params := NewCursorParams("", 2, "next", "asc")
 		var products []ProductWithScore
 		firstPagination, firstResults, err := PaginateCursor(db, params, &products, "price")
 		require.NoError(t, err)
 
 		// Get second page
 		var nextProducts []ProductWithScore
-		nextParams := NewCursorParams(firstPagination.NextCursor, 2, "next")
+		nextParams := NewCursorParams(firstPagination.NextCursor, 2, "next", "asc")
 		_, nextResults, err := PaginateCursor(db, nextParams, &nextProducts, "price")
 		require.NoError(t, err)
 
@@ -287,18 +330,18 @@ func TestPaginateCursorPrevDirection(t *testing.T) {
 
 	// Navigate to middle of dataset first
 	var users []UserWithCUID
-	params := NewCursorParams("", 2, "next")
+	params := NewCursorParams("", 2, "next", "asc")
 	firstPagination, firstResults, err := PaginateCursor(db, params, &users, "created_at")
 	require.NoError(t, err)
 
 	var secondPageUsers []UserWithCUID
-	secondParams := NewCursorParams(firstPagination.NextCursor, 2, "next")
+	secondParams := NewCursorParams(firstPagination.NextCursor, 2, "next", "asc")
 	secondPagination, _, err := PaginateCursor(db, secondParams, &secondPageUsers, "created_at")
 	require.NoError(t, err)
 
 	// Now go backwards from second page cursor
 	var prevUsers []UserWithCUID
-	prevParams := NewCursorParams(secondPagination.NextCursor, 2, "prev")
+	prevParams := NewCursorParams(secondPagination.NextCursor, 2, "prev", "asc")
 	prevPagination, prevResults, err := PaginateCursor(db, prevParams, &prevUsers, "created_at")
 	require.NoError(t, err)
 
@@ -310,6 +353,69 @@ func TestPaginateCursorPrevDirection(t *testing.T) {
 	assert.Equal(t, len(firstResults), len(prevResults))
 }
 
+func TestPaginateCursorBackToFirstPage(t *testing.T) {
+	db := setupCursorTestDB(t)
+	seedProductsWithScore(db, 7) // prices 10, 20, ..., 70
+
+	prices := func(products []ProductWithScore) []int {
+		result := make([]int, len(products))
+		for i, p := range products {
+			result[i] = p.Price
+		}
+		return result
+	}
+
+	t.Run("single column", func(t *testing.T) {
+		var products []ProductWithScore
+		first, _, err := PaginateCursor(db, NewCursorParams("", 3, "next", "asc"), &products, "price")
+		require.NoError(t, err)
+
+		var secondProducts []ProductWithScore
+		second, _, err := PaginateCursor(db, NewCursorParams(first.NextCursor, 3, "next", "asc"), &secondProducts, "price")
+		require.NoError(t, err)
+
+		var prevProducts []ProductWithScore
+		back, backResults, err := PaginateCursor(db, NewCursorParams(second.PrevCursor, 3, "prev", "asc"), &prevProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{10, 20, 30}, prices(backResults))
+		assert.False(t, back.HasPrev)
+		assert.Empty(t, back.PrevCursor)
+		assert.True(t, back.HasNext)
+		require.NotEmpty(t, back.NextCursor)
+
+		var forwardProducts []ProductWithScore
+		_, forwardResults, err := PaginateCursor(db, NewCursorParams(back.NextCursor, 3, "next", "asc"), &forwardProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{40, 50, 60}, prices(forwardResults))
+	})
+
+	t.Run("multiple columns", func(t *testing.T) {
+		columns := []string{"price", "id"}
+
+		var products []ProductWithScore
+		first, _, err := PaginateCursorMulti(db, NewCursorParams("", 3, "next", "asc"), &products, columns)
+		require.NoError(t, err)
+
+		var secondProducts []ProductWithScore
+		second, _, err := PaginateCursorMulti(db, NewCursorParams(first.NextCursor, 3, "next", "asc"), &secondProducts, columns)
+		require.NoError(t, err)
+
+		var prevProducts []ProductWithScore
+		back, backResults, err := PaginateCursorMulti(db, NewCursorParams(second.PrevCursor, 3, "prev", "asc"), &prevProducts, columns)
+		require.NoError(t, err)
+		assert.Equal(t, []int{10, 20, 30}, prices(backResults))
+		assert.False(t, back.HasPrev)
+		assert.Empty(t, back.PrevCursor)
+		assert.True(t, back.HasNext)
+		require.NotEmpty(t, back.NextCursor)
+
+		var forwardProducts []ProductWithScore
+		_, forwardResults, err := PaginateCursorMulti(db, NewCursorParams(back.NextCursor, 3, "next", "asc"), &forwardProducts, columns)
+		require.NoError(t, err)
+		assert.Equal(t, []int{40, 50, 60}, prices(forwardResults))
+	})
+}
+
 func TestPaginateCursorWithFilters(t *testing.T) {
 	db := setupCursorTestDB(t)
 	seedProductsWithScore(db, 10)
@@ -317,7 +423,7 @@ func TestPaginateCursorWithFilters(t *testing.T) {
 	t.Run("filtered cursor pagination", func(t *testing.T) {
 		// Filter products with price > 50
 		filteredQuery := db.Where("price > ?", 50)
-		params := NewCursorParams("", 3, "next")
+		params := NewCursorParams("", 3, "next", "asc")
 		var products []ProductWithScore
 		_, results, err := PaginateCursor(filteredQuery, params, &products, "price")
 		require.NoError(t, err)
@@ -337,14 +443,14 @@ func TestPaginateCursorWithFilters(t *testing.T) {
 
 	t.Run("navigate filtered results", func(t *testing.T) {
 		filteredQuery := db.Where("category_id = ?", 1)
-		params := NewCursorParams("", 2, "next")
+		params := NewCursorParams("", 2, "next", "asc")
 		var products []ProductWithScore
 		firstPagination, _, err := PaginateCursor(filteredQuery, params, &products, "id")
 		require.NoError(t, err)
 
 		if firstPagination.HasNext {
 			var nextProducts []ProductWithScore
-			nextParams := NewCursorParams(firstPagination.NextCursor, 2, "next")
+			nextParams := NewCursorParams(firstPagination.NextCursor, 2, "next", "asc")
 			_, nextResults, err := PaginateCursor(filteredQuery, nextParams, &nextProducts, "id")
 			require.NoError(t, err)
 
@@ -360,7 +466,7 @@ func TestPaginateCursorEmptyResults(t *testing.T) {
 	db := setupCursorTestDB(t)
 	// Don't seed any data
 
-	params := NewCursorParams("", 10, "next")
+	params := NewCursorParams("", 10, "next", "asc")
 	var users []UserWithCUID
 	pagination, results, err := PaginateCursor(db, params, &users, "id")
 	require.NoError(t, err)
@@ -472,6 +578,7 @@ func TestParseCursorParams(t *testing.T) {
 		cursor    string
 		pageSize  string
 		direction string
+		order     string
 		expected  CursorParams
 	}{
 		{
@@ -479,69 +586,77 @@ func TestParseCursorParams(t *testing.T) {
 			cursor:    "abc123",
 			pageSize:  "10",
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 10, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "empty parameters use defaults",
 			cursor:    "",
 			pageSize:  "",
 			direction: "",
-			expected:  CursorParams{Cursor: "", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "invalid pageSize uses default",
 			cursor:    "abc123",
 			pageSize:  "invalid",
 			direction: "prev",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "prev"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "prev", Order: "asc"},
 		},
 		{
 			name:      "zero pageSize uses default",
 			cursor:    "abc123",
 			pageSize:  "0",
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "negative pageSize uses default",
 			cursor:    "abc123",
 			pageSize:  "-5",
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "pageSize too large uses default",
 			cursor:    "abc123",
 			pageSize:  "101",
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 20, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "pageSize at limit is allowed",
 			cursor:    "abc123",
 			pageSize:  "100",
 			direction: "next",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 100, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 100, Direction: "next", Order: "asc"},
 		},
 		{
 			name:      "invalid direction uses default",
 			cursor:    "abc123",
 			pageSize:  "15",
 			direction: "invalid",
-			expected:  CursorParams{Cursor: "abc123", PageSize: 15, Direction: "next"},
+			expected:  CursorParams{Cursor: "abc123", PageSize: 15, Direction: "next", Order: "asc"},
+		},
+		{
+			name:      "desc order",
+			cursor:    "abc123",
+			pageSize:  "15",
+			direction: "prev",
+			order:     "desc",
+			expected:  CursorParams{Cursor: "abc123", PageSize: 15, Direction: "prev", Order: "desc"},
 		},
 		{
 			name:      "cuid2 cursor",
 			cursor:    cuid2.Generate(),
 			pageSize:  "25",
 			direction: "prev",
-			expected:  CursorParams{Cursor: "", PageSize: 25, Direction: "prev"}, // cursor will be set to the generated value
+			expected:  CursorParams{Cursor: "", PageSize: 25, Direction: "prev", Order: "asc"}, // cursor will be set to the generated value
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := ParseCursorParams(tt.cursor, tt.pageSize, tt.direction)
+			result := ParseCursorParams(tt.cursor, tt.pageSize, tt.direction, tt.order)
 			if tt.name == "cuid2 cursor" {
 				// Special case: just check the other fields since cursor is generated
 				assert.Equal(t, tt.expected.PageSize, result.PageSize)
@@ -709,7 +824,7 @@ func TestPaginateCursorDatabaseError(t *testing.T) {
 	require.NoError(t, err)
 	sqlDB.Close()
 
-	params := NewCursorParams("", 10, "next")
+	params := NewCursorParams("", 10, "next", "asc")
 	var users []UserWithCUID
 	pagination, results, err := PaginateCursor(db, params, &users, "id")
 
@@ -723,7 +838,7 @@ func TestPaginateCursorInvalidCursor(t *testing.T) {
 	db := setupCursorTestDB(t)
 	seedUsersWithCUID(db, 5)
 
-	params := NewCursorParams("invalid-cursor-data", 10, "next")
+	params := NewCursorParams("invalid-cursor-data", 10, "next", "asc")
 	var users []UserWithCUID
 	pagination, results, err := PaginateCursor(db, params, &users, "id")
 
@@ -732,3 +847,442 @@ func TestPaginateCursorInvalidCursor(t *testing.T) {
 	assert.Nil(t, results)
 	assert.Contains(t, err.Error(), "failed to decode cursor")
 }
+
+func TestPaginateAround(t *testing.T) {
+	db := setupCursorTestDB(t)
+	seedProductsWithScore(db, 10) // prices 10, 20, ..., 100
+
+	cursor, err := encodeCursor(50, "price")
+	require.NoError(t, err)
+
+	t.Run("items on both sides of the cursor", func(t *testing.T) {
+		var products []ProductWithScore
+		pagination, results, err := PaginateAround(db, cursor, 2, 3, &products, "price")
+		require.NoError(t, err)
+
+		require.Len(t, results, 6)
+		prices := make([]int, len(results))
+		for i, p := range results {
+			prices[i] = p.Price
+		}
+		assert.Equal(t, []int{30, 40, 50, 60, 70, 80}, prices)
+		assert.Equal(t, results, products)
+
+		assert.True(t, pagination.HasPrev)
+		assert.True(t, pagination.HasNext)
+		assert.NotEmpty(t, pagination.PrevCursor)
+		assert.NotEmpty(t, pagination.NextCursor)
+		assert.Equal(t, 6, pagination.PageSize)
+
+		// The returned cursors continue regular cursor pagination from the edges
+		var next []ProductWithScore
+		_, nextResults, err := PaginateCursor(db, NewCursorParams(pagination.NextCursor, 2, "next", "asc"), &next, "price")
+		require.NoError(t, err)
+		require.Len(t, nextResults, 2)
+		assert.Equal(t, 90, nextResults[0].Price)
+	})
+
+	t.Run("window larger than available items", func(t *testing.T) {
+		var products []ProductWithScore
+		pagination, results, err := PaginateAround(db, cursor, 10, 10, &products, "price")
+		require.NoError(t, err)
+
+		assert.Len(t, results, 10)
+		assert.Equal(t, 10, results[0].Price)
+		assert.Equal(t, 100, results[9].Price)
+		assert.False(t, pagination.HasPrev)
+		assert.False(t, pagination.HasNext)
+		assert.Empty(t, pagination.PrevCursor)
+		assert.Empty(t, pagination.NextCursor)
+	})
+
+	t.Run("filters are applied to both sides", func(t *testing.T) {
+		var products []ProductWithScore
+		_, results, err := PaginateAround(db.Where("category_id = ?", 2), cursor, 5, 5, &products, "price")
+		require.NoError(t, err)
+
+		for _, p := range results {
+			assert.Equal(t, uint(2), p.CategoryID)
+		}
+	})
+
+	t.Run("missing cursor", func(t *testing.T) {
+		var products []ProductWithScore
+		_, _, err := PaginateAround(db, "", 2, 2, &products, "price")
+		assert.Error(t, err)
+	})
+}
+
+func TestPaginateCursorMulti(t *testing.T) {
+	db := setupCursorTestDB(t)
+	// 10 products spread over 3 categories, category_id has many duplicates
+	seedProductsWithScore(db, 10)
+	columns := []string{"category_id", "id"}
+
+	var all []ProductWithScore
+	require.NoError(t, db.Order("category_id ASC, id ASC").Find(&all).Error)
+
+	t.Run("walks every row once across pages", func(t *testing.T) {
+		var seen []uint
+		cursor := ""
+		for {
+			var products []ProductWithScore
+			pagination, results, err := PaginateCursorMulti(db, NewCursorParams(cursor, 3, "next", "asc"), &products, columns)
+			require.NoError(t, err)
+			for _, product := range results {
+				seen = append(seen, product.ID)
+			}
+			if !pagination.HasNext {
+				break
+			}
+			cursor = pagination.NextCursor
+		}
+
+		expected := make([]uint, len(all))
+		for i, product := range all {
+			expected[i] = product.ID
+		}
+		assert.Equal(t, expected, seen)
+	})
+
+	t.Run("prev direction returns the preceding rows", func(t *testing.T) {
+		var products []ProductWithScore
+		first, _, err := PaginateCursorMulti(db, NewCursorParams("", 4, "next", "asc"), &products, columns)
+		require.NoError(t, err)
+
+		var nextProducts []ProductWithScore
+		second, secondResults, err := PaginateCursorMulti(db, NewCursorParams(first.NextCursor, 4, "next", "asc"), &nextProducts, columns)
+		require.NoError(t, err)
+		assert.Equal(t, all[4].ID, secondResults[0].ID)
+
+		var prevProducts []ProductWithScore
+		_, prevResults, err := PaginateCursorMulti(db, NewCursorParams(second.PrevCursor, 4, "prev", "asc"), &prevProducts, columns)
+		require.NoError(t, err)
+		require.Len(t, prevResults, 4)
+		for i, product := range prevResults {
+			assert.Equal(t, all[i].ID, product.ID)
+		}
+	})
+
+	t.Run("cursor payload carries every column", func(t *testing.T) {
+		var products []ProductWithScore
+		pagination, results, err := PaginateCursorMulti(db, NewCursorParams("", 2, "next", "asc"), &products, columns)
+		require.NoError(t, err)
+
+		cursorData, err := decodeMultiCursor(pagination.NextCursor, columns)
+		require.NoError(t, err)
+		require.Len(t, cursorData.Columns, 2)
+		assert.Equal(t, "category_id", cursorData.Columns[0].Field)
+		assert.EqualValues(t, results[1].CategoryID, cursorData.Columns[0].Value)
+		assert.Equal(t, "id", cursorData.Columns[1].Field)
+		assert.EqualValues(t, results[1].ID, cursorData.Columns[1].Value)
+	})
+
+	t.Run("cursor created for other columns", func(t *testing.T) {
+		var products []ProductWithScore
+		pagination, _, err := PaginateCursorMulti(db, NewCursorParams("", 2, "next", "asc"), &products, columns)
+		require.NoError(t, err)
+
+		_, _, err = PaginateCursorMulti(db, NewCursorParams(pagination.NextCursor, 2, "next", "asc"), &products, []string{"price", "id"})
+		assert.Error(t, err)
+	})
+
+	t.Run("no columns", func(t *testing.T) {
+		var products []ProductWithScore
+		_, _, err := PaginateCursorMulti(db, NewCursorParams("", 2, "next", "asc"), &products, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestPaginateCursorDescending(t *testing.T) {
+	db := setupCursorTestDB(t)
+	seedProductsWithScore(db, 7) // prices 10, 20, ..., 70
+
+	prices := func(products []ProductWithScore) []int {
+		result := make([]int, len(products))
+		for i, p := range products {
+			result[i] = p.Price
+		}
+		return result
+	}
+
+	var products []ProductWithScore
+	first, firstResults, err := PaginateCursor(db, NewCursorParams("", 3, "next", "desc"), &products, "price")
+	require.NoError(t, err)
+	assert.Equal(t, []int{70, 60, 50}, prices(firstResults))
+	assert.True(t, first.HasNext)
+	assert.False(t, first.HasPrev)
+
+	var secondProducts []ProductWithScore
+	second, secondResults, err := PaginateCursor(db, NewCursorParams(first.NextCursor, 3, "next", "desc"), &secondProducts, "price")
+	require.NoError(t, err)
+	assert.Equal(t, []int{40, 30, 20}, prices(secondResults))
+	assert.True(t, second.HasNext)
+	assert.True(t, second.HasPrev)
+
+	var lastProducts []ProductWithScore
+	last, lastResults, err := PaginateCursor(db, NewCursorParams(second.NextCursor, 3, "next", "desc"), &lastProducts, "price")
+	require.NoError(t, err)
+	assert.Equal(t, []int{10}, prices(lastResults))
+	assert.False(t, last.HasNext)
+
+	t.Run("navigate backwards", func(t *testing.T) {
+		var prevProducts []ProductWithScore
+		prev, prevResults, err := PaginateCursor(db, NewCursorParams(last.PrevCursor, 3, "prev", "desc"), &prevProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{40, 30, 20}, prices(prevResults))
+		assert.True(t, prev.HasPrev)
+		assert.True(t, prev.HasNext)
+		assert.NotEmpty(t, prev.PrevCursor)
+		assert.NotEmpty(t, prev.NextCursor)
+
+		var firstAgain []ProductWithScore
+		firstAgainPagination, firstAgainResults, err := PaginateCursor(db, NewCursorParams(prev.PrevCursor, 3, "prev", "desc"), &firstAgain, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{70, 60, 50}, prices(firstAgainResults))
+		assert.False(t, firstAgainPagination.HasPrev)
+		assert.Empty(t, firstAgainPagination.PrevCursor)
+		assert.True(t, firstAgainPagination.HasNext)
+
+		var nextProducts []ProductWithScore
+		_, nextResults, err := PaginateCursor(db, NewCursorParams(firstAgainPagination.NextCursor, 3, "next", "desc"), &nextProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{40, 30, 20}, prices(nextResults))
+	})
+
+	t.Run("navigate backwards ascending", func(t *testing.T) {
+		cursor := ""
+		var lastAsc *CursorPagination
+		for {
+			var products []ProductWithScore
+			pagination, _, err := PaginateCursor(db, NewCursorParams(cursor, 3, "next", "asc"), &products, "price")
+			require.NoError(t, err)
+			lastAsc = pagination
+			if !pagination.HasNext {
+				break
+			}
+			cursor = pagination.NextCursor
+		}
+
+		var prevProducts []ProductWithScore
+		prev, prevResults, err := PaginateCursor(db, NewCursorParams(lastAsc.PrevCursor, 3, "prev", "asc"), &prevProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{40, 50, 60}, prices(prevResults))
+		assert.True(t, prev.HasPrev)
+		assert.True(t, prev.HasNext)
+		assert.NotEmpty(t, prev.PrevCursor)
+		assert.NotEmpty(t, prev.NextCursor)
+
+		var firstProducts []ProductWithScore
+		firstAsc, firstResults, err := PaginateCursor(db, NewCursorParams(prev.PrevCursor, 3, "prev", "asc"), &firstProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, []int{10, 20, 30}, prices(firstResults))
+		assert.False(t, firstAsc.HasPrev)
+		assert.Empty(t, firstAsc.PrevCursor)
+		assert.True(t, firstAsc.HasNext)
+		assert.NotEmpty(t, firstAsc.NextCursor)
+	})
+}
+
+func TestPaginateCursorTotal(t *testing.T) {
+	db := setupCursorTestDB(t)
+	seedProductsWithScore(db, 9) // category_id cycles over 1, 2, 3
+
+	t.Run("disabled by default", func(t *testing.T) {
+		var products []ProductWithScore
+		pagination, _, err := PaginateCursor(db, NewCursorParams("", 2, "next", "asc"), &products, "id")
+		require.NoError(t, err)
+		assert.Zero(t, pagination.Total)
+	})
+
+	t.Run("counts every seeded row", func(t *testing.T) {
+		var products []ProductWithScore
+		first, results, err := PaginateCursor(db, NewCursorParams("", 2, "next", "asc"), &products, "id", TotalOption(true))
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.Equal(t, int64(9), first.Total)
+
+		// the cursor does not change the total
+		var nextProducts []ProductWithScore
+		next, _, err := PaginateCursor(db, NewCursorParams(first.NextCursor, 2, "next", "asc"), &nextProducts, "id", TotalOption(true))
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), next.Total)
+	})
+
+	t.Run("respects filters", func(t *testing.T) {
+		var products []ProductWithScore
+		query := db.Where("category_id = ?", 2)
+		pagination, results, err := PaginateCursor(query, NewCursorParams("", 2, "next", "asc"), &products, "id", TotalOption(true))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), pagination.Total)
+		for _, product := range results {
+			assert.Equal(t, uint(2), product.CategoryID)
+		}
+	})
+
+	t.Run("multi columns", func(t *testing.T) {
+		var products []ProductWithScore
+		pagination, _, err := PaginateCursorMulti(db, NewCursorParams("", 2, "next", "asc"), &products, []string{"category_id", "id"}, TotalOption(true))
+		require.NoError(t, err)
+		assert.Equal(t, int64(9), pagination.Total)
+	})
+}
+
+type ProductWithNullableScore struct {
+	ID    uint     `gorm:"primaryKey" json:"id"`
+	Name  string   `json:"name"`
+	Score *float64 `json:"score"`
+}
+
+func TestPaginateCursorNullableField(t *testing.T) {
+	db := setupCursorTestDB(t)
+	require.NoError(t, db.AutoMigrate(&ProductWithNullableScore{}))
+
+	// scores 1, NULL, 2, 3, NULL, 4
+	for i, score := range []float64{1, 0, 2, 3, 0, 4} {
+		product := ProductWithNullableScore{Name: "Product" + string(rune('A'+i))}
+		if score != 0 {
+			product.Score = &score
+		}
+		require.NoError(t, db.Create(&product).Error)
+	}
+
+	scores := func(products []ProductWithNullableScore) []interface{} {
+		result := make([]interface{}, len(products))
+		for i, p := range products {
+			if p.Score == nil {
+				result[i] = nil
+			} else {
+				result[i] = *p.Score
+			}
+		}
+		return result
+	}
+
+	walk := func(t *testing.T, params CursorParams, opts ...CursorOption) ([]interface{}, []interface{}) {
+		var all []interface{}
+		var lastPrevCursor string
+		for {
+			var products []ProductWithNullableScore
+			pagination, results, err := PaginateCursor(db, params, &products, "score", opts...)
+			require.NoError(t, err)
+			all = append(all, scores(results)...)
+			lastPrevCursor = pagination.PrevCursor
+			if !pagination.HasNext {
+				break
+			}
+			params.Cursor = pagination.NextCursor
+		}
+
+		var products []ProductWithNullableScore
+		_, previous, err := PaginateCursor(db, NewCursorParams(lastPrevCursor, params.PageSize, "prev", params.Order), &products, "score", opts...)
+		require.NoError(t, err)
+		return all, scores(previous)
+	}
+
+	t.Run("nulls last", func(t *testing.T) {
+		all, previous := walk(t, NewCursorParams("", 4, "next", "asc"), NullsOption(NullsLast))
+		assert.Equal(t, []interface{}{1.0, 2.0, 3.0, 4.0, nil, nil}, all)
+		assert.Equal(t, []interface{}{1.0, 2.0, 3.0, 4.0}, previous)
+	})
+
+	t.Run("nulls first", func(t *testing.T) {
+		all, previous := walk(t, NewCursorParams("", 2, "next", "asc"), NullsOption(NullsFirst))
+		assert.Equal(t, []interface{}{nil, nil, 1.0, 2.0, 3.0, 4.0}, all)
+		assert.Equal(t, []interface{}{1.0, 2.0}, previous)
+	})
+
+	t.Run("page boundaries inside the nulls", func(t *testing.T) {
+		all, previous := walk(t, NewCursorParams("", 1, "next", "asc"), NullsOption(NullsLast))
+		assert.Equal(t, []interface{}{1.0, 2.0, 3.0, 4.0, nil, nil}, all)
+		assert.Equal(t, []interface{}{nil}, previous)
+
+		all, previous = walk(t, NewCursorParams("", 1, "next", "asc"), NullsOption(NullsFirst))
+		assert.Equal(t, []interface{}{nil, nil, 1.0, 2.0, 3.0, 4.0}, all)
+		assert.Equal(t, []interface{}{3.0}, previous)
+	})
+
+	t.Run("nulls last descending", func(t *testing.T) {
+		all, _ := walk(t, NewCursorParams("", 3, "next", "desc"), NullsOption(NullsLast))
+		assert.Equal(t, []interface{}{4.0, 3.0, 2.0, 1.0, nil, nil}, all)
+	})
+
+	t.Run("cursor encodes null values", func(t *testing.T) {
+		cursor, err := encodeCursor((*float64)(nil), "score")
+		require.NoError(t, err)
+
+		decoded, err := decodeCursor(cursor)
+		require.NoError(t, err)
+		assert.True(t, decoded.Null)
+		assert.Nil(t, decoded.ID)
+
+		var products []ProductWithNullableScore
+		_, results, err := PaginateCursor(db, NewCursorParams(cursor, 10, "next", "asc"), &products, "score", NullsOption(NullsFirst))
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{1.0, 2.0, 3.0, 4.0}, scores(results))
+	})
+}
+
+func TestCursorPaginationLinkHeader(t *testing.T) {
+	const base = "https://api.example.com/users?order=desc"
+
+	t.Run("first page", func(t *testing.T) {
+		p := CursorPagination{NextCursor: "bmV4dA", HasNext: true, PageSize: 10}
+		assert.Equal(t,
+			`<https://api.example.com/users?order=desc&page_size=10>; rel="first", `+
+				`<https://api.example.com/users?cursor=bmV4dA&direction=next&order=desc&page_size=10>; rel="next"`,
+			p.LinkHeader(base))
+	})
+
+	t.Run("middle page", func(t *testing.T) {
+		p := CursorPagination{NextCursor: "bmV4dA", PrevCursor: "cHJldg", HasNext: true, HasPrev: true, PageSize: 10}
+		assert.Equal(t,
+			`<https://api.example.com/users?order=desc&page_size=10>; rel="first", `+
+				`<https://api.example.com/users?cursor=cHJldg&direction=prev&order=desc&page_size=10>; rel="prev", `+
+				`<https://api.example.com/users?cursor=bmV4dA&direction=next&order=desc&page_size=10>; rel="next"`,
+			p.LinkHeader(base))
+	})
+
+	t.Run("last page", func(t *testing.T) {
+		p := CursorPagination{PrevCursor: "cHJldg==", HasPrev: true, PageSize: 10}
+		// the cursor of the base URL is removed from the first link
+		assert.Equal(t,
+			`<https://api.example.com/users?order=desc&page_size=10>; rel="first", `+
+				`<https://api.example.com/users?cursor=cHJldg%3D%3D&direction=prev&order=desc&page_size=10>; rel="prev"`,
+			p.LinkHeader(base+"&cursor=old&direction=next"))
+	})
+}
+
+func TestCursorParamsWithConfig(t *testing.T) {
+	config := CursorConfig{DefaultPageSize: 50, MaxPageSize: 500}
+
+	tests := []struct {
+		name     string
+		pageSize int
+		config   CursorConfig
+		expected int
+	}{
+		{name: "size above the default max is allowed", pageSize: 250, config: config, expected: 250},
+		{name: "size at custom max is allowed", pageSize: 500, config: config, expected: 500},
+		{name: "size above custom max uses custom default", pageSize: 501, config: config, expected: 50},
+		{name: "zero size uses custom default", pageSize: 0, config: config, expected: 50},
+		{name: "negative size uses custom default", pageSize: -1, config: config, expected: 50},
+		{name: "lower max", pageSize: 20, config: CursorConfig{DefaultPageSize: 5, MaxPageSize: 10}, expected: 5},
+		{name: "zero config uses package defaults", pageSize: 101, config: CursorConfig{}, expected: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := NewCursorParamsWithConfig("abc123", tt.pageSize, "next", "asc", tt.config)
+			assert.Equal(t, CursorParams{Cursor: "abc123", PageSize: tt.expected, Direction: "next", Order: "asc"}, params)
+
+			parsed := ParseCursorParamsWithConfig("abc123", strconv.Itoa(tt.pageSize), "next", "asc", tt.config)
+			assert.Equal(t, params, parsed)
+		})
+	}
+
+	t.Run("unparsable size uses custom default", func(t *testing.T) {
+		params := ParseCursorParamsWithConfig("", "invalid", "", "", config)
+		assert.Equal(t, 50, params.PageSize)
+	})
+}
@@ -81,7 +81,7 @@ func TestPaginationWithDBUtil(t *testing.T) {
 		ctx := context.Background()
 		contextDB := dbutil.DB(ctx, db)
 
-		params := NewCursorParams("", 4, "next")
+		params := NewCursorParams("", 4, "next", "asc")
 		var orders []OrderModel
 		pagination, results, err := PaginateCursor(contextDB, params, &orders, "created_at")
 		require.NoError(t, err)
@@ -147,7 +147,7 @@ func TestPaginationInTransaction(t *testing.T) {
 			require.NoError(t, err)
 
 			// Paginate including the new record
-			params := NewCursorParams("", 5, "next")
+			params := NewCursorParams("", 5, "next", "asc")
 			var orders []OrderModel
 			pagination, results, err := PaginateCursor(txDB, params, &orders, "amount")
 			require.NoError(t, err)
@@ -203,7 +203,7 @@ func TestPaginationWithComplexQueries(t *testing.T) {
 			Where("status != ?", "cancelled").
 			Order("amount DESC")
 
-		params := NewCursorParams("", 4, "next")
+		params := NewCursorParams("", 4, "next", "asc")
 		var orders []OrderModel
 		_, results, err := PaginateCursor(query, params, &orders, "amount")
 		require.NoError(t, err)
@@ -230,7 +230,7 @@ func TestPaginationWithCUID(t *testing.T) {
 		contextDB := dbutil.DB(ctx, db)
 
 		// First page
-		params := NewCursorParams("", 3, "next")
+		params := NewCursorParams("", 3, "next", "asc")
 		var firstPageOrders []OrderModel
 		firstPagination, firstPageResults, err := PaginateCursor(contextDB, params, &firstPageOrders, "id")
 		require.NoError(t, err)
@@ -248,7 +248,7 @@ func TestPaginationWithCUID(t *testing.T) {
 		// Second page using cursor
 		if firstPagination.HasNext {
 			var secondPageOrders []OrderModel
-			nextParams := NewCursorParams(firstPagination.NextCursor, 3, "next")
+			nextParams := NewCursorParams(firstPagination.NextCursor, 3, "next", "asc")
 			secondPagination, secondPageResults, err := PaginateCursor(contextDB, nextParams, &secondPageOrders, "id")
 			require.NoError(t, err)
 
@@ -272,7 +272,7 @@ func TestPaginationWithCUID(t *testing.T) {
 		contextDB := dbutil.DB(ctx, db)
 
 		// Navigate to middle, then go backwards
-		params := NewCursorParams("", 2, "next")
+		params := NewCursorParams("", 2, "next", "asc")
 		var orders []OrderModel
 		firstPagination, _, err := PaginateCursor(contextDB, params, &orders, "id")
 		require.NoError(t, err)
@@ -280,13 +280,13 @@ func TestPaginationWithCUID(t *testing.T) {
 		if firstPagination.HasNext {
 			// Go to second page
 			var secondPageOrders []OrderModel
-			secondParams := NewCursorParams(firstPagination.NextCursor, 2, "next")
+			secondParams := NewCursorParams(firstPagination.NextCursor, 2, "next", "asc")
 			secondPagination, _, err := PaginateCursor(contextDB, secondParams, &secondPageOrders, "id")
 			require.NoError(t, err)
 
 			// Now go backwards
 			var backwardOrders []OrderModel
-			backParams := NewCursorParams(secondPagination.NextCursor, 2, "prev")
+			backParams := NewCursorParams(secondPagination.NextCursor, 2, "prev", "asc")
 			backPagination, backPageResults, err := PaginateCursor(contextDB, backParams, &backwardOrders, "id")
 			require.NoError(t, err)
 
@@ -351,7 +351,7 @@ func TestPaginationServicePattern(t *testing.T) {
 	})
 
 	t.Run("service cursor pagination", func(t *testing.T) {
-		params := NewCursorParams("", 7, "next")
+		params := NewCursorParams("", 7, "next", "asc")
 		pagination, results, err := getOrdersByCursor(ctx, service, params, "created_at")
 		require.NoError(t, err)
 
@@ -398,7 +398,7 @@ func TestPaginationErrorHandling(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to count records")
 
 		// Test cursor pagination error
-		cursorParams := NewCursorParams("", 10, "next")
+		cursorParams := NewCursorParams("", 10, "next", "asc")
 		var cursorOrders []OrderModel
 		cursorPagination, cursorResults, err := PaginateCursor(contextDB, cursorParams, &cursorOrders, "id")
 		assert.Error(t, err)
@@ -415,7 +415,7 @@ func TestPaginationErrorHandling(t *testing.T) {
 		contextDB := dbutil.DB(ctx, db)
 
 		// Test with malformed cursor
-		params := NewCursorParams("invalid-cursor-123", 10, "next")
+		params := NewCursorParams("invalid-cursor-123", 10, "next", "asc")
 		var orders []OrderModel
 		pagination, results, err := PaginateCursor(contextDB, params, &orders, "id")
 
@@ -425,3 +425,38 @@ func TestPaginationErrorHandling(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to decode cursor")
 	})
 }
+
+func TestGenericPagination(t *testing.T) {
+	db := setupIntegrationDB(t)
+	seedOrders(db, 12)
+
+	t.Run("offset", func(t *testing.T) {
+		pagination, orders, err := PaginateT[OrderModel](db.Order("created_at ASC"), NewParams(2, 5))
+		require.NoError(t, err)
+
+		require.Len(t, orders, 5)
+		assert.Equal(t, int64(1500), orders[0].CreatedAt)
+		assert.Equal(t, int64(12), pagination.Total)
+		assert.Equal(t, 3, pagination.TotalPages)
+		assert.True(t, pagination.HasNext)
+		assert.True(t, pagination.HasPrev)
+	})
+
+	t.Run("cursor", func(t *testing.T) {
+		first, orders, err := PaginateCursorT[OrderModel](db, NewCursorParams("", 5, "next", "asc"), "created_at")
+		require.NoError(t, err)
+		require.Len(t, orders, 5)
+		assert.Equal(t, int64(1000), orders[0].CreatedAt)
+		assert.True(t, first.HasNext)
+
+		next, nextOrders, err := PaginateCursorT[OrderModel](db.Where("status <> ?", "cancelled"),
+			NewCursorParams(first.NextCursor, 5, "next", "asc"), "created_at", TotalOption(true))
+		require.NoError(t, err)
+		require.NotEmpty(t, nextOrders)
+		assert.Greater(t, nextOrders[0].CreatedAt, orders[len(orders)-1].CreatedAt)
+		for _, order := range nextOrders {
+			assert.NotEqual(t, "cancelled", order.Status)
+		}
+		assert.Equal(t, int64(9), next.Total)
+	})
+}
@@ -23,7 +23,7 @@
 //
 // # Cursor-based Pagination Example
 //
-//	params := pagination.NewCursorParams("", 20, "next") // first page, 20 items
+//	params := pagination.NewCursorParams("", 20, "next", "asc") // first page, 20 items
 //	var users []User
 //	result, users, err := pagination.PaginateCursor(db, params, &users, "id")
 //	if err != nil {
@@ -31,7 +31,7 @@
 //	}
 //	// Navigate to next page using the cursor
 //	if result.HasNext {
-//		nextParams := pagination.NewCursorParams(result.NextCursor, 20, "next")
+//		nextParams := pagination.NewCursorParams(result.NextCursor, 20, "next", "asc")
 //		// Use nextParams for next request
 //	}
 //
@@ -45,11 +45,12 @@
 //		r.URL.Query().Get("page_size"),
 //	)
 //
-//	// Cursor pagination: GET /users?cursor=abc123&page_size=10&direction=next
+//	// Cursor pagination: GET /users?cursor=abc123&page_size=10&direction=next&order=desc
 //	params := pagination.ParseCursorParams(
 //		r.URL.Query().Get("cursor"),
 //		r.URL.Query().Get("page_size"),
 //		r.URL.Query().Get("direction"),
+//		r.URL.Query().Get("order"),
 //	)
 //
 // # Performance Considerations
@@ -77,7 +78,9 @@ package pagination
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -179,6 +182,75 @@ func Paginate[T any](db *gorm.DB, params Params, dest *[]T) (*Pagination, []T, e
 	return pagination, *dest, nil
 }
 
+// PaginateT performs offset-based pagination like Paginate, allocating and returning the typed slice
+// instead of filling a destination.
+//
+// Example:
+//
+//	pagination, users, err := pagination.PaginateT[User](db, params)
+func PaginateT[T any](db *gorm.DB, params Params) (*Pagination, []T, error) {
+	var dest []T
+	return Paginate(db, params, &dest)
+}
+
+// LinkHeader returns the value of an RFC 5988 Link header pointing at the first, previous, next and
+// last pages. The page and page_size query parameters are set on baseURL, its other query parameters
+// (filters, sorting, ...) are kept. The prev and next links are omitted when there is no such page.
+// An empty string is returned when baseURL can't be parsed.
+//
+// Example:
+//
+//	w.Header().Set("Link", result.LinkHeader("https://api.example.com/users?status=active"))
+func (p *Pagination) LinkHeader(baseURL string) string {
+	pageLink := func(page int) map[string]string {
+		return map[string]string{"page": strconv.Itoa(page), "page_size": strconv.Itoa(p.PageSize)}
+	}
+
+	links := []paginationLink{{rel: "first", params: pageLink(1)}}
+	if p.HasPrev {
+		links = append(links, paginationLink{rel: "prev", params: pageLink(p.Page - 1)})
+	}
+	if p.HasNext {
+		links = append(links, paginationLink{rel: "next", params: pageLink(p.Page + 1)})
+	}
+	if p.TotalPages > 0 {
+		links = append(links, paginationLink{rel: "last", params: pageLink(p.TotalPages)})
+	}
+
+	return linkHeader(baseURL, links)
+}
+
+// paginationLink is a link of a Link header, params are set on the base URL and empty values are removed.
+type paginationLink struct {
+	rel    string
+	params map[string]string
+}
+
+// linkHeader formats links relative to baseURL as a Link header value.
+func linkHeader(baseURL string, links []paginationLink) string {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(links))
+	for _, link := range links {
+		u := *base
+		query := u.Query()
+		for key, value := range link.params {
+			if value == "" {
+				query.Del(key)
+			} else {
+				query.Set(key, value)
+			}
+		}
+		u.RawQuery = query.Encode()
+		parts = append(parts, fmt.Sprintf(`<%s>; rel="%s"`, u.String(), link.rel))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 // ParseParams parses offset pagination parameters from query strings.
 // This is a convenience function for HTTP handlers that need to convert
 // string parameters to validated Params.
@@ -485,3 +485,51 @@ func TestPaginateDatabaseError(t *testing.T) {
 	assert.Nil(t, results)
 	assert.Contains(t, err.Error(), "failed to count records")
 }
+
+func TestPaginationLinkHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination Pagination
+		expected   string
+	}{
+		{
+			name:       "first page",
+			pagination: Pagination{Page: 1, PageSize: 10, Total: 25, TotalPages: 3, HasNext: true},
+			expected: `<https://api.example.com/users?page=1&page_size=10&status=active>; rel="first", ` +
+				`<https://api.example.com/users?page=2&page_size=10&status=active>; rel="next", ` +
+				`<https://api.example.com/users?page=3&page_size=10&status=active>; rel="last"`,
+		},
+		{
+			name:       "middle page",
+			pagination: Pagination{Page: 2, PageSize: 10, Total: 25, TotalPages: 3, HasNext: true, HasPrev: true},
+			expected: `<https://api.example.com/users?page=1&page_size=10&status=active>; rel="first", ` +
+				`<https://api.example.com/users?page=1&page_size=10&status=active>; rel="prev", ` +
+				`<https://api.example.com/users?page=3&page_size=10&status=active>; rel="next", ` +
+				`<https://api.example.com/users?page=3&page_size=10&status=active>; rel="last"`,
+		},
+		{
+			name:       "last page",
+			pagination: Pagination{Page: 3, PageSize: 10, Total: 25, TotalPages: 3, HasPrev: true},
+			expected: `<https://api.example.com/users?page=1&page_size=10&status=active>; rel="first", ` +
+				`<https://api.example.com/users?page=2&page_size=10&status=active>; rel="prev", ` +
+				`<https://api.example.com/users?page=3&page_size=10&status=active>; rel="last"`,
+		},
+		{
+			name:       "empty result",
+			pagination: Pagination{Page: 1, PageSize: 10},
+			expected:   `<https://api.example.com/users?page=1&page_size=10&status=active>; rel="first"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the page of the base URL is replaced
+			assert.Equal(t, tt.expected, tt.pagination.LinkHeader("https://api.example.com/users?status=active&page=7"))
+		})
+	}
+
+	t.Run("invalid base URL", func(t *testing.T) {
+		p := Pagination{Page: 1, PageSize: 10}
+		assert.Equal(t, "", p.LinkHeader("://invalid"))
+	})
+}
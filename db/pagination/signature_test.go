@@ -0,0 +1,93 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func useSigningKey(t *testing.T, key string) {
+	SetCursorSigningKey([]byte(key))
+	t.Cleanup(func() { SetCursorSigningKey(nil) })
+}
+
+func TestSignedCursor(t *testing.T) {
+	t.Run("valid signed cursor", func(t *testing.T) {
+		useSigningKey(t, "secret")
+
+		cursor, err := encodeCursor(42, "id")
+		require.NoError(t, err)
+		assert.Contains(t, cursor, ".")
+
+		decoded, err := decodeCursor(cursor)
+		require.NoError(t, err)
+		assert.Equal(t, float64(42), decoded.ID)
+		assert.Equal(t, "id", decoded.Field)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		useSigningKey(t, "secret")
+
+		cursor, err := encodeCursor(42, "id")
+		require.NoError(t, err)
+		_, signature, _ := strings.Cut(cursor, ".")
+		forged := base64.URLEncoding.EncodeToString([]byte(`{"id":1000,"field":"id"}`))
+
+		decoded, err := decodeCursor(forged + "." + signature)
+		assert.ErrorIs(t, err, ErrInvalidCursorSignature)
+		assert.Nil(t, decoded)
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		useSigningKey(t, "old-secret")
+		cursor, err := encodeCursor(42, "id")
+		require.NoError(t, err)
+
+		useSigningKey(t, "secret")
+		_, err = decodeCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursorSignature)
+	})
+
+	t.Run("unsigned cursor is rejected when signing is enabled", func(t *testing.T) {
+		unsigned, err := encodeCursor(42, "id")
+		require.NoError(t, err)
+
+		useSigningKey(t, "secret")
+		_, err = decodeCursor(unsigned)
+		assert.ErrorIs(t, err, ErrInvalidCursorSignature)
+
+		_, err = decodeMultiCursor(unsigned, []string{"id"})
+		assert.ErrorIs(t, err, ErrInvalidCursorSignature)
+	})
+
+	t.Run("unsigned cursors when signing is disabled", func(t *testing.T) {
+		cursor, err := encodeCursor(42, "id")
+		require.NoError(t, err)
+		assert.NotContains(t, cursor, ".")
+
+		decoded, err := decodeCursor(cursor)
+		require.NoError(t, err)
+		assert.Equal(t, float64(42), decoded.ID)
+	})
+
+	t.Run("paginate with signed cursors", func(t *testing.T) {
+		useSigningKey(t, "secret")
+		db := setupCursorTestDB(t)
+		seedProductsWithScore(db, 5)
+
+		var products []ProductWithScore
+		first, _, err := PaginateCursor(db, NewCursorParams("", 2, "next", "asc"), &products, "price")
+		require.NoError(t, err)
+
+		var nextProducts []ProductWithScore
+		_, results, err := PaginateCursor(db, NewCursorParams(first.NextCursor, 2, "next", "asc"), &nextProducts, "price")
+		require.NoError(t, err)
+		assert.Equal(t, 30, results[0].Price)
+
+		_, _, err = PaginateCursor(db, NewCursorParams(first.NextCursor+"x", 2, "next", "asc"), &nextProducts, "price")
+		assert.ErrorIs(t, err, ErrInvalidCursorSignature)
+	})
+}
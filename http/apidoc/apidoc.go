@@ -16,6 +16,50 @@
 // // goframe:http_route path=/items method=[GET, POST] required_header=Authorization response=ItemResponse
 // func ManageItems() {}
 //
+// Several required headers at once:
+//
+// // goframe:http_route path=/tenants method=GET required_header=[Authorization, X-Tenant-ID]
+// func ListTenants() {}
+//
+// Optional headers are documented but not enforced:
+//
+// // goframe:http_route path=/articles/{id} method=GET required_header=Authorization optional_header=[If-None-Match, Accept-Language]
+// func GetArticle() {}
+//
+// Response headers set by the route, typed in generated clients:
+//
+// // goframe:http_route path=/articles method=GET response_header=[X-Total-Count, ETag]
+// func ListArticles() {}
+//
+// Grouping routes under tags, used as client namespaces (group= is an alias of tag=):
+//
+// // goframe:http_route path=/invoices method=GET tag=[Billing, Reports]
+// func ListInvoices() {}
+//
+// Content types other than JSON for the request body and the response:
+//
+// // goframe:http_route path=/feeds method=POST request_content_type=application/xml response_content_type=application/xml
+// func ImportFeed() {}
+//
+// Content types accepted for the request body, other ones are rejected with 415 by middlewares.Consumes.
+// The first one is the request content type unless request_content_type is set:
+//
+// // goframe:http_route path=/uploads method=POST consumes=[multipart/form-data, application/octet-stream]
+// func Upload() {}
+//
+// Descriptions, rendered as doc comments by generated clients. Values containing spaces are double quoted,
+// with Go escapes:
+//
+// // goframe:http_route path=/users method=GET description="Lists the users of the current tenant."
+// func ListUsers() {}
+//
+// Deprecated routes, flagged in generated clients:
+//
+// // goframe:http_route path=/v1/users method=GET deprecated=true
+// func ListUsersV1() {}
+//
+// Commas inside bracketed list elements are escaped with a backslash: [a\,b, c] holds "a,b" and "c".
+//
 // Named route example:
 //
 // // goframe:http_route path=/orders method=GET name=ListOrders response=OrderListResponse
@@ -36,11 +80,22 @@
 // // goframe:http_route path=/status method=GET response=200-299:OKResponse response=400-499:ClientError response=500-599:ServerError
 // func CheckStatus() {}
 //
+// Open-ended range status response, matching any status from the lower bound up to 599 ("-299" is rejected):
+//
+// // goframe:http_route path=/status method=GET response=200:OKResponse response=400-:ErrorResponse
+// func CheckStatusOrFail() {}
+//
 // Combining headers, named routes, and status responses:
 //
 // // goframe:http_route path=/reports method=GET name=FetchReports required_header=X-Report-Auth response=2xx:ReportResponse response=401:AuthErrorResponse
 // func FetchReports() {}
 //
+// A response declared as an interface of the module is a union of the structs of its package implementing it,
+// told apart by the field tagged discriminator (e.g. Type string `json:"type" discriminator:"card"`):
+//
+// // goframe:http_route path=/payments/{id} method=GET response=Payment
+// func GetPayment() {}
+//
 // Omitting the request the request or response will try to find a type with the same name as the method suffixed with "Request" or "Response" respectively:
 //
 // type RouteResponse struct {}
@@ -49,11 +104,38 @@
 package apidoc
 
 import (
+	"errors"
+	"fmt"
+	"mime"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 )
 
+// DefaultContentType is the content type of request and response bodies when a route doesn't specify one.
+const DefaultContentType = "application/json"
+
+// MediaType returns the lower case media type of a content type, without its parameters, e.g. "text/csv" for
+// "text/csv; charset=utf-8". An empty content type is DefaultContentType.
+func MediaType(contentType string) string {
+	if strings.TrimSpace(contentType) == "" {
+		return DefaultContentType
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType, _, _ = strings.Cut(contentType, ";")
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// IsJSONContentType reports whether bodies of contentType are JSON: application/json, with or without
+// parameters, and structured syntax suffixes like application/problem+json. An empty content type is JSON.
+func IsJSONContentType(contentType string) bool {
+	mediaType := MediaType(contentType)
+	return mediaType == DefaultContentType || strings.HasSuffix(mediaType, "+json")
+}
+
 type RouteDefinition struct {
 	Path   string
 	Method string
@@ -65,7 +147,22 @@ type FromDoc struct {
 	Requests        string
 	Responses       []string
 	RequiredHeaders []string
+	OptionalHeaders []string // documented headers that the route accepts but does not enforce
+	ResponseHeaders []string // headers set on responses, documented for clients
+	Tags            []string // logical groups of the route, empty when the route is not tagged
 	StatusResponses []FromDocStatusToResponse
+	Description     string // free text describing the route, empty when not documented
+	Deprecated      bool   // the route is kept for compatibility and should not be used by new clients
+
+	RequestContentType  string   // content type of the request body, defaults to DefaultContentType
+	ResponseContentType string   // content type of the response body, defaults to DefaultContentType
+	Consumes            []string // content types accepted for the request body, empty when not enforced
+
+	// SourceLines maps each attribute key to the index, in the parsed lines, of the last line defining it.
+	// It is meant for error messages, e.g. "invalid response status on line 3 of GetUser's doc".
+	SourceLines map[string]int
+
+	invalidStatusResponses []string // response=status:Type values whose status could not be parsed
 }
 
 type FromDocStatusToResponse struct {
@@ -74,9 +171,15 @@ type FromDocStatusToResponse struct {
 }
 
 func ParseAPIDocRoute(lines []string) *FromDoc {
-	route := &FromDoc{}
+	route := &FromDoc{
+		Tags:                []string{},
+		Consumes:            []string{},
+		RequestContentType:  DefaultContentType,
+		ResponseContentType: DefaultContentType,
+		SourceLines:         make(map[string]int),
+	}
 
-	for _, line := range lines {
+	for index, line := range lines {
 		line = strings.TrimPrefix(line, "//")
 		line = strings.TrimSpace(line)
 		if !strings.HasPrefix(line, "goframe:http_route") {
@@ -85,16 +188,19 @@ func ParseAPIDocRoute(lines []string) *FromDoc {
 
 		content := strings.TrimSpace(line[len("goframe:http_route"):])
 		pairs := parseKeyValuePairs(content)
+		for key := range pairs {
+			route.SourceLines[key] = index
+		}
 
 		// Handle path-method pairs
-		if path, hasPath := pairs["path"]; hasPath {
+		if path, hasPath := pairs.last("path"); hasPath {
 			methods := []string{"GET"} // default method
-			if method, hasMethod := pairs["method"]; hasMethod {
+			if method, hasMethod := pairs.last("method"); hasMethod {
 				methods = parseList(method)
 			}
 
 			routeName := ""
-			if name, hasName := pairs["name"]; hasName {
+			if name, hasName := pairs.last("name"); hasName {
 				routeName = name
 			}
 
@@ -110,26 +216,116 @@ func ParseAPIDocRoute(lines []string) *FromDoc {
 		}
 
 		// Handle other attributes
-		if request, ok := pairs["request"]; ok {
+		if request, ok := pairs.last("request"); ok {
 			route.Requests = request
 		}
-		if response, ok := pairs["response"]; ok {
+		if description, ok := pairs.last("description"); ok {
+			route.Description = description
+		}
+		if deprecated, ok := pairs.last("deprecated"); ok {
+			route.Deprecated, _ = strconv.ParseBool(deprecated)
+		}
+		if contentType, ok := pairs.last("request_content_type"); ok && contentType != "" {
+			route.RequestContentType = contentType
+		}
+		if contentType, ok := pairs.last("response_content_type"); ok && contentType != "" {
+			route.ResponseContentType = contentType
+		}
+		for _, response := range pairs["response"] {
 			if statusResponse := parseStatusResponse(response); statusResponse != nil {
 				route.StatusResponses = append(route.StatusResponses, *statusResponse)
+			} else if strings.Contains(response, ":") && !strings.HasPrefix(response, "[") {
+				route.invalidStatusResponses = append(route.invalidStatusResponses, response)
 			} else {
 				route.Responses = append(route.Responses, parseList(response)...)
 			}
 		}
-		if header, ok := pairs["required_header"]; ok {
-			route.RequiredHeaders = append(route.RequiredHeaders, header)
+		for _, header := range pairs["required_header"] {
+			route.RequiredHeaders = append(route.RequiredHeaders, parseList(header)...)
+		}
+		for _, header := range pairs["optional_header"] {
+			route.OptionalHeaders = append(route.OptionalHeaders, parseList(header)...)
+		}
+		for _, header := range pairs["response_header"] {
+			route.ResponseHeaders = append(route.ResponseHeaders, parseList(header)...)
+		}
+		for _, consumes := range pairs["consumes"] {
+			for _, contentType := range parseList(consumes) {
+				if contentType != "" && !contains(route.Consumes, contentType) {
+					route.Consumes = append(route.Consumes, contentType)
+				}
+			}
+		}
+		for _, key := range []string{"tag", "group"} {
+			for _, tag := range pairs[key] {
+				for _, t := range parseList(tag) {
+					if !contains(route.Tags, t) {
+						route.Tags = append(route.Tags, t)
+					}
+				}
+			}
 		}
 	}
 
+	// The declared request content type is the first accepted one when not set explicitly
+	if _, ok := route.SourceLines["request_content_type"]; !ok && len(route.Consumes) > 0 {
+		route.RequestContentType = route.Consumes[0]
+	}
+
 	return route
 }
 
-func parseKeyValuePairs(content string) map[string]string {
-	pairs := make(map[string]string)
+// Validate reports duplicated route definitions, i.e. several definitions sharing the same path and method,
+// and status responses whose status can't be parsed (e.g. "499-200:ErrorResponse").
+// The returned error mentions the names of the offending definitions when they are named.
+func (d *FromDoc) Validate() error {
+	var errs []error
+	for _, response := range d.invalidStatusResponses {
+		errs = append(errs, fmt.Errorf("invalid status in response %s", response))
+	}
+	seen := make(map[string]RouteDefinition)
+	for _, def := range d.Routes {
+		key := def.Method + " " + def.Path
+		first, exists := seen[key]
+		if !exists {
+			seen[key] = def
+			continue
+		}
+		errs = append(errs, fmt.Errorf("duplicate route %s%s", key, describeDuplicate(first.Name, def.Name)))
+	}
+	return errors.Join(errs...)
+}
+
+// describeDuplicate formats the names of two colliding routes, names are optional.
+func describeDuplicate(first, second string) string {
+	if first == "" && second == "" {
+		return ""
+	}
+	if first == "" {
+		first = "<unnamed>"
+	}
+	if second == "" {
+		second = "<unnamed>"
+	}
+	return fmt.Sprintf(" (defined by %s and %s)", first, second)
+}
+
+// keyValuePairs holds the values of each key found on an annotation line.
+// A key may appear several times on the same line (e.g. response=200:A response=401:B),
+// values are kept in the order they were written.
+type keyValuePairs map[string][]string
+
+// last returns the last value written for key, later occurrences override earlier ones.
+func (p keyValuePairs) last(key string) (string, bool) {
+	values, ok := p[key]
+	if !ok || len(values) == 0 {
+		return "", false
+	}
+	return values[len(values)-1], true
+}
+
+func parseKeyValuePairs(content string) keyValuePairs {
+	pairs := make(keyValuePairs)
 	i := 0
 	for i < len(content) {
 		// Skip whitespace
@@ -154,14 +350,31 @@ func parseKeyValuePairs(content string) map[string]string {
 		// Find value
 		valueStart := i
 		if i < len(content) && content[i] == '[' {
-			// Handle bracketed values
+			// Handle bracketed values, escaped characters (e.g. \]) don't close the list
 			i++
 			for i < len(content) && content[i] != ']' {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+				}
 				i++
 			}
 			if i < len(content) {
 				i++ // skip ']'
 			}
+		} else if i < len(content) && content[i] == '"' {
+			// Handle quoted values, which may contain spaces and escaped quotes (e.g. \")
+			i++
+			for i < len(content) && content[i] != '"' {
+				if content[i] == '\\' && i+1 < len(content) {
+					i++
+				}
+				i++
+			}
+			if i < len(content) {
+				i++ // skip '"'
+			}
+			pairs[key] = append(pairs[key], unquoteValue(content[valueStart:i]))
+			continue
 		} else {
 			// Handle regular values (until space or end)
 			for i < len(content) && !unicode.IsSpace(rune(content[i])) {
@@ -169,11 +382,20 @@ func parseKeyValuePairs(content string) map[string]string {
 			}
 		}
 		value := strings.TrimSpace(content[valueStart:i])
-		pairs[key] = value
+		pairs[key] = append(pairs[key], value)
 	}
 	return pairs
 }
 
+// unquoteValue removes the quotes of a quoted value and interprets its Go escapes. A value that is not
+// a valid Go string, e.g. one missing its closing quote, is returned without its quotes.
+func unquoteValue(value string) string {
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		return unquoted
+	}
+	return strings.Trim(value, `"`)
+}
+
 func parseList(value string) []string {
 	value = strings.TrimSpace(value)
 	if value == "" {
@@ -186,7 +408,7 @@ func parseList(value string) []string {
 		if value == "" {
 			return nil
 		}
-		parts := strings.Split(value, ",")
+		parts := splitEscaped(value, ',')
 		result := make([]string, 0, len(parts))
 		for _, part := range parts {
 			part = strings.TrimSpace(part)
@@ -201,6 +423,28 @@ func parseList(value string) []string {
 	return []string{value}
 }
 
+// splitEscaped splits value on sep, a backslash escapes the next character so that \, is kept as a
+// literal comma inside an element. Backslashes before any other character are preserved.
+func splitEscaped(value string, sep byte) []string {
+	var parts []string
+	var current strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) && (value[i+1] == sep || value[i+1] == ']' || value[i+1] == '\\') {
+			current.WriteByte(value[i+1])
+			i++
+			continue
+		}
+		if c == sep {
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteByte(c)
+	}
+	return append(parts, current.String())
+}
+
 func parseStatusResponse(value string) *FromDocStatusToResponse {
 	value = strings.TrimSpace(value)
 	colonIndex := strings.Index(value, ":")
@@ -222,22 +466,106 @@ func parseStatusResponse(value string) *FromDocStatusToResponse {
 	}
 }
 
+// Bounds of the HTTP status codes matched by status ranges.
+const (
+	minStatusCode = 100
+	maxStatusCode = 599
+)
+
+// parseStatusCode parses a three digits HTTP status code.
+func parseStatusCode(value string) (int, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) != 3 {
+		return 0, false
+	}
+	code, err := strconv.Atoi(value)
+	if err != nil || code < minStatusCode || code > maxStatusCode {
+		return 0, false
+	}
+	return code, true
+}
+
+// statusRangePattern builds a regex group matching any three digits status code in [start, end].
+// Full hundreds and tens are collapsed, e.g. 404-599 gives (?:40[4-9]|4[1-9]\d|5\d\d).
+func statusRangePattern(start, end int) string {
+	if start > end {
+		return ""
+	}
+
+	var alternatives []string
+	for hundred := start / 100; hundred <= end/100; hundred++ {
+		lo := max(start, hundred*100) % 100
+		hi := min(end, hundred*100+99) % 100
+		if lo == 0 && hi == 99 {
+			// Merge consecutive full hundreds
+			last := hundred
+			for last+1 <= end/100 && min(end, (last+1)*100+99)%100 == 99 {
+				last++
+			}
+			alternatives = append(alternatives, digitClass(hundred, last)+`\d\d`)
+			hundred = last
+			continue
+		}
+		for _, tens := range twoDigitsRangePatterns(lo, hi) {
+			alternatives = append(alternatives, strconv.Itoa(hundred)+tens)
+		}
+	}
+	return "(?:" + strings.Join(alternatives, "|") + ")"
+}
+
+// twoDigitsRangePatterns returns the patterns matching two digits numbers in [lo, hi].
+func twoDigitsRangePatterns(lo, hi int) []string {
+	var patterns []string
+	for tens := lo / 10; tens <= hi/10; tens++ {
+		first := max(lo, tens*10) % 10
+		last := min(hi, tens*10+9) % 10
+		if first == 0 && last == 9 {
+			// Merge consecutive full tens
+			lastTens := tens
+			for lastTens+1 <= hi/10 && min(hi, (lastTens+1)*10+9)%10 == 9 {
+				lastTens++
+			}
+			patterns = append(patterns, digitClass(tens, lastTens)+`\d`)
+			tens = lastTens
+			continue
+		}
+		patterns = append(patterns, strconv.Itoa(tens)+digitClass(first, last))
+	}
+	return patterns
+}
+
+// digitClass returns the pattern matching a single digit in [lo, hi].
+func digitClass(lo, hi int) string {
+	switch {
+	case lo == hi:
+		return strconv.Itoa(lo)
+	case lo == 0 && hi == 9:
+		return `\d`
+	default:
+		return fmt.Sprintf("[%d-%d]", lo, hi)
+	}
+}
+
 func convertStatusToRegex(status string) *regexp.Regexp {
 	var pattern string
 
 	switch {
+	case strings.HasPrefix(status, "-"):
+		// Ranges without a lower bound like "-299" are ambiguous and rejected
+		return nil
+	case strings.HasSuffix(status, "-"):
+		// Open-ended ranges like "400-" match any status from the lower bound up to 599
+		if start, ok := parseStatusCode(strings.TrimSuffix(status, "-")); ok {
+			pattern = statusRangePattern(start, maxStatusCode)
+		}
 	case strings.Contains(status, "-"):
-		// Handle ranges like "200-299"
+		// Handle ranges like "200-299" or "200-499", bounds are inclusive
 		parts := strings.Split(status, "-")
 		if len(parts) == 2 {
-			start := strings.TrimSpace(parts[0])
-			end := strings.TrimSpace(parts[1])
-			if len(start) == 3 && len(end) == 3 {
-				startPrefix := start[:2]
-				endPrefix := end[:2]
-				if startPrefix == endPrefix {
-					pattern = startPrefix + "[" + start[2:] + "-" + end[2:] + "]"
-				}
+			start, startOk := parseStatusCode(parts[0])
+			end, endOk := parseStatusCode(parts[1])
+			if startOk && endOk && start <= end {
+				pattern = statusRangePattern(start, end)
 			}
 		}
 	case strings.Contains(status, "x") || strings.Contains(status, "X"):
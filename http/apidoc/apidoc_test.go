@@ -0,0 +1,462 @@
+package apidoc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseAPIDocRouteRequiredHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected []string
+	}{
+		{
+			name:     "single value",
+			lines:    []string{"// goframe:http_route path=/users method=GET required_header=Authorization"},
+			expected: []string{"Authorization"},
+		},
+		{
+			name:     "bracketed list",
+			lines:    []string{"// goframe:http_route path=/users method=GET required_header=[Authorization, X-Tenant-ID, X-Request-ID]"},
+			expected: []string{"Authorization", "X-Tenant-ID", "X-Request-ID"},
+		},
+		{
+			name:     "bracketed list with extra whitespace",
+			lines:    []string{"// goframe:http_route path=/users required_header=[  Authorization ,X-Tenant-ID  ]"},
+			expected: []string{"Authorization", "X-Tenant-ID"},
+		},
+		{
+			name:     "bracketed list mixed with standalone value on the same line",
+			lines:    []string{"// goframe:http_route path=/users required_header=[Authorization, X-Tenant-ID] required_header=X-Request-ID"},
+			expected: []string{"Authorization", "X-Tenant-ID", "X-Request-ID"},
+		},
+		{
+			name: "values spread across lines",
+			lines: []string{
+				"// goframe:http_route path=/users required_header=Authorization",
+				"// goframe:http_route required_header=[X-Tenant-ID]",
+			},
+			expected: []string{"Authorization", "X-Tenant-ID"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseAPIDocRoute(tt.lines)
+			if !reflect.DeepEqual(doc.RequiredHeaders, tt.expected) {
+				t.Errorf("Expected RequiredHeaders to be %v, got %v", tt.expected, doc.RequiredHeaders)
+			}
+		})
+	}
+}
+
+func TestParseAPIDocRouteMultipleStatusResponses(t *testing.T) {
+	doc := ParseAPIDocRoute([]string{
+		"// goframe:http_route path=/login method=POST response=200:LoginSuccessResponse response=401:ErrorResponse",
+	})
+
+	if len(doc.StatusResponses) != 2 {
+		t.Fatalf("Expected 2 status responses, got %d", len(doc.StatusResponses))
+	}
+	if doc.StatusResponses[0].Response != "LoginSuccessResponse" || !doc.StatusResponses[0].StatusPattern.MatchString("200") {
+		t.Errorf("Unexpected first status response: %+v", doc.StatusResponses[0])
+	}
+	if doc.StatusResponses[1].Response != "ErrorResponse" || !doc.StatusResponses[1].StatusPattern.MatchString("401") {
+		t.Errorf("Unexpected second status response: %+v", doc.StatusResponses[1])
+	}
+}
+
+func TestParseAPIDocRouteOptionalHeaders(t *testing.T) {
+	tests := []struct {
+		name             string
+		lines            []string
+		expectedRequired []string
+		expectedOptional []string
+		expectedResponse []string
+	}{
+		{
+			name:             "single value",
+			lines:            []string{"// goframe:http_route path=/articles method=GET optional_header=If-None-Match"},
+			expectedOptional: []string{"If-None-Match"},
+		},
+		{
+			name:             "bracketed list",
+			lines:            []string{"// goframe:http_route path=/articles method=GET optional_header=[If-None-Match, Accept-Language]"},
+			expectedOptional: []string{"If-None-Match", "Accept-Language"},
+		},
+		{
+			name:             "required and optional are kept apart",
+			lines:            []string{"// goframe:http_route path=/articles required_header=[Authorization, X-Tenant-ID] optional_header=If-None-Match"},
+			expectedRequired: []string{"Authorization", "X-Tenant-ID"},
+			expectedOptional: []string{"If-None-Match"},
+		},
+		{
+			name:             "response headers are kept apart",
+			lines:            []string{"// goframe:http_route path=/articles optional_header=If-None-Match response_header=[X-Total-Count, ETag]"},
+			expectedOptional: []string{"If-None-Match"},
+			expectedResponse: []string{"X-Total-Count", "ETag"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseAPIDocRoute(tt.lines)
+			if !reflect.DeepEqual(doc.RequiredHeaders, tt.expectedRequired) {
+				t.Errorf("Expected RequiredHeaders to be %v, got %v", tt.expectedRequired, doc.RequiredHeaders)
+			}
+			if !reflect.DeepEqual(doc.OptionalHeaders, tt.expectedOptional) {
+				t.Errorf("Expected OptionalHeaders to be %v, got %v", tt.expectedOptional, doc.OptionalHeaders)
+			}
+			if !reflect.DeepEqual(doc.ResponseHeaders, tt.expectedResponse) {
+				t.Errorf("Expected ResponseHeaders to be %v, got %v", tt.expectedResponse, doc.ResponseHeaders)
+			}
+		})
+	}
+}
+
+func TestFromDocValidate(t *testing.T) {
+	t.Run("duplicated path and method", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{
+			"// goframe:http_route path=/users method=GET name=ListUsers",
+			"// goframe:http_route path=/users method=GET name=SearchUsers",
+		})
+
+		err := doc.Validate()
+		if err == nil {
+			t.Fatal("Expected an error for duplicated GET /users")
+		}
+		for _, expected := range []string{"GET /users", "ListUsers", "SearchUsers"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Errorf("Expected error to mention %q, got %q", expected, err.Error())
+			}
+		}
+	})
+
+	t.Run("same path with distinct methods", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{
+			"// goframe:http_route path=/users method=GET",
+			"// goframe:http_route path=/users method=POST",
+		})
+
+		if err := doc.Validate(); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestValidateRoutes(t *testing.T) {
+	handler := "UserHandler"
+	routes := []*Route{
+		{Name: "ListUsers", ParentStructName: &handler, Paths: map[string][]string{"/users": {"GET", "POST"}}},
+		{Name: "SearchUsers", ParentStructName: &handler, Paths: map[string][]string{"/users": {"GET"}}},
+	}
+
+	err := ValidateRoutes(routes)
+	if err == nil {
+		t.Fatal("Expected an error for duplicated GET /users across handlers")
+	}
+	if !strings.Contains(err.Error(), "UserHandler.ListUsers and UserHandler.SearchUsers") {
+		t.Errorf("Expected error to mention both handlers, got %q", err.Error())
+	}
+
+	if err := ValidateRoutes(routes[:1]); err != nil {
+		t.Errorf("Expected no error for a single route, got %v", err)
+	}
+}
+
+func TestParseAPIDocRouteTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    []string
+		expected []string
+	}{
+		{
+			name:     "no tags",
+			lines:    []string{"// goframe:http_route path=/users method=GET"},
+			expected: []string{},
+		},
+		{
+			name:     "single tag",
+			lines:    []string{"// goframe:http_route path=/users method=GET tag=Users"},
+			expected: []string{"Users"},
+		},
+		{
+			name:     "bracketed list of tags",
+			lines:    []string{"// goframe:http_route path=/invoices method=GET tag=[Billing, Reports]"},
+			expected: []string{"Billing", "Reports"},
+		},
+		{
+			name: "group alias and duplicates across lines",
+			lines: []string{
+				"// goframe:http_route path=/invoices method=GET tag=Billing",
+				"// goframe:http_route group=[Billing, Admin]",
+			},
+			expected: []string{"Billing", "Admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseAPIDocRoute(tt.lines)
+			if !reflect.DeepEqual(doc.Tags, tt.expected) {
+				t.Errorf("Expected Tags to be %v, got %v", tt.expected, doc.Tags)
+			}
+		})
+	}
+}
+
+func TestParseAPIDocRouteContentTypes(t *testing.T) {
+	t.Run("defaults to JSON", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{"// goframe:http_route path=/users method=POST"})
+		if doc.RequestContentType != DefaultContentType {
+			t.Errorf("Expected RequestContentType to be %q, got %q", DefaultContentType, doc.RequestContentType)
+		}
+		if doc.ResponseContentType != DefaultContentType {
+			t.Errorf("Expected ResponseContentType to be %q, got %q", DefaultContentType, doc.ResponseContentType)
+		}
+	})
+
+	t.Run("explicit XML", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{
+			"// goframe:http_route path=/feeds method=POST request_content_type=application/xml",
+			"// goframe:http_route response_content_type=application/xml",
+		})
+		if doc.RequestContentType != "application/xml" {
+			t.Errorf("Expected RequestContentType to be application/xml, got %q", doc.RequestContentType)
+		}
+		if doc.ResponseContentType != "application/xml" {
+			t.Errorf("Expected ResponseContentType to be application/xml, got %q", doc.ResponseContentType)
+		}
+	})
+}
+
+func TestParseAPIDocRouteConsumes(t *testing.T) {
+	t.Run("not enforced by default", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{"// goframe:http_route path=/users method=POST"})
+		if len(doc.Consumes) != 0 {
+			t.Errorf("Expected no accepted content types, got %v", doc.Consumes)
+		}
+	})
+
+	t.Run("list sets the request content type", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{
+			"// goframe:http_route path=/uploads method=POST consumes=[multipart/form-data, application/octet-stream]",
+			"// goframe:http_route consumes=multipart/form-data",
+		})
+		expected := []string{"multipart/form-data", "application/octet-stream"}
+		if !reflect.DeepEqual(doc.Consumes, expected) {
+			t.Errorf("Expected Consumes to be %v, got %v", expected, doc.Consumes)
+		}
+		if doc.RequestContentType != "multipart/form-data" {
+			t.Errorf("Expected RequestContentType to be multipart/form-data, got %q", doc.RequestContentType)
+		}
+	})
+
+	t.Run("explicit request content type wins", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{
+			"// goframe:http_route path=/feeds method=POST consumes=[application/xml, text/xml] request_content_type=text/xml",
+		})
+		if doc.RequestContentType != "text/xml" {
+			t.Errorf("Expected RequestContentType to be text/xml, got %q", doc.RequestContentType)
+		}
+	})
+}
+
+func TestParseListEscapedCommas(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{name: "escaped comma", value: `[a\,b, c]`, expected: []string{"a,b", "c"}},
+		{name: "escaped closing bracket and backslash", value: `[a\]b, c\\]`, expected: []string{"a]b", `c\`}},
+		{name: "other backslashes are kept", value: `[a\nb]`, expected: []string{`a\nb`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseList(tt.value); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("through an annotation", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{`// goframe:http_route path=/users optional_header=[a\,b, c] required_header=[d\], e]`})
+		if !reflect.DeepEqual(doc.OptionalHeaders, []string{"a,b", "c"}) {
+			t.Errorf("Expected OptionalHeaders to be [a,b c], got %v", doc.OptionalHeaders)
+		}
+		if !reflect.DeepEqual(doc.RequiredHeaders, []string{"d]", "e"}) {
+			t.Errorf("Expected RequiredHeaders to be [d] e], got %v", doc.RequiredHeaders)
+		}
+	})
+}
+
+func TestParseAPIDocRouteSourceLines(t *testing.T) {
+	doc := ParseAPIDocRoute([]string{
+		"// GetUser returns a user.",
+		"// goframe:http_route path=/users/{id} method=GET",
+		"// goframe:http_route required_header=Authorization",
+		"//",
+		"// goframe:http_route response=200:UserResponse response=404:ErrorResponse",
+		"// goframe:http_route path=/v2/users/{id}",
+	})
+
+	expected := map[string]int{
+		"path":            5,
+		"method":          1,
+		"required_header": 2,
+		"response":        4,
+	}
+	if !reflect.DeepEqual(doc.SourceLines, expected) {
+		t.Errorf("Expected SourceLines to be %v, got %v", expected, doc.SourceLines)
+	}
+}
+
+func TestConvertStatusToRegexRanges(t *testing.T) {
+	tests := []struct {
+		status     string
+		matches    []string
+		notMatches []string
+	}{
+		{status: "200-209", matches: []string{"200", "204", "209"}, notMatches: []string{"199", "210"}},
+		{status: "401-403", matches: []string{"401", "402", "403"}, notMatches: []string{"400", "404"}},
+		{status: "400-", matches: []string{"400", "451", "503", "599"}, notMatches: []string{"399", "200", "600"}},
+		{status: "500-", matches: []string{"500", "599"}, notMatches: []string{"499", "600"}},
+		{status: "200-299", matches: []string{"200", "250", "299"}, notMatches: []string{"199", "300"}},
+		{status: "200-499", matches: []string{"200", "299", "301", "404", "499"}, notMatches: []string{"199", "500", "2000"}},
+		{status: "204-206", matches: []string{"204", "205", "206"}, notMatches: []string{"203", "207", "200"}},
+		{status: "299-301", matches: []string{"299", "300", "301"}, notMatches: []string{"298", "302", "309"}},
+		{status: "404-", matches: []string{"404", "409", "410", "499", "500"}, notMatches: []string{"400", "403"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			regex := convertStatusToRegex(tt.status)
+			if regex == nil {
+				t.Fatalf("Expected a regex for %q", tt.status)
+			}
+			for _, code := range tt.matches {
+				if !regex.MatchString(code) {
+					t.Errorf("Expected %s to match %s (%s)", tt.status, code, regex)
+				}
+			}
+			for _, code := range tt.notMatches {
+				if regex.MatchString(code) {
+					t.Errorf("Expected %s not to match %s (%s)", tt.status, code, regex)
+				}
+			}
+		})
+	}
+
+	for _, malformed := range []string{"-500", "-299", "abc-", "700-", "50-", "499-200", "200-600"} {
+		if regex := convertStatusToRegex(malformed); regex != nil {
+			t.Errorf("Expected %q to be rejected, got %s", malformed, regex)
+		}
+	}
+}
+
+func TestConvertStatusToRegexRangeExhaustive(t *testing.T) {
+	for _, bounds := range [][2]int{{200, 499}, {204, 206}, {299, 301}, {150, 250}, {100, 599}, {510, 510}} {
+		regex := convertStatusToRegex(fmt.Sprintf("%d-%d", bounds[0], bounds[1]))
+		if regex == nil {
+			t.Fatalf("Expected a regex for %d-%d", bounds[0], bounds[1])
+		}
+		for code := 100; code <= 599; code++ {
+			inRange := code >= bounds[0] && code <= bounds[1]
+			if regex.MatchString(strconv.Itoa(code)) != inRange {
+				t.Errorf("Range %d-%d: expected match(%d) to be %v (%s)", bounds[0], bounds[1], code, inRange, regex)
+			}
+		}
+	}
+}
+
+func TestFromDocValidateInvalidStatusRange(t *testing.T) {
+	doc := ParseAPIDocRoute([]string{
+		"// goframe:http_route path=/users method=GET response=499-200:ErrorResponse",
+	})
+
+	err := doc.Validate()
+	if err == nil || !strings.Contains(err.Error(), "499-200:ErrorResponse") {
+		t.Errorf("Expected an error mentioning the invalid status range, got %v", err)
+	}
+	if len(doc.Responses) != 0 {
+		t.Errorf("Expected the invalid status response not to be treated as a regular response, got %v", doc.Responses)
+	}
+}
+
+func TestParseAPIDocRouteDescription(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      []string
+		expected   string
+		deprecated bool
+	}{
+		{
+			name:     "not documented",
+			lines:    []string{"// goframe:http_route path=/users method=GET"},
+			expected: "",
+		},
+		{
+			name:     "quoted with spaces",
+			lines:    []string{`// goframe:http_route path=/users method=GET description="Lists the users, newest first." tag=Users`},
+			expected: "Lists the users, newest first.",
+		},
+		{
+			name:     "escaped quotes",
+			lines:    []string{`// goframe:http_route path=/users description="Lists \"active\" users"`},
+			expected: `Lists "active" users`,
+		},
+		{
+			name:       "deprecated",
+			lines:      []string{"// goframe:http_route path=/v1/users description=Legacy deprecated=true"},
+			expected:   "Legacy",
+			deprecated: true,
+		},
+		{
+			name:     "not deprecated",
+			lines:    []string{"// goframe:http_route path=/users deprecated=false"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseAPIDocRoute(tt.lines)
+			if doc.Description != tt.expected {
+				t.Errorf("Expected Description to be %q, got %q", tt.expected, doc.Description)
+			}
+			if doc.Deprecated != tt.deprecated {
+				t.Errorf("Expected Deprecated to be %v, got %v", tt.deprecated, doc.Deprecated)
+			}
+		})
+	}
+
+	t.Run("following values are kept", func(t *testing.T) {
+		doc := ParseAPIDocRoute([]string{`// goframe:http_route path=/users description="List users" tag=Users`})
+		if !reflect.DeepEqual(doc.Tags, []string{"Users"}) {
+			t.Errorf("Expected Tags to be [Users], got %v", doc.Tags)
+		}
+	})
+}
+
+func TestIsJSONContentType(t *testing.T) {
+	for contentType, expected := range map[string]bool{
+		"":                                true,
+		"application/json":                true,
+		"application/json; charset=utf-8": true,
+		"Application/JSON":                true,
+		"application/problem+json":        true,
+		"application/xml":                 false,
+		"text/plain; charset=utf-8":       false,
+		"application/jsonl":               false,
+	} {
+		if got := IsJSONContentType(contentType); got != expected {
+			t.Errorf("IsJSONContentType(%q): expected %v, got %v", contentType, expected, got)
+		}
+	}
+}
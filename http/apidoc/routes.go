@@ -1,9 +1,11 @@
 package apidoc
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/alexisvisco/goframe/core/helpers/introspect"
@@ -19,6 +21,15 @@ type Route struct {
 	Request          *introspect.ObjectType
 	StatusToResponse []StatusToResponse
 	RequiredHeaders  []string
+	OptionalHeaders  []string
+	ResponseHeaders  []string // headers set on responses, see FromDoc.ResponseHeaders
+	Tags             []string // logical groups of the route, see FromDoc.Tags
+	Description      string   // see FromDoc.Description
+	Deprecated       bool     // see FromDoc.Deprecated
+
+	RequestContentType  string   // content type of the request body, see FromDoc.RequestContentType
+	ResponseContentType string   // content type of the response body, see FromDoc.ResponseContentType
+	Consumes            []string // content types accepted for the request body, see FromDoc.Consumes
 }
 
 type StatusToResponse struct {
@@ -38,13 +49,13 @@ type StatusToResponse struct {
 // - If no response type is specified in comments, looks for {methodName}Response struct
 // - Default types are optional and won't cause errors if they don't exist
 func ParseRoute(rootPath, relPkgPath, structName, method string) (*Route, error) {
-	ctx := &introspect.ParseContext{
-		Visited:     make(map[string]*introspect.ObjectType),
-		Enums:       make(map[string]*introspect.FieldTypeEnum),
-		Packages:    make(map[string]*packages.Package),
-		EnumsParsed: make(map[string]bool),
-		RootPath:    rootPath,
-	}
+	return ParseRouteWithParser(introspect.NewParser(rootPath), relPkgPath, structName, method)
+}
+
+// ParseRouteWithParser is like ParseRoute, reusing the packages and types already loaded by parser.
+// Share a parser between the routes of a module to avoid loading the same packages for each route.
+func ParseRouteWithParser(parser *introspect.Parser, relPkgPath, structName, method string) (*Route, error) {
+	ctx := parser.ParseContext
 
 	// Load the package
 	pkg, err := ctx.LoadPackage(relPkgPath)
@@ -66,6 +77,9 @@ func ParseRoute(rootPath, relPkgPath, structName, method string) (*Route, error)
 	}
 
 	fromDoc := ParseAPIDocRoute(methodComments)
+	if err := fromDoc.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid route documentation for %s: %w", method, err)
+	}
 
 	// Build import map for resolving types
 	imports := buildImportMap(pkg)
@@ -195,9 +209,56 @@ func ParseRoute(rootPath, relPkgPath, structName, method string) (*Route, error)
 		Request:          requests,
 		StatusToResponse: statusResponses,
 		RequiredHeaders:  fromDoc.RequiredHeaders,
+		OptionalHeaders:  fromDoc.OptionalHeaders,
+		ResponseHeaders:  fromDoc.ResponseHeaders,
+		Tags:             fromDoc.Tags,
+		Description:      fromDoc.Description,
+		Deprecated:       fromDoc.Deprecated,
+
+		RequestContentType:  fromDoc.RequestContentType,
+		Consumes:            fromDoc.Consumes,
+		ResponseContentType: fromDoc.ResponseContentType,
 	}, nil
 }
 
+// ValidateRoutes reports path and method combinations declared by more than one route,
+// which would otherwise produce colliding generated client functions.
+func ValidateRoutes(routes []*Route) error {
+	var errs []error
+	seen := make(map[string]string) // "METHOD path" -> qualified route name
+	for _, route := range routes {
+		for _, path := range sortedKeys(route.Paths) {
+			for _, method := range route.Paths[path] {
+				key := method + " " + path
+				if first, exists := seen[key]; exists {
+					errs = append(errs, fmt.Errorf("duplicate route %s%s", key, describeDuplicate(first, route.QualifiedName())))
+					continue
+				}
+				seen[key] = route.QualifiedName()
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// QualifiedName returns the route handler name prefixed by its parent struct if any (e.g. UserHandler.GetUser).
+func (r *Route) QualifiedName() string {
+	if r.ParentStructName != nil && *r.ParentStructName != "" {
+		return *r.ParentStructName + "." + r.Name
+	}
+	return r.Name
+}
+
+// sortedKeys returns the keys of a paths map in a stable order.
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Helper function to check if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
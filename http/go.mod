@@ -5,6 +5,7 @@ go 1.24
 require (
 	github.com/alexisvisco/goframe/core v0.0.0-20250807165130-a40d8d39823e
 	golang.org/x/tools v0.35.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
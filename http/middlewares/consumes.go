@@ -0,0 +1,40 @@
+package middlewares
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Consumes rejects requests having a body whose Content-Type is not one of contentTypes with
+// 415 Unsupported Media Type, before the handler binds it. Parameters such as charset or boundary
+// are ignored. Requests without a body are let through.
+// It enforces the consumes= key of goframe:http_route annotations, see apidoc.FromDoc.Consumes.
+func Consumes(contentTypes ...string) func(http.Handler) http.Handler {
+	accepted := make(map[string]bool, len(contentTypes))
+	for _, contentType := range contentTypes {
+		accepted[strings.ToLower(strings.TrimSpace(contentType))] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(accepted) == 0 || !hasBody(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || !accepted[mediaType] {
+				w.Header().Set("Accept", strings.Join(contentTypes, ", "))
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && (r.ContentLength != 0 || len(r.TransferEncoding) > 0)
+}
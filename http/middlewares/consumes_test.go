@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestConsumes(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		status      int
+	}{
+		{name: "matching media type", method: http.MethodPost, contentType: "application/json", body: `{}`, status: http.StatusOK},
+		{name: "matching media type with charset", method: http.MethodPost, contentType: "application/json; charset=utf-8", body: `{}`, status: http.StatusOK},
+		{name: "matching media type case insensitive", method: http.MethodPut, contentType: "Application/JSON", body: `{}`, status: http.StatusOK},
+		{name: "second media type", method: http.MethodPost, contentType: "multipart/form-data; boundary=xyz", body: "--xyz--", status: http.StatusOK},
+		{name: "mismatching media type", method: http.MethodPost, contentType: "text/plain", body: "hello", status: http.StatusUnsupportedMediaType},
+		{name: "missing content type", method: http.MethodPost, body: `{}`, status: http.StatusUnsupportedMediaType},
+		{name: "malformed content type", method: http.MethodPatch, contentType: "application/json; charset", body: `{}`, status: http.StatusUnsupportedMediaType},
+		{name: "bodyless GET", method: http.MethodGet, status: http.StatusOK},
+		{name: "bodyless DELETE", method: http.MethodDelete, contentType: "text/plain", status: http.StatusOK},
+		{name: "empty POST", method: http.MethodPost, status: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Consumes("application/json", "multipart/form-data")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusUnsupportedMediaType {
+				if accept := rec.Header().Get("Accept"); accept != "application/json, multipart/form-data" {
+					t.Errorf("Expected Accept header to list the consumed media types, got %q", accept)
+				}
+			}
+		})
+	}
+
+	t.Run("no media types", func(t *testing.T) {
+		handler := Consumes()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("hello"))
+		req.Header.Set("Content-Type", "text/plain")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+)
+
+// TrailingSlashOptions configures the TrailingSlash middleware.
+type TrailingSlashOptions struct {
+	// Redirect answers with a 308 to the registered path instead of serving it directly.
+	Redirect bool
+}
+
+// routeMatcher is implemented by *http.ServeMux.
+type routeMatcher interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+// TrailingSlash makes /users and /users/ equivalent. It must wrap the mux directly: when next is a
+// *http.ServeMux, a path matching no route is retried with the trailing slash added or removed, so routes
+// registered either way keep working. Other handlers get the trailing slash removed.
+func TrailingSlash(opts *TrailingSlashOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path, ok := alternatePath(next, r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if opts != nil && opts.Redirect {
+				target := *r.URL
+				target.Path = path
+				target.RawPath = ""
+				http.Redirect(w, r, target.String(), http.StatusPermanentRedirect)
+				return
+			}
+
+			r2 := r.Clone(r.Context())
+			r2.URL.Path = path
+			r2.URL.RawPath = ""
+			next.ServeHTTP(w, r2)
+		})
+	}
+}
+
+// alternatePath returns the path with its trailing slash toggled when it is the one to serve.
+func alternatePath(next http.Handler, r *http.Request) (string, bool) {
+	path := r.URL.Path
+	if path == "/" || path == "" {
+		return "", false
+	}
+
+	matcher, isMatcher := next.(routeMatcher)
+	if !isMatcher {
+		trimmed := strings.TrimRight(path, "/")
+		return trimmed, trimmed != path && trimmed != ""
+	}
+
+	if _, pattern := matcher.Handler(r); pattern != "" {
+		// the mux answers /tree with its own redirect when only the /tree/ route exists
+		if patternPath(pattern) == path+"/" {
+			return path + "/", true
+		}
+		return "", false
+	}
+
+	alternate := path + "/"
+	if strings.HasSuffix(path, "/") {
+		alternate = strings.TrimSuffix(path, "/")
+	}
+	r2 := r.Clone(r.Context())
+	r2.URL.Path = alternate
+	r2.URL.RawPath = ""
+	if _, pattern := matcher.Handler(r2); pattern == "" {
+		return "", false
+	}
+	return alternate, true
+}
+
+// patternPath returns the path of a ServeMux pattern, without its method and host.
+func patternPath(pattern string) string {
+	if _, rest, found := strings.Cut(pattern, " "); found {
+		pattern = strings.TrimLeft(rest, " \t")
+	}
+	if i := strings.IndexByte(pattern, '/'); i > 0 {
+		pattern = pattern[i:]
+	}
+	return pattern
+}
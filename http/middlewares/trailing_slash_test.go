@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTrailingSlash(t *testing.T) {
+	newMux := func() *http.ServeMux {
+		mux := http.NewServeMux()
+		for _, pattern := range []string{"GET /users", "GET /teams/", "GET /{$}"} {
+			mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(pattern + " " + r.URL.String()))
+			})
+		}
+		return mux
+	}
+
+	tests := []struct {
+		name     string
+		redirect bool
+		target   string
+		status   int
+		body     string
+		location string
+	}{
+		{name: "registered path", target: "/users", status: http.StatusOK, body: "GET /users /users"},
+		{name: "trailing slash removed", target: "/users/", status: http.StatusOK, body: "GET /users /users"},
+		{name: "trailing slash added", target: "/teams", status: http.StatusOK, body: "GET /teams/ /teams/"},
+		{name: "query string kept", target: "/users/?page=2&sort=name", status: http.StatusOK, body: "GET /users /users?page=2&sort=name"},
+		{name: "root path", target: "/", status: http.StatusOK, body: "GET /{$} /"},
+		{name: "unknown path", target: "/orders/", status: http.StatusNotFound},
+		{name: "redirect", redirect: true, target: "/users/", status: http.StatusPermanentRedirect, location: "/users"},
+		{name: "redirect with query string", redirect: true, target: "/users/?page=2", status: http.StatusPermanentRedirect, location: "/users?page=2"},
+		{name: "redirect adding the slash", redirect: true, target: "/teams?page=2", status: http.StatusPermanentRedirect, location: "/teams/?page=2"},
+		{name: "redirect not needed", redirect: true, target: "/users", status: http.StatusOK, body: "GET /users /users"},
+		{name: "redirect root path", redirect: true, target: "/", status: http.StatusOK, body: "GET /{$} /"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := TrailingSlash(&TrailingSlashOptions{Redirect: tt.redirect})(newMux())
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d (%s)", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, rec.Body.String())
+			}
+			if location := rec.Header().Get("Location"); location != tt.location {
+				t.Errorf("Expected location %q, got %q", tt.location, location)
+			}
+		})
+	}
+
+	t.Run("other handlers", func(t *testing.T) {
+		var served string
+		handler := TrailingSlash(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			served = r.URL.String()
+		}))
+		for target, expected := range map[string]string{"/users/?page=2": "/users?page=2", "/": "/", "/users": "/users"} {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+			if served != expected {
+				t.Errorf("Expected %s to be served as %s, got %s", target, expected, served)
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package params
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"sync"
+)
+
+// fieldCache maps a struct reflect.Type to its []fieldDescriptor, so tags are parsed once per type.
+var fieldCache sync.Map
+
+// tagValue is the result of a struct tag lookup.
+type tagValue struct {
+	value string
+	ok    bool
+}
+
+func lookupTag(field reflect.StructField, key string) tagValue {
+	value, ok := field.Tag.Lookup(key)
+	return tagValue{value: value, ok: ok}
+}
+
+// fieldDescriptor holds what bindStruct needs to know about an exported struct field.
+type fieldDescriptor struct {
+	index int
+	field reflect.StructField
+
+	embedded    bool       // anonymous struct, its fields are bound as if they were declared by the parent
+	nestedQuery bool       // struct with a query tag, bound from prefixed query keys, see isNestedQueryStruct
+	sources     bindSource // sources read by the tags of the field, see tagSources
+	required    bool
+
+	form, query, queryset, path, headers, cookie, ctx, file, files, defaultValue tagValue
+}
+
+// structFields returns the descriptors of the exported fields of t, computing them on first use.
+func structFields(t reflect.Type) []fieldDescriptor {
+	if fields, ok := fieldCache.Load(t); ok {
+		return fields.([]fieldDescriptor)
+	}
+	fields, _ := fieldCache.LoadOrStore(t, describeFields(t))
+	return fields.([]fieldDescriptor)
+}
+
+// describeFields computes the descriptors of the exported fields of t without using the cache.
+func describeFields(t reflect.Type) []fieldDescriptor {
+	fields := make([]fieldDescriptor, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fd := fieldDescriptor{
+			index:        i,
+			field:        field,
+			embedded:     field.Anonymous && field.Type.Kind() == reflect.Struct,
+			form:         lookupTag(field, "form"),
+			query:        lookupTag(field, "query"),
+			queryset:     lookupTag(field, "queryset"),
+			path:         lookupTag(field, "path"),
+			headers:      lookupTag(field, "headers"),
+			cookie:       lookupTag(field, "cookie"),
+			ctx:          lookupTag(field, "ctx"),
+			file:         lookupTag(field, "file"),
+			files:        lookupTag(field, "files"),
+			defaultValue: lookupTag(field, "default"),
+		}
+		fd.nestedQuery = fd.query.ok && isNestedQueryStruct(field.Type)
+		fd.required, _ = strconv.ParseBool(field.Tag.Get("required"))
+		for tag, source := range tagSources {
+			if _, ok := field.Tag.Lookup(tag); ok {
+				fd.sources |= source
+			}
+		}
+
+		fields = append(fields, fd)
+	}
+	return fields
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// isNestedQueryStruct reports whether t is a struct bound field by field from prefixed query keys,
+// rather than parsed from a single value like time.Time or a TextUnmarshaler.
+func isNestedQueryStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
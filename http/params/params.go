@@ -1,20 +1,33 @@
 // Package params provides functionality for binding HTTP request data to Go structs.
-// It supports binding from JSON, XML, headers, query parameters, form values,
+// It supports binding from JSON, XML, YAML, headers, query parameters, form values,
 // context values, cookies, files, and defaults through struct tags.
+// Other body formats such as MessagePack are decoded by decoders registered with WithBodyDecoder.
+//
+// Bind reads every source. BindQuery, BindBody and BindHeaders only read one, e.g. in a middleware
+// that must not consume the body. They take the same options and return the same errors.
 package params
 
 import (
+	"compress/gzip"
+	"compress/zlib"
 	"encoding"
+	"encoding/base64"
 	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Common errors that may be returned during binding.
@@ -22,6 +35,9 @@ var (
 	ErrInvalidTarget   = errors.New("binding target must be a non-nil pointer to a struct")
 	ErrUnsupportedType = errors.New("unsupported type for binding")
 	ErrFileNotFound    = errors.New("file not found in request")
+	ErrRequired        = errors.New("required value is missing")
+
+	ErrInvalidSourceOrder = errors.New("invalid source order")
 )
 
 // BindingError represents a specific error that occurred during binding.
@@ -40,14 +56,183 @@ func (e *BindingError) Unwrap() error {
 	return e.Err
 }
 
+// BindingErrors holds every error collected by a non-strict binding.
+// It unwraps to its individual errors, so errors.As can extract the *BindingError of a field.
+type BindingErrors []*BindingError
+
+func (e BindingErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e BindingErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// appendBindingError adds err to errs, flattening nested BindingErrors and wrapping
+// errors that are not a *BindingError yet.
+func appendBindingError(errs BindingErrors, err error, field, source string) BindingErrors {
+	var multi BindingErrors
+	if errors.As(err, &multi) {
+		return append(errs, multi...)
+	}
+	var bindErr *BindingError
+	if errors.As(err, &bindErr) {
+		return append(errs, bindErr)
+	}
+	return append(errs, &BindingError{
+		Field:   field,
+		Type:    source,
+		Message: "failed to bind value",
+		Err:     err,
+	})
+}
+
+// ValidationError wraps the error returned by the validator set with WithValidator.
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation error: %s", e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
 // Option represents a configuration option for the Bind function.
 type Option func(*bindOptions)
 
 type bindOptions struct {
 	// Add options here like strict mode, custom tag names, etc.
-	strictMode bool
+	strictMode         bool
+	maxMultipartMemory int64
+	maxFormSize        int64
+	sources            bindSource
+	validator          func(interface{}) error
+	bodyDecoders       []bodyDecoder // registered with WithBodyDecoder, looked up before defaultBodyDecoders
+	disallowUnknown    bool          // reject JSON bodies with fields unknown to the destination
+	lenientBool        bool          // accept on/off, yes/no and y/n as booleans, see parseLenientBool
+	lenientNumbers     bool          // accept underscores and integral scientific notation, see parseLenientInt
+	sourceOrder        []string      // precedence of the field sources, see WithSourceOrder
+	decompressBody     bool          // decode gzip and deflate Content-Encoding, see decompressBody
+	err                error         // invalid option, returned by Bind
+}
+
+// BodyDecoder decodes a request body into v, a pointer to the destination struct.
+type BodyDecoder func(r io.Reader, v interface{}) error
+
+type bodyDecoder struct {
+	contentType string
+	name        string // type of the BindingError returned when decoding fails
+	decode      BodyDecoder
+}
+
+// defaultBodyDecoders are the decoders of the supported body content types. MessagePack has no built-in
+// decoder so this package does not depend on a specific library, one must be registered with WithBodyDecoder.
+var defaultBodyDecoders = []bodyDecoder{
+	{contentType: "application/json", name: "json", decode: decodeJSON},
+	{contentType: "application/yaml", name: "yaml", decode: decodeYAML},
+	{contentType: "text/yaml", name: "yaml", decode: decodeYAML},
+	{contentType: "application/xml", name: "xml", decode: decodeXML},
+	{contentType: "application/msgpack", name: "msgpack"},
 }
 
+// bodyDecoder returns the decoder matching contentType.
+func (o *bindOptions) bodyDecoder(contentType string) (bodyDecoder, bool) {
+	if contentType == "" {
+		return bodyDecoder{}, false
+	}
+	for _, decoders := range [][]bodyDecoder{o.bodyDecoders, defaultBodyDecoders} {
+		for _, decoder := range decoders {
+			if strings.Contains(contentType, decoder.contentType) {
+				if decoder.name == "json" && o.disallowUnknown {
+					decoder.decode = decodeJSONDisallowUnknown
+				}
+				return decoder, true
+			}
+		}
+	}
+	return bodyDecoder{}, false
+}
+
+// bindSource is a set of request parts a binding pass reads from.
+type bindSource uint
+
+const (
+	sourceBody bindSource = 1 << iota // JSON, XML, YAML, form values and files
+	sourceQuery
+	sourcePath
+	sourceHeaders
+	sourceCookies
+	sourceContext
+
+	sourceAll = sourceBody | sourceQuery | sourcePath | sourceHeaders | sourceCookies | sourceContext
+)
+
+func (o *bindOptions) reads(source bindSource) bool {
+	return o.sources&source != 0
+}
+
+// readsForm reports whether form fields are bound. Form values are parsed with the body and also hold
+// the query parameters, they are only read from the query when it is a source too.
+func (o *bindOptions) readsForm(isForm bool) bool {
+	return o.reads(sourceBody) && (isForm || o.reads(sourceQuery))
+}
+
+// readsField reports whether a source of the field is read, see readsForm for form fields.
+func (o *bindOptions) readsField(fd fieldDescriptor, isForm bool) bool {
+	return o.reads(fd.sources) || (fd.form.ok && o.readsForm(isForm))
+}
+
+// tagSources maps binding tags to the source they are read from.
+// Form fields depend on the body content type, see readsForm.
+var tagSources = map[string]bindSource{
+	"json":     sourceBody,
+	"xml":      sourceBody,
+	"yaml":     sourceBody,
+	"file":     sourceBody,
+	"files":    sourceBody,
+	"query":    sourceQuery,
+	"queryset": sourceQuery,
+	"path":     sourcePath,
+	"headers":  sourceHeaders,
+	"cookie":   sourceCookies,
+	"ctx":      sourceContext,
+}
+
+// defaultSourceOrder is the precedence of the field sources, the first one wins.
+var defaultSourceOrder = []string{"form", "query", "queryset", "path", "headers", "cookie", "ctx", "file", "files", "default"}
+
+// sourceErrorTypes is the source reported by the BindingError of each field source.
+var sourceErrorTypes = map[string]string{
+	"form":     "form",
+	"query":    "query",
+	"queryset": "query",
+	"path":     "path",
+	"headers":  "header",
+	"cookie":   "cookie",
+	"ctx":      "context",
+	"file":     "file",
+	"files":    "files",
+	"default":  "default",
+}
+
+const (
+	// DefaultMaxMultipartMemory is the amount of a multipart body kept in memory, the rest is stored on disk.
+	DefaultMaxMultipartMemory = 32 << 20
+	// DefaultMaxFormSize is the maximum size of an application/x-www-form-urlencoded body.
+	DefaultMaxFormSize = 10 << 20
+)
+
 // WithStrictMode enables strict mode where all errors are returned immediately.
 func WithStrictMode(strict bool) Option {
 	return func(o *bindOptions) {
@@ -56,17 +241,144 @@ func WithStrictMode(strict bool) Option {
 
 }
 
+// WithMaxMultipartMemory sets the amount of a multipart/form-data body kept in memory while parsing,
+// parts exceeding it are stored in temporary files. It defaults to DefaultMaxMultipartMemory (32MB).
+func WithMaxMultipartMemory(size int64) Option {
+	return func(o *bindOptions) {
+		o.maxMultipartMemory = size
+	}
+}
+
+// WithMaxFormSize sets the maximum size of an application/x-www-form-urlencoded body.
+// Larger bodies are rejected instead of being truncated.
+func WithMaxFormSize(size int64) Option {
+	return func(o *bindOptions) {
+		o.maxFormSize = size
+	}
+}
+
+// WithValidator sets a validator called with the destination once it is fully bound, e.g.
+// go-playground/validator's Struct method. It is not called when binding fails.
+// Errors it returns are wrapped in a ValidationError.
+func WithValidator(validator func(interface{}) error) Option {
+	return func(o *bindOptions) {
+		o.validator = validator
+	}
+}
+
+// WithBodyDecoder registers a decoder for bodies of the given content type, overriding the built-in one if any:
+//
+//	params.Bind(&req, r, params.WithBodyDecoder("application/msgpack", func(r io.Reader, v interface{}) error {
+//		return msgpack.NewDecoder(r).Decode(v)
+//	}))
+func WithBodyDecoder(contentType string, decode func(io.Reader, interface{}) error) Option {
+	return func(o *bindOptions) {
+		name := contentType
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = strings.TrimPrefix(name[i+1:], "x-")
+		}
+		o.bodyDecoders = append(o.bodyDecoders, bodyDecoder{contentType: contentType, name: name, decode: decode})
+	}
+}
+
+// WithDisallowUnknownFields rejects JSON bodies having fields that don't exist in the destination,
+// instead of ignoring them. Other body formats are not affected.
+func WithDisallowUnknownFields(disallow bool) Option {
+	return func(o *bindOptions) {
+		o.disallowUnknown = disallow
+	}
+}
+
+// WithLenientBool makes booleans accept the tokens sent by HTML checkboxes and some clients:
+// on, yes, y, 1 and true are true, off, no, n, 0, false and the empty string are false (case-insensitive).
+func WithLenientBool(lenient bool) Option {
+	return func(o *bindOptions) {
+		o.lenientBool = lenient
+	}
+}
+
+// WithLenientNumbers makes numbers accept the formats some upstream APIs send: a leading +,
+// underscores between digits (1_000) and, for integers, scientific notation when the value is integral (1e3).
+func WithLenientNumbers(lenient bool) Option {
+	return func(o *bindOptions) {
+		o.lenientNumbers = lenient
+	}
+}
+
+// WithDecompressBody makes bodies sent with a gzip or deflate Content-Encoding be decompressed before
+// they are decoded. Other encodings are left as is.
+func WithDecompressBody(decompress bool) Option {
+	return func(o *bindOptions) {
+		o.decompressBody = decompress
+	}
+}
+
+// WithSourceOrder changes the precedence of the field sources, earlier sources win. The sources and their
+// default order are form, query, queryset, path, headers, cookie, ctx, file, files and default.
+// Sources missing from order keep their default relative order after the listed ones.
+// Unknown or duplicated sources make Bind return an error wrapping ErrInvalidSourceOrder.
+func WithSourceOrder(order []string) Option {
+	return func(o *bindOptions) {
+		resolved := make([]string, 0, len(defaultSourceOrder))
+		for _, source := range order {
+			if _, ok := sourceErrorTypes[source]; !ok {
+				o.err = fmt.Errorf("%w: unknown source %q", ErrInvalidSourceOrder, source)
+				return
+			}
+			if slices.Contains(resolved, source) {
+				o.err = fmt.Errorf("%w: duplicated source %q", ErrInvalidSourceOrder, source)
+				return
+			}
+			resolved = append(resolved, source)
+		}
+		for _, source := range defaultSourceOrder {
+			if !slices.Contains(resolved, source) {
+				resolved = append(resolved, source)
+			}
+		}
+		o.sourceOrder = resolved
+	}
+}
+
 // Bind attempts to bind data from an HTTP request to the destination struct.
 // The destination must be a non-nil pointer to a struct.
 // Binding is performed based on struct tags defined in the destination type.
+// Fields tagged with required:"true" that are still zero once every source and default were tried
+// produce a BindingError wrapping ErrRequired.
 func Bind(dest interface{}, req *http.Request, opts ...Option) error {
+	return bindSources(dest, req, sourceAll, opts)
+}
+
+// BindQuery binds only the query parameters (query tags) of the request, then applies defaults.
+func BindQuery(dest interface{}, req *http.Request, opts ...Option) error {
+	return bindSources(dest, req, sourceQuery, opts)
+}
+
+// BindBody binds only the request body (JSON, XML, YAML, form values and files), then applies defaults.
+func BindBody(dest interface{}, req *http.Request, opts ...Option) error {
+	return bindSources(dest, req, sourceBody, opts)
+}
+
+// BindHeaders binds only the request headers (headers tags), then applies defaults.
+func BindHeaders(dest interface{}, req *http.Request, opts ...Option) error {
+	return bindSources(dest, req, sourceHeaders, opts)
+}
+
+func bindSources(dest interface{}, req *http.Request, sources bindSource, opts []Option) error {
 	// Apply options
 	options := &bindOptions{
-		strictMode: false,
+		strictMode:         false,
+		maxMultipartMemory: DefaultMaxMultipartMemory,
+		maxFormSize:        DefaultMaxFormSize,
+		sources:            sources,
+		sourceOrder:        defaultSourceOrder,
 	}
 	for _, opt := range opts {
 		opt(options)
 	}
+	if options.err != nil {
+		return options.err
+	}
 
 	// Validate destination
 	v := reflect.ValueOf(dest)
@@ -80,35 +392,55 @@ func Bind(dest interface{}, req *http.Request, opts ...Option) error {
 	}
 
 	// Perform binding
-	return bindStruct(v, req, options)
+	if err := bindStruct(v, req, options, ""); err != nil {
+		return err
+	}
+
+	if options.validator != nil {
+		if err := options.validator(dest); err != nil {
+			return &ValidationError{Err: err}
+		}
+	}
+
+	return nil
 }
 
 // bindStruct binds data to a struct based on its tags.
-func bindStruct(v reflect.Value, req *http.Request, opts *bindOptions) error {
+// queryPrefix is prepended to query parameter names, it is set when binding a nested struct
+// from dotted query keys (e.g. filter.min). The body is only decoded at the top level.
+func bindStruct(v reflect.Value, req *http.Request, opts *bindOptions, queryPrefix string) error {
 	t := v.Type()
-	var errs []error
+	var errs BindingErrors
+
+	// First pass: handle the body with the decoder of its content type (JSON, XML, YAML, ...)
+	// The content type is left empty when the body is not a source of this binding, skipping body passes
+	var contentType string
+	if opts.reads(sourceBody) && queryPrefix == "" {
+		contentType = req.Header.Get("Content-Type")
+	}
 
-	// First pass: handle JSON/XML body if appropriate content type
-	contentType := req.Header.Get("Content-Type")
-	if strings.Contains(contentType, "application/json") {
-		if err := bindJSON(v, req); err != nil {
+	// Compressed bodies are decompressed before any body pass when enabled with WithDecompressBody
+	if contentType != "" && opts.decompressBody {
+		if err := decompressBody(req); err != nil {
 			bindErr := &BindingError{
 				Field:   "body",
-				Type:    "json",
-				Message: "failed to bind JSON body",
+				Type:    "encoding",
+				Message: "failed to decompress body",
 				Err:     err,
 			}
 			if opts.strictMode {
 				return bindErr
 			}
 			errs = append(errs, bindErr)
+			contentType = ""
 		}
-	} else if strings.Contains(contentType, "application/xml") {
-		if err := bindXML(v, req); err != nil {
+	}
+	if decoder, ok := opts.bodyDecoder(contentType); ok {
+		if err := bindBody(v, req, decoder); err != nil {
 			bindErr := &BindingError{
-				Field:   "body",
-				Type:    "xml",
-				Message: "failed to bind XML body",
+				Field:   bodyErrorField(err),
+				Type:    decoder.name,
+				Message: fmt.Sprintf("failed to bind %s body", strings.ToUpper(decoder.name)),
 				Err:     err,
 			}
 			if opts.strictMode {
@@ -119,172 +451,245 @@ func bindStruct(v reflect.Value, req *http.Request, opts *bindOptions) error {
 	}
 
 	// Second pass: handle form data (Parse it only once)
-	if strings.Contains(contentType, "multipart/form-data") ||
-		strings.Contains(contentType, "application/x-www-form-urlencoded") {
-		if err := req.ParseMultipartForm(32 << 20); err != nil && !errors.Is(err, http.ErrNotMultipart) {
-			if err := req.ParseForm(); err != nil {
-				bindErr := &BindingError{
-					Field:   "form",
-					Type:    "form",
-					Message: "failed to parse form data",
-					Err:     err,
-				}
-				if opts.strictMode {
-					return bindErr
-				}
-				errs = append(errs, bindErr)
+	isForm := strings.Contains(contentType, "multipart/form-data") ||
+		strings.Contains(contentType, "application/x-www-form-urlencoded")
+	if isForm {
+		if err := parseForm(req, opts); err != nil {
+			bindErr := &BindingError{
+				Field:   "form",
+				Type:    "form",
+				Message: "failed to parse form data",
+				Err:     err,
+			}
+			if opts.strictMode {
+				return bindErr
 			}
+			errs = append(errs, bindErr)
 		}
 	}
 
 	// Third pass: handle individual field bindings
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		fieldValue := v.Field(i)
-
-		// Skip unexported fields
-		if !fieldValue.CanSet() {
-			continue
-		}
+	// Tags are parsed once per type, unexported fields are not described
+	for _, fd := range structFields(t) {
+		field := fd.field
+		fieldValue := v.Field(fd.index)
 
 		// Check for embedded structs
-		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
-			if err := bindStruct(fieldValue, req, opts); err != nil {
+		if fd.embedded {
+			if err := bindStruct(fieldValue, req, opts, queryPrefix); err != nil {
 				if opts.strictMode {
 					return err
 				}
-				errs = append(errs, err)
+				errs = appendBindingError(errs, err, field.Name, "struct")
 			}
 			continue
 		}
 
-		// Process field bindings in a specific order
-		// We need to bind in order of priority because some sources might override others
-		// Priority:
-		// 1. JSON/XML (already handled above)
-		// 2. Form
-		// 3. Query
-		// 4. Headers
-		// 5. Cookies
-		// 6. Context
-		// 7. File/Files (special case)
-		// 8. Default (applies only if value not set from other sources)
-
-		// Try form tag
-		if formTag, ok := field.Tag.Lookup("form"); ok {
-			if err := bindForm(formTag, field, fieldValue, req, opts); err != nil {
+		// Named struct fields with a query tag are bound from prefixed keys (e.g. filter.min)
+		if fd.nestedQuery && opts.reads(sourceQuery) {
+			if err := bindStruct(fieldValue, req, opts, queryPrefix+fd.query.value+"."); err != nil {
 				if opts.strictMode {
 					return err
 				}
-				errs = append(errs, err)
+				errs = appendBindingError(errs, err, field.Name, "query")
 			}
+			continue
 		}
 
-		// Try query tag (only if field is still zero)
-		if queryTag, ok := field.Tag.Lookup("query"); ok && fieldValue.IsZero() {
-			if err := bindQuery(queryTag, field, fieldValue, req, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
+		// Process field bindings in the configured source order, see WithSourceOrder
+		// The body (JSON/XML/YAML) is already decoded above. The first source overrides body values,
+		// the following ones only fill fields that are still zero, so earlier sources take precedence.
+		for n, source := range opts.sourceOrder {
+			if n > 0 && !fieldValue.IsZero() {
+				continue
 			}
-		}
-
-		if pathTag, ok := field.Tag.Lookup("path"); ok && fieldValue.IsZero() {
-			if err := bindPath(pathTag, field, fieldValue, req, opts); err != nil {
+			if err := bindFieldSource(source, fd, fieldValue, req, opts, queryPrefix, isForm); err != nil {
 				if opts.strictMode {
 					return err
 				}
-				errs = append(errs, err)
+				errs = appendBindingError(errs, err, field.Name, sourceErrorTypes[source])
 			}
 		}
 
-		// Try headers tag (only if field is still zero)
-		if headerTag, ok := field.Tag.Lookup("headers"); ok && fieldValue.IsZero() {
-			if err := bindHeader(headerTag, field, fieldValue, req, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
+		// Check required fields once every source and the default had a chance to set them
+		if fd.required && fieldValue.IsZero() && opts.readsField(fd, isForm) {
+			err := &BindingError{
+				Field:   field.Name,
+				Type:    "required",
+				Message: fmt.Sprintf("field %s is required", field.Name),
+				Err:     ErrRequired,
 			}
-		}
-
-		// Try cookie tag (only if field is still zero)
-		if cookieTag, ok := field.Tag.Lookup("cookie"); ok && fieldValue.IsZero() {
-			if err := bindCookie(cookieTag, field, fieldValue, req, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
+			if opts.strictMode {
+				return err
 			}
+			errs = append(errs, err)
 		}
+	}
 
-		// Try ctx tag (only if field is still zero)
-		if ctxTag, ok := field.Tag.Lookup("ctx"); ok && fieldValue.IsZero() {
-			if err := bindContext(ctxTag, field, fieldValue, req, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
-			}
-		}
+	if len(errs) > 0 {
+		return errs
+	}
 
-		// Try file tag (only if field is still zero)
-		if fileTag, ok := field.Tag.Lookup("file"); ok && fieldValue.IsZero() {
-			if err := bindFile(fileTag, field, fieldValue, req, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
-			}
-		}
+	return nil
+}
 
-		// Try files tag (only if field is still zero)
-		if filesTag, ok := field.Tag.Lookup("files"); ok && fieldValue.IsZero() {
-			if err := bindFiles(filesTag, field, fieldValue, req, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
-			}
+// bindFieldSource binds a field from one source of the source order. Fields without the tag of the source are left untouched.
+func bindFieldSource(source string, fd fieldDescriptor, value reflect.Value, req *http.Request, opts *bindOptions, queryPrefix string, isForm bool) error {
+	field := fd.field
+	switch source {
+	case "form":
+		if fd.form.ok && opts.readsForm(isForm) {
+			return bindForm(fd.form.value, field, value, req, opts)
 		}
-
-		// Try default tag (lowest priority, only if field is still zero)
-		if defaultTag, ok := field.Tag.Lookup("default"); ok && fieldValue.IsZero() {
-			if err := bindDefault(defaultTag, field, fieldValue, opts); err != nil {
-				if opts.strictMode {
-					return err
-				}
-				errs = append(errs, err)
-			}
+	case "query":
+		if fd.query.ok && opts.reads(sourceQuery) {
+			return bindQuery(queryPrefix+fd.query.value, field, value, req, opts)
+		}
+	case "queryset":
+		if fd.queryset.ok && opts.reads(sourceQuery) {
+			return bindQueryPresence(queryPrefix+fd.queryset.value, field, value, req)
+		}
+	case "path":
+		if fd.path.ok && opts.reads(sourcePath) {
+			return bindPath(fd.path.value, field, value, req, opts)
+		}
+	case "headers":
+		if fd.headers.ok && opts.reads(sourceHeaders) {
+			return bindHeader(fd.headers.value, field, value, req, opts)
+		}
+	case "cookie":
+		if fd.cookie.ok && opts.reads(sourceCookies) {
+			return bindCookie(fd.cookie.value, field, value, req, opts)
+		}
+	case "ctx":
+		if fd.ctx.ok && opts.reads(sourceContext) {
+			return bindContext(fd.ctx.value, field, value, req, opts)
+		}
+	case "file":
+		if fd.file.ok && opts.reads(sourceBody) {
+			return bindFile(fd.file.value, field, value, req, opts)
+		}
+	case "files":
+		if fd.files.ok && opts.reads(sourceBody) {
+			return bindFiles(fd.files.value, field, value, req, opts)
+		}
+	case "default":
+		if fd.defaultValue.ok {
+			return bindDefault(fd.defaultValue.value, field, value, opts)
 		}
 	}
+	return nil
+}
 
-	if len(errs) > 0 {
-		return errors.Join(errs...)
+// decompressedBody reads a decompressed request body, closing it closes the decompressor and the original body.
+type decompressedBody struct {
+	io.Reader
+	decompressor io.Closer
+	original     io.Closer
+}
+
+func (b *decompressedBody) Close() error {
+	return errors.Join(b.decompressor.Close(), b.original.Close())
+}
+
+// decompressBody replaces a gzip or deflate encoded request body with its decompressed content.
+// The Content-Encoding header is removed so the body is not decompressed twice.
+func decompressBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	var decompressor io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding"))) {
+	case "gzip", "x-gzip":
+		reader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return err
+		}
+		decompressor = reader
+	case "deflate":
+		reader, err := zlib.NewReader(req.Body)
+		if err != nil {
+			return err
+		}
+		decompressor = reader
+	default:
+		return nil
 	}
 
+	req.Body = &decompressedBody{Reader: decompressor, decompressor: decompressor, original: req.Body}
+	req.Header.Del("Content-Encoding")
+	req.ContentLength = -1
 	return nil
 }
 
-// bindJSON binds JSON request body to the struct.
-func bindJSON(v reflect.Value, req *http.Request) error {
-	if req.Body == nil {
-		return nil
+// parseForm parses the request form. Multipart bodies are bounded by the multipart memory limit,
+// urlencoded bodies by the form size limit.
+func parseForm(req *http.Request, opts *bindOptions) error {
+	if strings.Contains(req.Header.Get("Content-Type"), "multipart/form-data") {
+		return req.ParseMultipartForm(opts.maxMultipartMemory)
 	}
 
-	decoder := json.NewDecoder(req.Body)
-	return decoder.Decode(v.Addr().Interface())
+	if req.Form != nil {
+		return nil // already parsed
+	}
+
+	// net/http only lifts its own 10MB cap when the body is a MaxBytesReader
+	if req.Body != nil && opts.maxFormSize > 0 {
+		req.Body = http.MaxBytesReader(nil, req.Body, opts.maxFormSize)
+	}
+	return req.ParseForm()
 }
 
-// bindXML binds XML request body to the struct.
-func bindXML(v reflect.Value, req *http.Request) error {
+// bindBody decodes the request body into the struct with decoder.
+func bindBody(v reflect.Value, req *http.Request, decoder bodyDecoder) error {
 	if req.Body == nil {
 		return nil
 	}
+	if decoder.decode == nil {
+		return fmt.Errorf("%w: no decoder registered for %s, see WithBodyDecoder", ErrUnsupportedType, decoder.contentType)
+	}
+	return decoder.decode(req.Body, v.Addr().Interface())
+}
+
+// decodeJSON decodes a JSON body.
+func decodeJSON(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// decodeJSONDisallowUnknown decodes a JSON body, failing on fields unknown to v.
+func decodeJSONDisallowUnknown(r io.Reader, v interface{}) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// bodyErrorField returns the field a body decoding error is about, or "body" when unknown.
+func bodyErrorField(err error) string {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return typeErr.Field
+	}
+	// encoding/json reports unknown fields as `json: unknown field "name"`
+	if name, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if unquoted, err := strconv.Unquote(name); err == nil {
+			return unquoted
+		}
+	}
+	return "body"
+}
+
+// decodeXML decodes an XML body.
+func decodeXML(r io.Reader, v interface{}) error {
+	return xml.NewDecoder(r).Decode(v)
+}
 
-	decoder := xml.NewDecoder(req.Body)
-	return decoder.Decode(v.Addr().Interface())
+// decodeYAML decodes a YAML body, an empty body is not an error.
+func decodeYAML(r io.Reader, v interface{}) error {
+	if err := yaml.NewDecoder(r).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
 }
 
 // getValueSlice is a common helper for handling exploded values
@@ -301,19 +706,43 @@ func getValueSlice(values []string, exploderTag string, singleValue string) []st
 }
 
 // processSliceValues is a common helper for binding slices
-func processSliceValues(value reflect.Value, values []string, field reflect.StructField) error {
+func processSliceValues(value reflect.Value, values []string, field reflect.StructField, opts *bindOptions) error {
 	if len(values) == 0 {
 		return nil
 	}
 
+	// Byte slices hold a single base64 value rather than a list
+	if isBytes(value.Type()) {
+		return setValueFromString(value, values[0], field, opts)
+	}
+
 	// GenerateHandler a new slice to hold the values
 	slice := reflect.MakeSlice(value.Type(), 0, len(values))
 	elemType := value.Type().Elem()
 
+	// Struct elements are decoded from JSON objects (e.g., items={"id":1}&items={"id":2})
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	jsonElements := isNestedQueryStruct(structType)
+
 	// Add each value to the slice
 	for _, val := range values {
 		newVal := reflect.New(elemType).Elem()
-		if err := setValueFromString(newVal, val, field); err != nil {
+		if jsonElements && strings.HasPrefix(strings.TrimSpace(val), "{") {
+			if err := json.Unmarshal([]byte(val), newVal.Addr().Interface()); err != nil {
+				return &BindingError{
+					Field:   field.Name,
+					Type:    "conversion",
+					Message: "failed to decode JSON element",
+					Err:     err,
+				}
+			}
+			slice = reflect.Append(slice, newVal)
+			continue
+		}
+		if err := setValueFromString(newVal, val, field, opts); err != nil {
 			return err
 		}
 		slice = reflect.Append(slice, newVal)
@@ -323,6 +752,37 @@ func processSliceValues(value reflect.Value, values []string, field reflect.Stru
 	return nil
 }
 
+// processMapValues fills a map from bracketed keys such as filter[status]=open, where name is "filter".
+// The map is allocated when nil, and both keys and values are converted with setValueFromString.
+func processMapValues(value reflect.Value, name string, values url.Values, field reflect.StructField, opts *bindOptions) error {
+	prefix := name + "["
+	mapType := value.Type()
+	for key, vals := range values {
+		if len(vals) == 0 || !strings.HasPrefix(key, prefix) || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		mapKey := key[len(prefix) : len(key)-1]
+		if mapKey == "" || strings.ContainsAny(mapKey, "[]") {
+			continue
+		}
+
+		if value.IsNil() {
+			value.Set(reflect.MakeMap(mapType))
+		}
+
+		keyValue := reflect.New(mapType.Key()).Elem()
+		if err := setValueFromString(keyValue, mapKey, field, opts); err != nil {
+			return err
+		}
+		elemValue := reflect.New(mapType.Elem()).Elem()
+		if err := setValueFromString(elemValue, vals[0], field, opts); err != nil {
+			return err
+		}
+		value.SetMapIndex(keyValue, elemValue)
+	}
+	return nil
+}
+
 // bindHeader binds a value from HTTP headers.
 func bindHeader(tag string, field reflect.StructField, value reflect.Value, req *http.Request, opts *bindOptions) error {
 	headerName := tag
@@ -344,7 +804,7 @@ func bindHeader(tag string, field reflect.StructField, value reflect.Value, req
 		}
 
 		// Process the values
-		if err := processSliceValues(value, headerValues, field); err != nil {
+		if err := processSliceValues(value, headerValues, field, opts); err != nil {
 			return &BindingError{
 				Field:   field.Name,
 				Type:    "header",
@@ -362,7 +822,7 @@ func bindHeader(tag string, field reflect.StructField, value reflect.Value, req
 		return nil // No value found
 	}
 
-	return setValueFromString(value, headerValue, field)
+	return setValueFromString(value, headerValue, field, opts)
 }
 
 // bindQuery binds a value from query parameters.
@@ -391,7 +851,7 @@ func bindQuery(tag string, field reflect.StructField, value reflect.Value, req *
 		paramValues = getValueSlice(paramValues, exploderValue, singleParam)
 
 		// Process all values
-		if err := processSliceValues(value, paramValues, field); err != nil {
+		if err := processSliceValues(value, paramValues, field, opts); err != nil {
 			return &BindingError{
 				Field:   field.Name,
 				Type:    "query",
@@ -403,13 +863,45 @@ func bindQuery(tag string, field reflect.StructField, value reflect.Value, req *
 		return nil
 	}
 
+	// Maps are filled from bracketed keys (e.g., filter[status]=open)
+	if value.Kind() == reflect.Map {
+		if err := processMapValues(value, paramName, query, field, opts); err != nil {
+			return &BindingError{
+				Field:   field.Name,
+				Type:    "query",
+				Message: "failed to set value from query parameter",
+				Err:     err,
+			}
+		}
+		return nil
+	}
+
 	// Regular (non-slice) field
 	paramValue := query.Get(paramName)
 	if paramValue == "" {
 		return nil // No value found
 	}
 
-	return setValueFromString(value, paramValue, field)
+	return setValueFromString(value, paramValue, field, opts)
+}
+
+// bindQueryPresence sets a bool field tagged queryset to true when the query parameter is present,
+// whatever its value, even empty. Like query, param[] is accepted for param.
+func bindQueryPresence(tag string, field reflect.StructField, value reflect.Value, req *http.Request) error {
+	if value.Kind() != reflect.Bool {
+		return &BindingError{
+			Field:   field.Name,
+			Type:    "query",
+			Message: "queryset requires a bool field",
+			Err:     ErrUnsupportedType,
+		}
+	}
+
+	query := req.URL.Query()
+	if query.Has(tag) || query.Has(tag+"[]") {
+		value.SetBool(true)
+	}
+	return nil
 }
 
 // bindPath binds a value from path parameters. It uses the new req.PathValue() method
@@ -438,7 +930,7 @@ func bindPath(tag string, field reflect.StructField, value reflect.Value, req *h
 		pathValues = getValueSlice(pathValues, exploderValue, singleParam)
 
 		// Process all values
-		if err := processSliceValues(value, pathValues, field); err != nil {
+		if err := processSliceValues(value, pathValues, field, opts); err != nil {
 			return &BindingError{
 				Field:   field.Name,
 				Type:    "path",
@@ -456,31 +948,19 @@ func bindPath(tag string, field reflect.StructField, value reflect.Value, req *h
 		return nil // No value found
 	}
 
-	return setValueFromString(value, paramValue, field)
+	return setValueFromString(value, paramValue, field, opts)
 }
 
 // bindForm binds a value from form values.
 func bindForm(tag string, field reflect.StructField, value reflect.Value, req *http.Request, opts *bindOptions) error {
 	// Ensure form is parsed - handle both regular forms and multipart forms
 	if req.Form == nil {
-		if err := req.ParseMultipartForm(32 << 20); err != nil {
-			if err != http.ErrNotMultipart {
-				return &BindingError{
-					Field:   field.Name,
-					Type:    "form",
-					Message: "failed to parse multipart form",
-					Err:     err,
-				}
-			}
-
-			// Not a multipart form, try regular form
-			if err := req.ParseForm(); err != nil {
-				return &BindingError{
-					Field:   field.Name,
-					Type:    "form",
-					Message: "failed to parse form",
-					Err:     err,
-				}
+		if err := parseForm(req, opts); err != nil {
+			return &BindingError{
+				Field:   field.Name,
+				Type:    "form",
+				Message: "failed to parse form",
+				Err:     err,
 			}
 		}
 	}
@@ -515,7 +995,7 @@ func bindForm(tag string, field reflect.StructField, value reflect.Value, req *h
 		formValues = getValueSlice(formValues, exploderValue, singleParam)
 
 		// Process all values
-		if err := processSliceValues(value, formValues, field); err != nil {
+		if err := processSliceValues(value, formValues, field, opts); err != nil {
 			return &BindingError{
 				Field:   field.Name,
 				Type:    "form",
@@ -527,13 +1007,26 @@ func bindForm(tag string, field reflect.StructField, value reflect.Value, req *h
 		return nil
 	}
 
+	// Maps are filled from bracketed keys (e.g., filter[status]=open)
+	if value.Kind() == reflect.Map {
+		if err := processMapValues(value, formName, req.Form, field, opts); err != nil {
+			return &BindingError{
+				Field:   field.Name,
+				Type:    "form",
+				Message: "failed to set value from form parameter",
+				Err:     err,
+			}
+		}
+		return nil
+	}
+
 	// Regular (non-slice) field
 	formValue := req.FormValue(formName)
 	if formValue == "" {
 		return nil // No value found
 	}
 
-	return setValueFromString(value, formValue, field)
+	return setValueFromString(value, formValue, field, opts)
 }
 
 // bindContext binds a value from request context.
@@ -562,7 +1055,7 @@ func bindContext(tag string, field reflect.StructField, value reflect.Value, req
 		}
 	}
 
-	return setValueFromString(value, strVal, field)
+	return setValueFromString(value, strVal, field, opts)
 }
 
 // bindCookie binds a value from cookies.
@@ -573,7 +1066,7 @@ func bindCookie(tag string, field reflect.StructField, value reflect.Value, req
 		return nil // No cookie found
 	}
 
-	return setValueFromString(value, cookie.Value, field)
+	return setValueFromString(value, cookie.Value, field, opts)
 }
 
 // bindFile binds a single file to a multipart.FileHeader field.
@@ -582,7 +1075,7 @@ func bindFile(tag string, field reflect.StructField, value reflect.Value, req *h
 		return nil // Not a multipart form
 	}
 
-	if err := req.ParseMultipartForm(32 << 20); err != nil {
+	if err := req.ParseMultipartForm(opts.maxMultipartMemory); err != nil {
 		return &BindingError{
 			Field:   field.Name,
 			Type:    "file",
@@ -623,7 +1116,7 @@ func bindFiles(tag string, field reflect.StructField, value reflect.Value, req *
 		return nil // Not a multipart form
 	}
 
-	if err := req.ParseMultipartForm(32 << 20); err != nil {
+	if err := req.ParseMultipartForm(opts.maxMultipartMemory); err != nil {
 		return &BindingError{
 			Field:   field.Name,
 			Type:    "files",
@@ -634,12 +1127,14 @@ func bindFiles(tag string, field reflect.StructField, value reflect.Value, req *
 
 	fileName := tag
 
-	// Check if the field is a slice of *multipart.FileHeader
-	if value.Kind() != reflect.Slice || value.Type().Elem() != reflect.TypeOf(&multipart.FileHeader{}) {
+	// Check if the field is a slice of *multipart.FileHeader or a slice of structs holding one
+	isHeaderSlice := value.Kind() == reflect.Slice && value.Type().Elem() == fileHeaderType
+	isStructSlice := value.Kind() == reflect.Slice && value.Type().Elem().Kind() == reflect.Struct
+	if !isHeaderSlice && !isStructSlice {
 		return &BindingError{
 			Field:   field.Name,
 			Type:    "files",
-			Message: "field must be of type []*multipart.FileHeader",
+			Message: "field must be of type []*multipart.FileHeader or a slice of structs",
 			Err:     ErrUnsupportedType,
 		}
 	}
@@ -668,6 +1163,10 @@ func bindFiles(tag string, field reflect.StructField, value reflect.Value, req *
 		}
 	}
 
+	if isStructSlice {
+		return bindFilesWithMetadata(fileName, field, value, req, headers, opts)
+	}
+
 	// GenerateHandler a new slice to hold the file headers
 	slice := reflect.MakeSlice(value.Type(), 0, len(headers))
 	for _, header := range headers {
@@ -678,6 +1177,91 @@ func bindFiles(tag string, field reflect.StructField, value reflect.Value, req *
 	return nil
 }
 
+var (
+	fileHeaderType = reflect.TypeOf(&multipart.FileHeader{})
+	timeType       = reflect.TypeOf(time.Time{})
+)
+
+// bindFilesWithMetadata binds files into a slice of structs, correlating each file with its metadata by index.
+// The *multipart.FileHeader field of the element receives the file, other fields tagged with form are read from
+// the indexed form field name[i][tag] or, when absent, from the i-th value of the parallel form field tag:
+//
+//	type Photo struct {
+//		File    *multipart.FileHeader
+//		Caption string `form:"caption"`
+//		Order   int    `form:"order"`
+//	}
+//
+//	type UploadGalleryRequest struct {
+//		Photos []Photo `files:"photos"` // photos[0][caption]=... or caption[]=...
+//	}
+func bindFilesWithMetadata(name string, field reflect.StructField, value reflect.Value, req *http.Request, headers []*multipart.FileHeader, opts *bindOptions) error {
+	elemType := value.Type().Elem()
+
+	fileIndex := -1
+	for i := 0; i < elemType.NumField(); i++ {
+		if elemType.Field(i).Type == fileHeaderType {
+			fileIndex = i
+			break
+		}
+	}
+	if fileIndex == -1 {
+		return &BindingError{
+			Field:   field.Name,
+			Type:    "files",
+			Message: "slice element must have a *multipart.FileHeader field",
+			Err:     ErrUnsupportedType,
+		}
+	}
+
+	slice := reflect.MakeSlice(value.Type(), len(headers), len(headers))
+	for i, header := range headers {
+		elem := slice.Index(i)
+		elem.Field(fileIndex).Set(reflect.ValueOf(header))
+
+		for j := 0; j < elemType.NumField(); j++ {
+			metaField := elemType.Field(j)
+			formTag, ok := metaField.Tag.Lookup("form")
+			if !ok || j == fileIndex || !elem.Field(j).CanSet() {
+				continue
+			}
+
+			metaValue, found := fileMetadataValue(req, name, formTag, i)
+			if !found {
+				continue
+			}
+			if err := setValueFromString(elem.Field(j), metaValue, metaField, opts); err != nil {
+				return &BindingError{
+					Field:   fmt.Sprintf("%s[%d].%s", field.Name, i, metaField.Name),
+					Type:    "files",
+					Message: "failed to set file metadata",
+					Err:     err,
+				}
+			}
+		}
+	}
+
+	value.Set(slice)
+	return nil
+}
+
+// fileMetadataValue returns the metadata of the index-th file, looking first for name[index][key]
+// then for the index-th value of the parallel key or key[] form field.
+func fileMetadataValue(req *http.Request, name, key string, index int) (string, bool) {
+	if values, ok := req.MultipartForm.Value[fmt.Sprintf("%s[%d][%s]", name, index, key)]; ok && len(values) > 0 {
+		return values[0], true
+	}
+
+	parallel := req.MultipartForm.Value[key]
+	if len(parallel) == 0 {
+		parallel = req.MultipartForm.Value[key+"[]"]
+	}
+	if index < len(parallel) {
+		return parallel[index], true
+	}
+	return "", false
+}
+
 // bindDefault applies a default value from a tag.
 func bindDefault(tag string, field reflect.StructField, value reflect.Value, opts *bindOptions) error {
 	// Don't apply default if the value is already set
@@ -686,7 +1270,7 @@ func bindDefault(tag string, field reflect.StructField, value reflect.Value, opt
 	}
 
 	// For slices with default values
-	if value.Kind() == reflect.Slice {
+	if value.Kind() == reflect.Slice && !isBytes(value.Type()) {
 		defaultValues := strings.Split(tag, ",")
 
 		// GenerateHandler a slice to hold the default values
@@ -701,7 +1285,7 @@ func bindDefault(tag string, field reflect.StructField, value reflect.Value, opt
 			}
 
 			newVal := reflect.New(elemType).Elem()
-			if err := setValueFromString(newVal, dv, field); err != nil {
+			if err := setValueFromString(newVal, dv, field, opts); err != nil {
 				return &BindingError{
 					Field:   field.Name,
 					Type:    "default",
@@ -717,11 +1301,21 @@ func bindDefault(tag string, field reflect.StructField, value reflect.Value, opt
 	}
 
 	// For regular fields
-	return setValueFromString(value, tag, field)
+	return setValueFromString(value, tag, field, opts)
 }
 
 // setValueFromString sets a value from a string based on the field's type.
-func setValueFromString(value reflect.Value, input string, field reflect.StructField) error {
+func setValueFromString(value reflect.Value, input string, field reflect.StructField, opts *bindOptions) error {
+	// Byte slices are base64 encoded, see setBytes
+	if isBytes(value.Type()) {
+		return setBytes(value, input, field)
+	}
+
+	// Times tagged with timeformat are parsed with that layout only
+	if layout, ok := field.Tag.Lookup("timeformat"); ok && (value.Type() == timeType || value.Type() == reflect.PointerTo(timeType)) {
+		return setTimeWithLayout(value, input, layout, field)
+	}
+
 	// Check if the field implements encoding.TextUnmarshaler
 	if value.CanAddr() {
 		ptrVal := value.Addr()
@@ -746,7 +1340,7 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 
 		// Not a TextUnmarshaler, continue with regular handling
 		elemValue := newVal.Elem()
-		if err := setValueFromString(elemValue, input, field); err != nil {
+		if err := setValueFromString(elemValue, input, field, opts); err != nil {
 			return err
 		}
 		value.Set(newVal)
@@ -759,7 +1353,11 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 		value.SetString(input)
 
 	case reflect.Bool:
-		b, err := strconv.ParseBool(input)
+		parseBool := strconv.ParseBool
+		if opts.lenientBool {
+			parseBool = parseLenientBool
+		}
+		b, err := parseBool(input)
 		if err != nil {
 			return &BindingError{
 				Field:   field.Name,
@@ -786,7 +1384,11 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 			return nil
 		}
 
-		i, err := strconv.ParseInt(input, 10, 64)
+		parseInt := func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+		if opts.lenientNumbers {
+			parseInt = parseLenientInt
+		}
+		i, err := parseInt(input)
 		if err != nil {
 			return &BindingError{
 				Field:   field.Name,
@@ -798,7 +1400,11 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 		value.SetInt(i)
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		u, err := strconv.ParseUint(input, 10, 64)
+		parseUint := func(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) }
+		if opts.lenientNumbers {
+			parseUint = parseLenientUint
+		}
+		u, err := parseUint(input)
 		if err != nil {
 			return &BindingError{
 				Field:   field.Name,
@@ -810,7 +1416,11 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 		value.SetUint(u)
 
 	case reflect.Float32, reflect.Float64:
-		f, err := strconv.ParseFloat(input, 64)
+		parseFloat := func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
+		if opts.lenientNumbers {
+			parseFloat = parseLenientFloat
+		}
+		f, err := parseFloat(input)
 		if err != nil {
 			return &BindingError{
 				Field:   field.Name,
@@ -828,7 +1438,7 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 
 		// Set the element value
 		elemValue := newVal.Elem()
-		if err := setValueFromString(elemValue, input, field); err != nil {
+		if err := setValueFromString(elemValue, input, field, opts); err != nil {
 			return err
 		}
 
@@ -887,3 +1497,126 @@ func setValueFromString(value reflect.Value, input string, field reflect.StructF
 
 	return nil
 }
+
+// parseLenientBool parses the boolean tokens accepted with WithLenientBool.
+func parseLenientBool(input string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(input)) {
+	case "on", "yes", "y", "1", "true":
+		return true, nil
+	case "off", "no", "n", "0", "false", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid boolean %q", input)
+}
+
+// parseLenientFloat parses a float accepted with WithLenientNumbers.
+func parseLenientFloat(input string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(input), "_", ""), 64)
+}
+
+// parseLenientInt parses an integer accepted with WithLenientNumbers. Values that are not plain integers
+// are read as floats and only accepted when integral and in range.
+func parseLenientInt(input string) (int64, error) {
+	s := strings.ReplaceAll(strings.TrimSpace(input), "_", "")
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil || !errors.Is(err, strconv.ErrSyntax) {
+		return i, err
+	}
+	f, ferr := strconv.ParseFloat(s, 64)
+	if ferr != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, err
+	}
+	return int64(f), nil
+}
+
+// parseLenientUint parses an unsigned integer accepted with WithLenientNumbers, see parseLenientInt.
+func parseLenientUint(input string) (uint64, error) {
+	s := strings.TrimPrefix(strings.ReplaceAll(strings.TrimSpace(input), "_", ""), "+")
+	u, err := strconv.ParseUint(s, 10, 64)
+	if err == nil || !errors.Is(err, strconv.ErrSyntax) {
+		return u, err
+	}
+	f, ferr := strconv.ParseFloat(s, 64)
+	if ferr != nil || f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, err
+	}
+	return uint64(f), nil
+}
+
+// setUnixTime sets a time.Time or *time.Time value from a Unix timestamp in seconds, or in milliseconds when milli is set.
+func setUnixTime(value reflect.Value, input string, field reflect.StructField, milli bool) error {
+	timestamp, err := strconv.ParseInt(strings.TrimSpace(input), 10, 64)
+	if err != nil {
+		return &BindingError{
+			Field:   field.Name,
+			Type:    "conversion",
+			Message: "failed to parse unix timestamp",
+			Err:     err,
+		}
+	}
+
+	if milli {
+		setTime(value, time.UnixMilli(timestamp).UTC())
+	} else {
+		setTime(value, time.Unix(timestamp, 0).UTC())
+	}
+	return nil
+}
+
+// setTimeWithLayout sets a time.Time or *time.Time value parsed with the layout of a timeformat tag.
+// The unix and unixmilli layouts read Unix timestamps in seconds and milliseconds.
+func setTimeWithLayout(value reflect.Value, input, layout string, field reflect.StructField) error {
+	switch layout {
+	case "unix":
+		return setUnixTime(value, input, field, false)
+	case "unixmilli":
+		return setUnixTime(value, input, field, true)
+	}
+
+	t, err := time.Parse(layout, input)
+	if err != nil {
+		return &BindingError{
+			Field:   field.Name,
+			Type:    "conversion",
+			Message: fmt.Sprintf("failed to parse time with layout %q", layout),
+			Err:     err,
+		}
+	}
+
+	setTime(value, t)
+	return nil
+}
+
+func setTime(value reflect.Value, t time.Time) {
+	if value.Kind() == reflect.Ptr {
+		value.Set(reflect.ValueOf(&t))
+		return
+	}
+	value.Set(reflect.ValueOf(t))
+}
+
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// setBytes sets a []byte value from standard base64, or URL-safe base64 when the field is tagged
+// encoding:"base64url". Padding is optional.
+func setBytes(value reflect.Value, input string, field reflect.StructField) error {
+	encoding := base64.RawStdEncoding
+	if field.Tag.Get("encoding") == "base64url" {
+		encoding = base64.RawURLEncoding
+	}
+
+	b, err := encoding.DecodeString(strings.TrimRight(input, "="))
+	if err != nil {
+		return &BindingError{
+			Field:   field.Name,
+			Type:    "conversion",
+			Message: "failed to decode base64 value",
+			Err:     err,
+		}
+	}
+
+	value.SetBytes(b)
+	return nil
+}
@@ -2,16 +2,22 @@ package params
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 // Define test structs
@@ -122,6 +128,64 @@ func TestBindXML(t *testing.T) {
 	}
 }
 
+type TestConfig struct {
+	Name     string            `yaml:"name"`
+	Replicas int               `yaml:"replicas"`
+	Labels   map[string]string `yaml:"labels"`
+	Region   string            `query:"region" default:"eu-west-1"`
+}
+
+func TestBindYAML(t *testing.T) {
+	for _, contentType := range []string{"application/yaml", "text/yaml"} {
+		t.Run(contentType, func(t *testing.T) {
+			yamlData := `
+name: worker
+replicas: 3
+labels:
+  team: platform
+`
+			req := httptest.NewRequest("POST", "/?region=us-east-1", strings.NewReader(yamlData))
+			req.Header.Set("Content-Type", contentType)
+
+			config := &TestConfig{}
+			if err := Bind(config, req); err != nil {
+				t.Fatalf("Failed to bind YAML: %v", err)
+			}
+
+			if config.Name != "worker" {
+				t.Errorf("Expected Name to be 'worker', got '%s'", config.Name)
+			}
+			if config.Replicas != 3 {
+				t.Errorf("Expected Replicas to be 3, got %d", config.Replicas)
+			}
+			if config.Labels["team"] != "platform" {
+				t.Errorf("Expected Labels[team] to be 'platform', got %v", config.Labels)
+			}
+			if config.Region != "us-east-1" {
+				t.Errorf("Expected Region to be 'us-east-1', got '%s'", config.Region)
+			}
+		})
+	}
+
+	t.Run("malformed YAML", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("name: [worker\nreplicas: three"))
+		req.Header.Set("Content-Type", "application/yaml")
+
+		config := &TestConfig{}
+		err := Bind(config, req)
+		if err == nil {
+			t.Fatal("Expected an error for malformed YAML")
+		}
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) || bindErr.Type != "yaml" {
+			t.Errorf("Expected a yaml BindingError, got %v", err)
+		}
+		if config.Region != "eu-west-1" {
+			t.Errorf("Expected other sources to still be bound, got Region '%s'", config.Region)
+		}
+	})
+}
+
 func TestBindForm(t *testing.T) {
 	// GenerateHandler form data
 	form := url.Values{}
@@ -163,6 +227,42 @@ func TestBindForm(t *testing.T) {
 	}
 }
 
+func TestBindFormMaxSize(t *testing.T) {
+	form := url.Values{}
+	form.Add("name", strings.Repeat("a", 64))
+
+	t.Run("body within the limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		user := &TestUser{}
+		if err := Bind(user, req, WithMaxFormSize(1024)); err != nil {
+			t.Fatalf("Failed to bind form: %v", err)
+		}
+		if user.Name != strings.Repeat("a", 64) {
+			t.Errorf("Expected Name to be bound, got '%s'", user.Name)
+		}
+	})
+
+	t.Run("body exceeding the limit", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		user := &TestUser{}
+		err := Bind(user, req, WithMaxFormSize(16), WithStrictMode(true))
+		if err == nil {
+			t.Fatalf("Expected binding to fail for a body larger than the form size limit")
+		}
+		var maxBytesErr *http.MaxBytesError
+		if !errors.As(err, &maxBytesErr) {
+			t.Errorf("Expected a MaxBytesError, got %v", err)
+		}
+		if user.Name != "" {
+			t.Errorf("Expected Name to stay empty, got '%s'", user.Name)
+		}
+	})
+}
+
 func TestBindQuery(t *testing.T) {
 	// GenerateHandler query parameters
 	req := httptest.NewRequest("GET", "/user?name=Bob+Johnson&age=40&is_active=true&created_at=2023-04-15&tags[]=tag1&tags[]=tag2&tags[]=tag3", nil)
@@ -369,6 +469,146 @@ func TestBindFile(t *testing.T) {
 	}
 }
 
+func TestBindFileMaxMemory(t *testing.T) {
+	newRequest := func() *http.Request {
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		fw, err := w.CreateFormFile("avatar", "avatar.png")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(fw, strings.Repeat("x", 1024)); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		req := httptest.NewRequest("POST", "/", &b)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+
+	// Files exceeding the memory limit are stored in temporary files, opened as *os.File
+	isOnDisk := func(t *testing.T, opts ...Option) bool {
+		user := &struct {
+			Avatar *multipart.FileHeader `file:"avatar"`
+		}{}
+		req := newRequest()
+		if err := Bind(user, req, opts...); err != nil {
+			t.Fatalf("Failed to bind file: %v", err)
+		}
+		defer req.MultipartForm.RemoveAll()
+		f, err := user.Avatar.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		_, onDisk := f.(*os.File)
+		return onDisk
+	}
+
+	if isOnDisk(t) {
+		t.Error("Expected the file to be kept in memory with the default limit")
+	}
+	if !isOnDisk(t, WithMaxMultipartMemory(512)) {
+		t.Error("Expected the file to be stored on disk with a 512 bytes limit")
+	}
+}
+
+type GalleryPhoto struct {
+	File    *multipart.FileHeader
+	Caption string `form:"caption"`
+	Order   int    `form:"order"`
+}
+
+type GalleryUpload struct {
+	Title  string         `form:"title"`
+	Photos []GalleryPhoto `files:"photos"`
+}
+
+func TestBindFilesWithMetadata(t *testing.T) {
+	newRequest := func(t *testing.T, fields [][2]string) *http.Request {
+		var b bytes.Buffer
+		w := multipart.NewWriter(&b)
+		for _, f := range fields {
+			if err := w.WriteField(f[0], f[1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i := 0; i < 2; i++ {
+			fw, err := w.CreateFormFile("photos[]", fmt.Sprintf("photo%d.jpg", i))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := io.WriteString(fw, fmt.Sprintf("photo %d content", i)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w.Close()
+
+		req := httptest.NewRequest("POST", "/", &b)
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		return req
+	}
+
+	t.Run("indexed metadata", func(t *testing.T) {
+		req := newRequest(t, [][2]string{
+			{"title", "Holidays"},
+			{"photos[0][caption]", "Beach"},
+			{"photos[0][order]", "2"},
+			{"photos[1][caption]", "Mountain"},
+			{"photos[1][order]", "1"},
+		})
+
+		upload := &GalleryUpload{}
+		if err := Bind(upload, req); err != nil {
+			t.Fatalf("Failed to bind gallery upload: %v", err)
+		}
+
+		if upload.Title != "Holidays" {
+			t.Errorf("Expected Title to be 'Holidays', got '%s'", upload.Title)
+		}
+		if len(upload.Photos) != 2 {
+			t.Fatalf("Expected 2 photos, got %d", len(upload.Photos))
+		}
+		if upload.Photos[0].File == nil || upload.Photos[0].File.Filename != "photo0.jpg" {
+			t.Errorf("Expected first photo file to be photo0.jpg, got %+v", upload.Photos[0].File)
+		}
+		if upload.Photos[0].Caption != "Beach" || upload.Photos[0].Order != 2 {
+			t.Errorf("Unexpected first photo metadata: %+v", upload.Photos[0])
+		}
+		if upload.Photos[1].Caption != "Mountain" || upload.Photos[1].Order != 1 {
+			t.Errorf("Unexpected second photo metadata: %+v", upload.Photos[1])
+		}
+	})
+
+	t.Run("parallel metadata arrays", func(t *testing.T) {
+		req := newRequest(t, [][2]string{
+			{"caption[]", "Beach"},
+			{"caption[]", "Mountain"},
+			{"order[]", "1"},
+		})
+
+		upload := &GalleryUpload{}
+		if err := Bind(upload, req); err != nil {
+			t.Fatalf("Failed to bind gallery upload: %v", err)
+		}
+
+		if len(upload.Photos) != 2 {
+			t.Fatalf("Expected 2 photos, got %d", len(upload.Photos))
+		}
+		if upload.Photos[0].Caption != "Beach" || upload.Photos[0].Order != 1 {
+			t.Errorf("Unexpected first photo metadata: %+v", upload.Photos[0])
+		}
+		if upload.Photos[1].Caption != "Mountain" || upload.Photos[1].Order != 0 {
+			t.Errorf("Unexpected second photo metadata: %+v", upload.Photos[1])
+		}
+		if upload.Photos[1].File == nil || upload.Photos[1].File.Filename != "photo1.jpg" {
+			t.Errorf("Expected second photo file to be photo1.jpg, got %+v", upload.Photos[1].File)
+		}
+	})
+}
+
 func TestBindCustomUnmarshaler(t *testing.T) {
 	// GenerateHandler query string with custom formatted value
 	req := httptest.NewRequest("GET", "/pagination?page=2&limit=20&orderBy=name:ASC&orderBy=date:DESC", nil)
@@ -420,6 +660,617 @@ func TestBindWithOptions(t *testing.T) {
 	}
 }
 
+func TestTargetedBinding(t *testing.T) {
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest("POST", "/?name=QueryUser&age=20", strings.NewReader(`{"name":"BodyUser","age":40}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-User-Name", "HeaderUser")
+		return req
+	}
+
+	t.Run("BindQuery", func(t *testing.T) {
+		user := &TestUser{}
+		if err := BindQuery(user, newRequest()); err != nil {
+			t.Fatalf("Failed to bind query: %v", err)
+		}
+		if user.Name != "QueryUser" || user.Age != 20 {
+			t.Errorf("Expected query values only, got Name '%s' and Age %d", user.Name, user.Age)
+		}
+		if user.UserID != "guest" {
+			t.Errorf("Expected defaults to be applied, got UserID '%s'", user.UserID)
+		}
+	})
+
+	t.Run("BindBody", func(t *testing.T) {
+		user := &TestUser{}
+		if err := BindBody(user, newRequest()); err != nil {
+			t.Fatalf("Failed to bind body: %v", err)
+		}
+		if user.Name != "BodyUser" || user.Age != 40 {
+			t.Errorf("Expected body values only, got Name '%s' and Age %d", user.Name, user.Age)
+		}
+	})
+
+	t.Run("BindHeaders", func(t *testing.T) {
+		user := &TestUser{}
+		if err := BindHeaders(user, newRequest()); err != nil {
+			t.Fatalf("Failed to bind headers: %v", err)
+		}
+		if user.Name != "HeaderUser" {
+			t.Errorf("Expected header value only, got Name '%s'", user.Name)
+		}
+		if user.Age != 30 {
+			t.Errorf("Expected Age to fall back to its default, got %d", user.Age)
+		}
+	})
+
+	t.Run("errors of other sources are ignored", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/?age=20", strings.NewReader(`{"age":"invalid"}`))
+		req.Header.Set("Content-Type", "application/json")
+
+		user := &TestUser{}
+		if err := BindQuery(user, req, WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected the malformed body to be ignored, got %v", err)
+		}
+		if err := BindBody(&TestUser{}, req, WithStrictMode(true)); err == nil {
+			t.Error("Expected BindBody to fail on the malformed body")
+		}
+	})
+}
+
+func TestBindWithValidator(t *testing.T) {
+	errNameRequired := errors.New("name is required")
+	validator := func(calls *int) func(interface{}) error {
+		return func(v interface{}) error {
+			*calls++
+			if v.(*TestUser).Name == "" {
+				return errNameRequired
+			}
+			return nil
+		}
+	}
+
+	t.Run("passing validator", func(t *testing.T) {
+		var calls int
+		req := httptest.NewRequest("GET", "/?name=Alice", nil)
+		if err := Bind(&TestUser{}, req, WithValidator(validator(&calls))); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected validator to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("failing validator", func(t *testing.T) {
+		var calls int
+		req := httptest.NewRequest("GET", "/", nil)
+		err := Bind(&struct {
+			Name string `query:"name"`
+		}{}, req, WithValidator(func(v interface{}) error {
+			calls++
+			return errNameRequired
+		}))
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected a ValidationError, got %v", err)
+		}
+		if !errors.Is(err, errNameRequired) {
+			t.Errorf("Expected the validator error to be wrapped, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected validator to be called once, got %d", calls)
+		}
+	})
+
+	t.Run("not called when binding fails", func(t *testing.T) {
+		for _, strict := range []bool{true, false} {
+			var calls int
+			req := httptest.NewRequest("GET", "/?age=invalid", nil)
+			err := Bind(&TestUser{}, req, WithStrictMode(strict), WithValidator(validator(&calls)))
+			var bindErr *BindingError
+			if !errors.As(err, &bindErr) {
+				t.Errorf("Strict %v: expected a BindingError, got %v", strict, err)
+			}
+			if calls != 0 {
+				t.Errorf("Strict %v: expected validator not to be called, got %d calls", strict, calls)
+			}
+		}
+	})
+}
+
+func TestBindUnixTime(t *testing.T) {
+	type Range struct {
+		From time.Time  `query:"from" timeformat:"unix"`
+		To   *time.Time `query:"to" timeformat:"unix"`
+	}
+
+	t.Run("valid timestamps", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?from=1700000000&to=1700003600", nil)
+		r := &Range{}
+		if err := Bind(r, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !r.From.Equal(time.Unix(1700000000, 0)) {
+			t.Errorf("Expected From to be %v, got %v", time.Unix(1700000000, 0).UTC(), r.From)
+		}
+		if r.To == nil || !r.To.Equal(time.Unix(1700003600, 0)) {
+			t.Errorf("Expected To to be %v, got %v", time.Unix(1700003600, 0).UTC(), r.To)
+		}
+	})
+
+	t.Run("invalid timestamp", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?from=2023-11-14", nil)
+		err := Bind(&Range{}, req, WithStrictMode(true))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("Expected a BindingError, got %v", err)
+		}
+		if bindErr.Field != "From" {
+			t.Errorf("Expected error on field From, got %s", bindErr.Field)
+		}
+	})
+}
+
+func TestBindDisallowUnknownFields(t *testing.T) {
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+	body := `{"name":"Alice","nmae":"typo"}`
+
+	t.Run("ignored by default", func(t *testing.T) {
+		user := &TestUser{}
+		if err := Bind(user, newRequest(body), WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if user.Name != "Alice" {
+			t.Errorf("Expected name Alice, got %q", user.Name)
+		}
+	})
+
+	t.Run("rejected when disallowed", func(t *testing.T) {
+		err := Bind(&TestUser{}, newRequest(body), WithStrictMode(true), WithDisallowUnknownFields(true))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("Expected a BindingError, got %v", err)
+		}
+		if bindErr.Type != "json" || bindErr.Field != "nmae" {
+			t.Errorf("Expected a json error on field nmae, got type %s and field %s", bindErr.Type, bindErr.Field)
+		}
+	})
+
+	t.Run("other formats are not affected", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("<TestUser><name>Alice</name><nmae>typo</nmae></TestUser>"))
+		req.Header.Set("Content-Type", "application/xml")
+		if err := Bind(&TestUser{}, req, WithStrictMode(true), WithDisallowUnknownFields(true)); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestBindWithBodyDecoder(t *testing.T) {
+	// Stands in for a MessagePack decoder: reads "name=age" bodies
+	var calls int
+	decoder := func(r io.Reader, v interface{}) error {
+		calls++
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		name, age, ok := strings.Cut(string(b), "=")
+		if !ok {
+			return errors.New("invalid body")
+		}
+		user := v.(*TestUser)
+		user.Name = name
+		_, err = fmt.Sscan(age, &user.Age)
+		return err
+	}
+
+	t.Run("custom decoder is used for its content type", func(t *testing.T) {
+		calls = 0
+		req := httptest.NewRequest("POST", "/", strings.NewReader("Alice=30"))
+		req.Header.Set("Content-Type", "application/msgpack")
+		user := &TestUser{}
+		if err := Bind(user, req, WithBodyDecoder("application/msgpack", decoder)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected decoder to be called once, got %d", calls)
+		}
+		if user.Name != "Alice" || user.Age != 30 {
+			t.Errorf("Expected Alice (30), got %s (%d)", user.Name, user.Age)
+		}
+	})
+
+	t.Run("custom decoder is not used for other content types", func(t *testing.T) {
+		calls = 0
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"Bob"}`))
+		req.Header.Set("Content-Type", "application/json")
+		user := &TestUser{}
+		if err := Bind(user, req, WithBodyDecoder("application/msgpack", decoder)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if calls != 0 || user.Name != "Bob" {
+			t.Errorf("Expected the JSON decoder to be used, got %d calls and name %q", calls, user.Name)
+		}
+	})
+
+	t.Run("decoder errors are binding errors", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("invalid"))
+		req.Header.Set("Content-Type", "application/msgpack")
+		err := Bind(&TestUser{}, req, WithStrictMode(true), WithBodyDecoder("application/msgpack", decoder))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) || bindErr.Type != "msgpack" {
+			t.Errorf("Expected a msgpack BindingError, got %v", err)
+		}
+	})
+
+	t.Run("msgpack without decoder", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("Alice=30"))
+		req.Header.Set("Content-Type", "application/msgpack")
+		err := Bind(&TestUser{}, req, WithStrictMode(true))
+		if !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType, got %v", err)
+		}
+	})
+}
+
+func TestBindBase64Bytes(t *testing.T) {
+	type Signed struct {
+		Payload   []byte `query:"payload"`
+		Signature []byte `headers:"X-Signature" encoding:"base64url"`
+	}
+
+	t.Run("valid base64 and base64url", func(t *testing.T) {
+		// 0xfb 0xff encodes as "+/8=" in standard base64 and "-_8" in unpadded base64url
+		req := httptest.NewRequest("GET", "/?payload="+url.QueryEscape("aGVsbG8=")+"&other=1", nil)
+		req.Header.Set("X-Signature", "-_8")
+		s := &Signed{}
+		if err := Bind(s, req, WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if string(s.Payload) != "hello" {
+			t.Errorf("Expected payload hello, got %q", s.Payload)
+		}
+		if !bytes.Equal(s.Signature, []byte{0xfb, 0xff}) {
+			t.Errorf("Expected signature fb ff, got %x", s.Signature)
+		}
+	})
+
+	t.Run("standard alphabet", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?payload="+url.QueryEscape("+/8="), nil)
+		s := &Signed{}
+		if err := Bind(s, req, WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !bytes.Equal(s.Payload, []byte{0xfb, 0xff}) {
+			t.Errorf("Expected payload fb ff, got %x", s.Payload)
+		}
+	})
+
+	t.Run("malformed input", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Signature", "+/8=")
+		err := Bind(&Signed{}, req, WithStrictMode(true))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("Expected a BindingError, got %v", err)
+		}
+		if bindErr.Field != "Signature" {
+			t.Errorf("Expected error on field Signature, got %s", bindErr.Field)
+		}
+	})
+}
+
+func TestBindTimeFormat(t *testing.T) {
+	type Report struct {
+		Day   time.Time  `query:"day" timeformat:"02/01/2006"`
+		Until *time.Time `query:"until" timeformat:"2006-01-02"`
+		At    time.Time  `query:"at"`
+	}
+
+	t.Run("custom layout", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?day=15/01/2006&until=2006-02-01&at=2006-01-15T10:00:00Z", nil)
+		r := &Report{}
+		if err := Bind(r, req, WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if expected := time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC); !r.Day.Equal(expected) {
+			t.Errorf("Expected Day %v, got %v", expected, r.Day)
+		}
+		if expected := time.Date(2006, 2, 1, 0, 0, 0, 0, time.UTC); r.Until == nil || !r.Until.Equal(expected) {
+			t.Errorf("Expected Until %v, got %v", expected, r.Until)
+		}
+		if expected := time.Date(2006, 1, 15, 10, 0, 0, 0, time.UTC); !r.At.Equal(expected) {
+			t.Errorf("Expected At without layout to keep the default parsing, got %v", r.At)
+		}
+	})
+
+	t.Run("value not matching the layout", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?day=2006-01-15", nil)
+		err := Bind(&Report{}, req, WithStrictMode(true))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("Expected a BindingError, got %v", err)
+		}
+		if bindErr.Field != "Day" || !strings.Contains(bindErr.Message, "02/01/2006") {
+			t.Errorf("Expected a layout error on Day, got %v", bindErr)
+		}
+	})
+
+	type Event struct {
+		CreatedAt time.Time  `query:"created_at" timeformat:"unix"`
+		UpdatedAt *time.Time `query:"updated_at" timeformat:"unixmilli"`
+	}
+
+	t.Run("unix and unixmilli", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?created_at=1700000000&updated_at=1700000000123", nil)
+		e := &Event{}
+		if err := Bind(e, req, WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if expected := time.Unix(1700000000, 0); !e.CreatedAt.Equal(expected) {
+			t.Errorf("Expected CreatedAt %v, got %v", expected, e.CreatedAt)
+		}
+		if expected := time.UnixMilli(1700000000123); e.UpdatedAt == nil || !e.UpdatedAt.Equal(expected) {
+			t.Errorf("Expected UpdatedAt %v, got %v", expected, e.UpdatedAt)
+		}
+	})
+
+	for _, query := range []string{"created_at=2023-11-14T22:13:20Z", "updated_at=abc"} {
+		t.Run("non numeric "+query, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?"+query, nil)
+			var bindErr *BindingError
+			if err := Bind(&Event{}, req, WithStrictMode(true)); !errors.As(err, &bindErr) {
+				t.Errorf("Expected a BindingError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBindBracketedMap(t *testing.T) {
+	type Search struct {
+		Filter map[string]string `query:"filter" form:"filter"`
+		Limits map[string]int    `query:"limit"`
+	}
+
+	t.Run("query", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?filter[status]=open&filter[type]=bug&limit[users]=10&filter=ignored", nil)
+		s := &Search{}
+		if err := Bind(s, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expectedFilter := map[string]string{"status": "open", "type": "bug"}
+		if fmt.Sprint(s.Filter) != fmt.Sprint(expectedFilter) {
+			t.Errorf("Expected filter %v, got %v", expectedFilter, s.Filter)
+		}
+		if s.Limits["users"] != 10 {
+			t.Errorf("Expected limit[users] to be 10, got %v", s.Limits)
+		}
+	})
+
+	t.Run("form", func(t *testing.T) {
+		form := url.Values{"filter[status]": {"closed"}}
+		req := httptest.NewRequest("POST", "/", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		s := &Search{}
+		if err := Bind(s, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.Filter["status"] != "closed" {
+			t.Errorf("Expected filter[status] to be closed, got %v", s.Filter)
+		}
+	})
+
+	t.Run("no keys leaves map nil", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/", nil)
+		s := &Search{}
+		if err := Bind(s, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.Filter != nil {
+			t.Errorf("Expected nil filter, got %v", s.Filter)
+		}
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?limit[users]=many", nil)
+		err := Bind(&Search{}, req, WithStrictMode(true))
+		var bindErr *BindingError
+		if !errors.As(err, &bindErr) {
+			t.Fatalf("Expected a BindingError, got %v", err)
+		}
+		if bindErr.Field != "Limits" {
+			t.Errorf("Expected error on field Limits, got %s", bindErr.Field)
+		}
+	})
+}
+
+func TestBindNestedQueryStruct(t *testing.T) {
+	type Range struct {
+		Min int `query:"min"`
+		Max int `query:"max"`
+	}
+	type Filter struct {
+		Status string `query:"status"`
+		Price  Range  `query:"price"`
+		Min    int    `query:"min"`
+	}
+	type Search struct {
+		Min       int       `query:"min"`
+		Filter    Filter    `query:"filter"`
+		CreatedAt time.Time `query:"created_at"`
+	}
+
+	req := httptest.NewRequest("GET", "/?min=3&filter.status=open&filter.min=1&filter.price.min=10&filter.price.max=90&created_at=2024-01-02T00:00:00Z", nil)
+	s := &Search{}
+	if err := Bind(s, req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if s.Min != 3 {
+		t.Errorf("Expected top level min to be 3, got %d", s.Min)
+	}
+	if s.Filter.Status != "open" || s.Filter.Min != 1 {
+		t.Errorf("Expected filter status open and min 1, got %+v", s.Filter)
+	}
+	if s.Filter.Price.Min != 10 || s.Filter.Price.Max != 90 {
+		t.Errorf("Expected filter price range 10-90, got %+v", s.Filter.Price)
+	}
+	if s.CreatedAt.IsZero() {
+		t.Errorf("Expected created_at to be parsed as a single value")
+	}
+
+	t.Run("unprefixed keys do not leak into the nested struct", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?min=5&status=open", nil)
+		s := &Search{}
+		if err := Bind(s, req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if s.Min != 5 {
+			t.Errorf("Expected top level min to be 5, got %d", s.Min)
+		}
+		if s.Filter != (Filter{}) {
+			t.Errorf("Expected filter to stay empty, got %+v", s.Filter)
+		}
+	})
+
+	t.Run("invalid nested value is reported", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?filter.price.min=abc", nil)
+		err := Bind(&Search{}, req)
+		if err == nil {
+			t.Fatal("Expected an error for a non numeric nested value")
+		}
+	})
+}
+func TestBindingErrorsNonStrict(t *testing.T) {
+	type Filters struct {
+		Age   int     `query:"age"`
+		Score float64 `query:"score"`
+		Name  string  `query:"name"`
+	}
+
+	req := httptest.NewRequest("GET", "/?age=old&score=high&name=Alice", nil)
+	f := &Filters{}
+	err := Bind(f, req, WithStrictMode(false))
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	var bindErrs BindingErrors
+	if !errors.As(err, &bindErrs) {
+		t.Fatalf("Expected BindingErrors, got %T", err)
+	}
+	if len(bindErrs) != 2 {
+		t.Fatalf("Expected 2 binding errors, got %d: %v", len(bindErrs), bindErrs)
+	}
+
+	var bindErr *BindingError
+	if !errors.As(err, &bindErr) {
+		t.Fatal("Expected errors.As to extract a BindingError")
+	}
+	if bindErr.Field != "Age" {
+		t.Errorf("Expected first error on field Age, got %s", bindErr.Field)
+	}
+	if bindErrs[1].Field != "Score" {
+		t.Errorf("Expected second error on field Score, got %s", bindErrs[1].Field)
+	}
+	if !strings.Contains(err.Error(), "'Age'") || !strings.Contains(err.Error(), "'Score'") {
+		t.Errorf("Expected error message to mention both fields, got %q", err.Error())
+	}
+	if f.Name != "Alice" {
+		t.Errorf("Expected valid fields to still be bound, got %q", f.Name)
+	}
+}
+
+func TestBindRequired(t *testing.T) {
+	type GetUser struct {
+		ID   string `path:"id" required:"true"`
+		Sort string `query:"sort" required:"true" default:"name"`
+	}
+
+	newRequest := func(id string) *http.Request {
+		req := httptest.NewRequest("GET", "/users/"+id, nil)
+		if id != "" {
+			req.SetPathValue("id", id)
+		}
+		return req
+	}
+
+	t.Run("present", func(t *testing.T) {
+		u := &GetUser{}
+		if err := Bind(u, newRequest("42"), WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u.ID != "42" {
+			t.Errorf("Expected ID 42, got %q", u.ID)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		for _, strict := range []bool{true, false} {
+			err := Bind(&GetUser{}, newRequest(""), WithStrictMode(strict))
+			if !errors.Is(err, ErrRequired) {
+				t.Fatalf("Strict %v: expected ErrRequired, got %v", strict, err)
+			}
+			var bindErr *BindingError
+			if !errors.As(err, &bindErr) || bindErr.Field != "ID" {
+				t.Fatalf("Strict %v: expected a BindingError on ID, got %v", strict, err)
+			}
+			if !strings.Contains(err.Error(), "field ID is required") {
+				t.Errorf("Strict %v: expected a clear message, got %q", strict, err.Error())
+			}
+		}
+	})
+
+	t.Run("satisfied by default", func(t *testing.T) {
+		u := &GetUser{}
+		if err := Bind(u, newRequest("42"), WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u.Sort != "name" {
+			t.Errorf("Expected default sort, got %q", u.Sort)
+		}
+	})
+
+	t.Run("ignored when the source is not bound", func(t *testing.T) {
+		if err := BindHeaders(&GetUser{}, newRequest(""), WithStrictMode(true)); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("ignored when the form is not bound", func(t *testing.T) {
+		type ListUsers struct {
+			Page int    `query:"page"`
+			Name string `form:"name" required:"true"`
+		}
+		u := &ListUsers{}
+		if err := BindQuery(u, httptest.NewRequest("GET", "/?page=1&name=bob", nil), WithStrictMode(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if u.Page != 1 || u.Name != "" {
+			t.Errorf("Expected only the query field to be bound, got %+v", u)
+		}
+		if err := Bind(&ListUsers{}, httptest.NewRequest("GET", "/?page=1", nil)); !errors.Is(err, ErrRequired) {
+			t.Errorf("Expected ErrRequired when the form is bound, got %v", err)
+		}
+	})
+
+	t.Run("accumulates in non-strict mode", func(t *testing.T) {
+		type Search struct {
+			Query string `query:"q" required:"true"`
+			Page  int    `query:"page" required:"true"`
+		}
+		var bindErrs BindingErrors
+		err := Bind(&Search{}, httptest.NewRequest("GET", "/", nil))
+		if !errors.As(err, &bindErrs) || len(bindErrs) != 2 {
+			t.Errorf("Expected 2 required errors, got %v", err)
+		}
+	})
+}
+
 func TestBindingErrors(t *testing.T) {
 	// Invalid target (not a pointer)
 	var invalidUser TestUser
@@ -504,6 +1355,62 @@ func TestCombinedDataSources(t *testing.T) {
 	}
 }
 
+func TestBindFieldCache(t *testing.T) {
+	type Embedded struct {
+		Region string `query:"region"`
+	}
+	type Filter struct {
+		Min int `query:"min"`
+	}
+	type Request struct {
+		Embedded
+		TestUser
+		Filter   Filter `query:"filter"`
+		Token    string `headers:"Authorization" required:"true"`
+		internal string
+	}
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest("GET", "/?region=eu&name=Cached+User&age=35&filter.min=2", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		return req.WithContext(context.WithValue(req.Context(), "userID", "12345"))
+	}
+
+	for _, typ := range []reflect.Type{reflect.TypeOf(Request{}), reflect.TypeOf(Embedded{}), reflect.TypeOf(Filter{}), reflect.TypeOf(TestUser{})} {
+		fieldCache.Delete(typ)
+	}
+
+	uncached := &Request{}
+	if err := Bind(uncached, newRequest()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, ok := fieldCache.Load(reflect.TypeOf(Request{})); !ok {
+		t.Fatal("Expected the descriptors of the request type to be cached")
+	}
+
+	cached := &Request{}
+	if err := Bind(cached, newRequest()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !reflect.DeepEqual(uncached, cached) {
+		t.Errorf("Expected cached and uncached binding to match, got %+v and %+v", uncached, cached)
+	}
+	if cached.Region != "eu" || cached.Name != "Cached User" || cached.Age != 35 || cached.Filter.Min != 2 || cached.UserID != "12345" {
+		t.Errorf("Unexpected binding result %+v", cached)
+	}
+
+	typ := reflect.TypeOf(Request{})
+	if !reflect.DeepEqual(describeFields(typ), structFields(typ)) {
+		t.Errorf("Expected cached descriptors to match freshly computed ones")
+	}
+	for _, fd := range structFields(typ) {
+		if fd.field.Name == "internal" {
+			t.Errorf("Expected unexported fields not to be described")
+		}
+	}
+}
+
 func BenchmarkBindJSON(b *testing.B) {
 	jsonData, _ := json.Marshal(TestUser{
 		Name:      "Benchmark User",
@@ -537,3 +1444,384 @@ func BenchmarkBindCombined(b *testing.B) {
 		_ = Bind(user, req.WithContext(ctx))
 	}
 }
+
+func TestStreamJSON(t *testing.T) {
+	type Row struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	t.Run("elements are processed one at a time", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`[{"id":1,"name":"a"},{"id":2,"name":"b"}]`))
+		var rows []Row
+		err := StreamJSON(req, func(row Row) error {
+			rows = append(rows, row)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(rows) != 2 || rows[0].ID != 1 || rows[1].Name != "b" {
+			t.Errorf("Expected both rows, got %+v", rows)
+		}
+	})
+
+	t.Run("callback error stops the stream", func(t *testing.T) {
+		errStop := errors.New("stop")
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`[{"id":1},{"id":2},{"id":3}]`))
+		var calls int
+		err := StreamJSON(req, func(row Row) error {
+			calls++
+			if row.ID == 2 {
+				return errStop
+			}
+			return nil
+		})
+		if !errors.Is(err, errStop) {
+			t.Errorf("Expected the callback error, got %v", err)
+		}
+		if calls != 2 {
+			t.Errorf("Expected 2 calls, got %d", calls)
+		}
+	})
+
+	t.Run("invalid bodies", func(t *testing.T) {
+		bodies := map[string]error{
+			`{"id":1}`:              ErrNotJSONArray,
+			`[{"id":1},{"id":"x"}]`: nil,
+			`[{"id":1}`:             nil,
+		}
+		for body, expected := range bodies {
+			req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+			err := StreamJSON(req, func(row Row) error { return nil })
+			var bindErr *BindingError
+			if !errors.As(err, &bindErr) {
+				t.Errorf("Body %s: expected a BindingError, got %v", body, err)
+			}
+			if expected != nil && !errors.Is(err, expected) {
+				t.Errorf("Body %s: expected %v, got %v", body, expected, err)
+			}
+		}
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(""))
+		if err := StreamJSON(req, func(row Row) error { return nil }); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
+
+func TestBindLenientBool(t *testing.T) {
+	type Settings struct {
+		Enabled *bool `query:"enabled"`
+	}
+
+	tokens := map[string]bool{
+		"on": true, "ON": true, "yes": true, "Yes": true, "y": true, "1": true, "true": true, "TRUE": true,
+		"off": false, "OFF": false, "no": false, "No": false, "n": false, "0": false, "false": false, "False": false,
+	}
+	for token, expected := range tokens {
+		t.Run(token, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?enabled="+token, nil)
+			s := &Settings{}
+			if err := Bind(s, req, WithLenientBool(true)); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if s.Enabled == nil || *s.Enabled != expected {
+				t.Errorf("Expected %q to be %v, got %v", token, expected, s.Enabled)
+			}
+		})
+	}
+
+	t.Run("empty string is false", func(t *testing.T) {
+		b, err := parseLenientBool("")
+		if err != nil || b {
+			t.Errorf("Expected empty string to be false, got %v (err %v)", b, err)
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?enabled=maybe", nil)
+		if err := Bind(&Settings{}, req, WithLenientBool(true)); err == nil {
+			t.Error("Expected an error for an unknown token")
+		}
+	})
+
+	t.Run("strict by default", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?enabled=on", nil)
+		if err := Bind(&Settings{}, req); err == nil {
+			t.Error("Expected on to be rejected without WithLenientBool")
+		}
+	})
+}
+
+func TestBindLenientNumbers(t *testing.T) {
+	type Numbers struct {
+		Int   *int     `query:"int"`
+		Uint  *uint    `query:"uint"`
+		Float *float64 `query:"float"`
+	}
+
+	valid := []struct {
+		query string
+		int   int
+		uint  uint
+		float float64
+	}{
+		{"int=%2B5&uint=%2B5&float=%2B5.5", 5, 5, 5.5},
+		{"int=1_000&uint=1_000&float=1_000.5", 1000, 1000, 1000.5},
+		{"int=1e3&uint=1e3&float=1e3", 1000, 1000, 1000},
+		{"int=-2.5e1&uint=2.5e1&float=-2.5e1", -25, 25, -25},
+	}
+	for _, tc := range valid {
+		t.Run(tc.query, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?"+tc.query, nil)
+			n := &Numbers{}
+			if err := Bind(n, req, WithLenientNumbers(true)); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if n.Int == nil || *n.Int != tc.int {
+				t.Errorf("Expected int %d, got %v", tc.int, n.Int)
+			}
+			if n.Uint == nil || *n.Uint != tc.uint {
+				t.Errorf("Expected uint %d, got %v", tc.uint, n.Uint)
+			}
+			if n.Float == nil || *n.Float != tc.float {
+				t.Errorf("Expected float %v, got %v", tc.float, n.Float)
+			}
+		})
+	}
+
+	invalid := []string{"int=1.5", "int=1e-1", "int=1e30", "uint=-1", "uint=-1e2", "float=abc"}
+	for _, query := range invalid {
+		t.Run("invalid "+query, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?"+query, nil)
+			if err := Bind(&Numbers{}, req, WithLenientNumbers(true)); err == nil {
+				t.Errorf("Expected an error for %s", query)
+			}
+		})
+	}
+
+	t.Run("strict by default", func(t *testing.T) {
+		for _, query := range []string{"int=1e3", "int=1_000", "uint=%2B5"} {
+			req := httptest.NewRequest("GET", "/?"+query, nil)
+			if err := Bind(&Numbers{}, req); err == nil {
+				t.Errorf("Expected %s to be rejected without WithLenientNumbers", query)
+			}
+		}
+	})
+}
+
+func TestBindWithSourceOrder(t *testing.T) {
+	type Request struct {
+		Name string `form:"name" query:"name" headers:"X-Name"`
+	}
+
+	newRequest := func() *http.Request {
+		form := url.Values{"name": {"from-form"}}
+		req := httptest.NewRequest("POST", "/?name=from-query", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Name", "from-header")
+		return req
+	}
+
+	tests := []struct {
+		name     string
+		order    []string
+		expected string
+	}{
+		{"default order", nil, "from-form"},
+		{"query over form", []string{"query", "form"}, "from-query"},
+		{"headers first", []string{"headers"}, "from-header"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var opts []Option
+			if tc.order != nil {
+				opts = append(opts, WithSourceOrder(tc.order))
+			}
+			r := &Request{}
+			if err := Bind(r, newRequest(), opts...); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if r.Name != tc.expected {
+				t.Errorf("Expected name %q, got %q", tc.expected, r.Name)
+			}
+		})
+	}
+
+	for _, order := range [][]string{{"query", "body"}, {"query", "query"}} {
+		t.Run(fmt.Sprintf("invalid %v", order), func(t *testing.T) {
+			err := Bind(&Request{}, newRequest(), WithSourceOrder(order))
+			if !errors.Is(err, ErrInvalidSourceOrder) {
+				t.Errorf("Expected ErrInvalidSourceOrder, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBindJSONSliceElements(t *testing.T) {
+	type Item struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type Request struct {
+		Items    []Item   `query:"items"`
+		Pointers []*Item  `query:"pointers"`
+		IDs      []int    `query:"ids"`
+		Names    []string `query:"names"`
+	}
+
+	query := url.Values{
+		"items":    {`{"id":1,"name":"a"}`, `{"id":2}`},
+		"pointers": {`{"id":3}`},
+		"ids":      {"4", "5"},
+		"names":    {`{"not":"decoded"}`},
+	}
+	req := httptest.NewRequest("GET", "/?"+query.Encode(), nil)
+	r := &Request{}
+	if err := Bind(r, req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(r.Items) != 2 || r.Items[0] != (Item{ID: 1, Name: "a"}) || r.Items[1] != (Item{ID: 2}) {
+		t.Errorf("Expected two decoded items, got %+v", r.Items)
+	}
+	if len(r.Pointers) != 1 || r.Pointers[0] == nil || r.Pointers[0].ID != 3 {
+		t.Errorf("Expected a decoded pointer item, got %+v", r.Pointers)
+	}
+	if fmt.Sprint(r.IDs) != "[4 5]" {
+		t.Errorf("Expected primitive slices to be unaffected, got %v", r.IDs)
+	}
+	if len(r.Names) != 1 || r.Names[0] != `{"not":"decoded"}` {
+		t.Errorf("Expected string slices to keep raw values, got %v", r.Names)
+	}
+
+	t.Run("invalid JSON element", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?"+url.Values{"items": {`{"id":"x"}`}}.Encode(), nil)
+		var bindErr *BindingError
+		if err := Bind(&Request{}, req, WithStrictMode(true)); !errors.As(err, &bindErr) {
+			t.Errorf("Expected a BindingError, got %v", err)
+		}
+	})
+}
+
+func TestBindDecompressBody(t *testing.T) {
+	type Payload struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	gzipped := func(s string) *bytes.Buffer {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(s))
+		w.Close()
+		return &buf
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", gzipped(`{"name":"John","age":30}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+
+		p := &Payload{}
+		if err := Bind(p, req, WithDecompressBody(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if p.Name != "John" || p.Age != 30 {
+			t.Errorf("Expected John/30, got %s/%d", p.Name, p.Age)
+		}
+		if req.Header.Get("Content-Encoding") != "" {
+			t.Errorf("Expected Content-Encoding to be removed, got %q", req.Header.Get("Content-Encoding"))
+		}
+		if err := req.Body.Close(); err != nil {
+			t.Errorf("Expected body to close, got %v", err)
+		}
+	})
+
+	t.Run("deflate", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		w.Write([]byte(`{"name":"Jane","age":25}`))
+		w.Close()
+
+		req := httptest.NewRequest("POST", "/", &buf)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "deflate")
+
+		p := &Payload{}
+		if err := Bind(p, req, WithDecompressBody(true)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if p.Name != "Jane" || p.Age != 25 {
+			t.Errorf("Expected Jane/25, got %s/%d", p.Name, p.Age)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", gzipped(`{"name":"John"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+
+		if err := Bind(&Payload{}, req); err == nil {
+			t.Error("Expected an error decoding a compressed body without WithDecompressBody")
+		}
+	})
+
+	t.Run("invalid gzip", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"John"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Content-Encoding", "gzip")
+
+		var bindErr *BindingError
+		if err := Bind(&Payload{}, req, WithDecompressBody(true), WithStrictMode(true)); !errors.As(err, &bindErr) || bindErr.Type != "encoding" {
+			t.Errorf("Expected an encoding BindingError, got %v", err)
+		}
+	})
+}
+
+func TestBindQueryPresence(t *testing.T) {
+	type Request struct {
+		Filter    string `query:"filter"`
+		HasFilter bool   `queryset:"filter"`
+		HasTags   bool   `queryset:"tags"`
+		HasSort   bool   `queryset:"sort"`
+	}
+
+	req := httptest.NewRequest("GET", "/?filter=&tags[]=a", nil)
+	r := &Request{}
+	if err := Bind(r, req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !r.HasFilter {
+		t.Error("Expected HasFilter to be true for an empty filter parameter")
+	}
+	if !r.HasTags {
+		t.Error("Expected HasTags to be true for tags[]")
+	}
+	if r.HasSort {
+		t.Error("Expected HasSort to be false without sort parameter")
+	}
+
+	t.Run("BindQuery", func(t *testing.T) {
+		r := &Request{}
+		if err := BindQuery(r, httptest.NewRequest("GET", "/?sort=name", nil)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !r.HasSort || r.HasFilter {
+			t.Errorf("Expected only HasSort to be true, got %+v", r)
+		}
+	})
+
+	t.Run("non bool field", func(t *testing.T) {
+		type Invalid struct {
+			Filter string `queryset:"filter"`
+		}
+		err := Bind(&Invalid{}, httptest.NewRequest("GET", "/?filter=1", nil), WithStrictMode(true))
+		if !errors.Is(err, ErrUnsupportedType) {
+			t.Errorf("Expected ErrUnsupportedType, got %v", err)
+		}
+	})
+}
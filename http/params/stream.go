@@ -0,0 +1,67 @@
+package params
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ErrNotJSONArray is returned by StreamJSON when the body is not a JSON array.
+var ErrNotJSONArray = errors.New("request body is not a JSON array")
+
+// StreamJSON decodes a JSON array request body one element at a time and calls fn with each of them,
+// so large bulk payloads are processed with bounded memory. It stops at the first error returned by fn,
+// which is returned as is. Decoding errors are wrapped in a BindingError.
+//
+//	err := params.StreamJSON(r, func(item ImportRow) error {
+//		return importer.Add(ctx, item)
+//	})
+func StreamJSON[T any](req *http.Request, fn func(item T) error) error {
+	if req.Body == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(req.Body)
+	token, err := decoder.Token()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return streamError(err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return streamError(ErrNotJSONArray)
+	}
+
+	for index := 0; decoder.More(); index++ {
+		var item T
+		if err := decoder.Decode(&item); err != nil {
+			return &BindingError{
+				Field:   fmt.Sprintf("body[%d]", index),
+				Type:    "json",
+				Message: "failed to decode JSON array element",
+				Err:     err,
+			}
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+
+	// Consume the closing bracket so truncated bodies are reported
+	if _, err := decoder.Token(); err != nil {
+		return streamError(err)
+	}
+	return nil
+}
+
+func streamError(err error) error {
+	return &BindingError{
+		Field:   "body",
+		Type:    "json",
+		Message: "failed to stream JSON array",
+		Err:     err,
+	}
+}